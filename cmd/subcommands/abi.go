@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/spf13/cobra"
+)
+
+var sigDBFile string
+
+func abiSub() []*cobra.Command {
+	cmdLookup := &cobra.Command{
+		Use:   "lookup <SELECTOR|TOPIC|CALLDATA>",
+		Short: "Resolve a 4-byte selector, event topic or call data offline",
+		Long: `Resolve hex input against the embedded signature database.
+
+A 4-byte value is looked up as a function selector, a 32-byte value as an
+event topic0, and anything longer is decoded as call data. Use --db to load
+extra signatures from a file with "function <sig>" / "event <sig>" lines.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			db := abi.DefaultSignatureDB()
+			if sigDBFile != "" {
+				f, err := os.Open(sigDBFile)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				if err := db.Load(f); err != nil {
+					return fmt.Errorf("%s: %w", sigDBFile, err)
+				}
+			}
+
+			data, err := common.FromHex(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid hex input: %w", err)
+			}
+
+			result := make(map[string]interface{})
+			switch {
+			case len(data) == 4:
+				result["selector"] = common.BytesToHexString(data)
+				result["functions"] = db.LookupSelector(data)
+			case len(data) == 32:
+				result["topic"] = common.BytesToHexString(data)
+				result["events"] = db.LookupEvent(data)
+			case len(data) > 4:
+				call, err := db.DecodeCalldata(data)
+				if err != nil {
+					return err
+				}
+				values := make([]string, len(call.Args))
+				for i, arg := range call.Args {
+					values[i] = formatABIValue(arg)
+				}
+				result["selector"] = common.BytesToHexString(data[:4])
+				result["method"] = call.Signature
+				result["args"] = values
+			default:
+				return fmt.Errorf("input too short: %d bytes", len(data))
+			}
+
+			if noPrettyOutput {
+				for _, key := range []string{"functions", "events"} {
+					if sigs, ok := result[key].([]string); ok {
+						fmt.Println(strings.Join(sigs, "\n"))
+						return nil
+					}
+				}
+				fmt.Printf("%s %s\n", result["method"], strings.Join(result["args"].([]string), " "))
+				return nil
+			}
+
+			asJSON, _ := json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+	cmdLookup.Flags().StringVar(&sigDBFile, "db", "", "file with additional signatures to load")
+
+	return []*cobra.Command{cmdLookup}
+}
+
+// formatABIValue renders a decoded argument for display; byte values are
+// shown as 0x-prefixed hex, everything else through its String form.
+func formatABIValue(v interface{}) string {
+	switch val := v.(type) {
+	case []byte:
+		return common.BytesToHexString(val)
+	case [32]byte:
+		return common.BytesToHexString(val[:])
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+func init() {
+	cmdABI := &cobra.Command{
+		Use:   "abi",
+		Short: "Offline ABI helpers",
+		Long:  "Decode selectors, event topics and call data without a contract ABI",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmdABI.AddCommand(abiSub()...)
+	RootCmd.AddCommand(cmdABI)
+}
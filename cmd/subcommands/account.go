@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"os"
 	"strconv"
 	"strings"
 
@@ -14,7 +15,9 @@ import (
 	"github.com/fbsobreira/gotron-sdk/pkg/common"
 	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	signing "github.com/fbsobreira/gotron-sdk/pkg/signer"
 	"github.com/fbsobreira/gotron-sdk/pkg/store"
+	"github.com/fbsobreira/gotron-sdk/pkg/typeddata"
 	"github.com/spf13/cobra"
 )
 
@@ -136,7 +139,7 @@ func accountActivateCmd() *cobra.Command {
 }
 
 func accountSendCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:     "send <ADDRESS_TO> <AMOUNT>",
 		Short:   "send TRX to an address",
 		Args:    cobra.ExactArgs(2),
@@ -157,6 +160,10 @@ func accountSendCmd() *cobra.Command {
 				return err
 			}
 
+			if estimate {
+				return printCostEstimate(tx.Transaction)
+			}
+
 			var ctrlr *transaction.Controller
 			if useLedgerWallet {
 				account := keystore.Account{Address: signerAddress.GetAddress()}
@@ -195,6 +202,8 @@ func accountSendCmd() *cobra.Command {
 			return nil
 		},
 	}
+	cmd.Flags().BoolVar(&estimate, "estimate", false, "estimate the transaction cost without sending it")
+	return cmd
 }
 
 func accountAddressCmd() *cobra.Command {
@@ -817,19 +826,31 @@ func accountSignCmd() *cobra.Command {
 				message = common.Keccak256([]byte(message))
 			}
 
-			var signature []byte
+			var s signing.Signer
 			if useLedgerWallet {
-				// TODO:
-				// account := keystore.Account{Address: signerAddress.GetAddress()}
-			} else {
-				ks, acct, err := store.UnlockedKeystore(signerAddress.String(), passphrase)
+				ledgerSigner, err := signing.NewLedgerSigner()
 				if err != nil {
 					return err
 				}
-				signature, err = ks.Wallets()[0].SignText(*acct, []byte(message), useFixedLength)
+				s = ledgerSigner
+			} else {
+				ks, acct, err := store.UnlockedKeystore(signerAddress.String(), passphrase)
 				if err != nil {
 					return err
 				}
+				s = signing.NewKeystoreSigner(ks, *acct)
+			}
+			ms, ok := s.(signing.MessageSigner)
+			if !ok {
+				return fmt.Errorf("signer cannot sign messages")
+			}
+			format := signing.MessageV2
+			if useFixedLength {
+				format = signing.MessageV1
+			}
+			signature, err := ms.SignMessage(message, format)
+			if err != nil {
+				return err
 			}
 
 			result := make(map[string]interface{})
@@ -886,6 +907,95 @@ func accountVerifyCmd() *cobra.Command {
 	return cmd
 }
 
+// readTypedData parses the TIP-712 JSON document in path.
+func readTypedData(path string) (*typeddata.TypedData, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return typeddata.Parse(data)
+}
+
+func accountSignTypedCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "sign-typed <TYPED_DATA_FILE>",
+		Short: "sign TIP-712 typed structured data",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if signerAddress.String() == "" {
+				return fmt.Errorf("no signer specified")
+			}
+			if useLedgerWallet {
+				return fmt.Errorf("TIP-712 signing is not supported with ledger")
+			}
+			td, err := readTypedData(args[0])
+			if err != nil {
+				return err
+			}
+			hash, err := td.Hash()
+			if err != nil {
+				return err
+			}
+
+			ks, acct, err := store.UnlockedKeystore(signerAddress.String(), passphrase)
+			if err != nil {
+				return err
+			}
+			signature, err := typeddata.Sign(td, signing.NewKeystoreSigner(ks, *acct))
+			if err != nil {
+				return err
+			}
+
+			result := make(map[string]interface{})
+			result["Signer"] = signerAddress.String()
+			result["PrimaryType"] = td.PrimaryType
+			result["Hash"] = hex.EncodeToString(hash)
+			result["Signature"] = hex.EncodeToString(signature)
+			asJSON, _ := json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+}
+
+func accountVerifyTypedCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify-typed <TYPED_DATA_FILE> <SIGNATURE> [ADDRESS]",
+		Short: "recover the signer of TIP-712 typed data, optionally checking it against an address",
+		Args:  cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			td, err := readTypedData(args[0])
+			if err != nil {
+				return err
+			}
+			signature, err := common.FromHex(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid signature: %w", err)
+			}
+			addr, err := typeddata.Recover(td, signature)
+			if err != nil {
+				return err
+			}
+
+			result := make(map[string]interface{})
+			result["PrimaryType"] = td.PrimaryType
+			result["Signature"] = args[1]
+			result["Signer"] = addr.String()
+			if len(args) == 3 {
+				valid, err := typeddata.Verify(td, signature, args[2])
+				if err != nil {
+					return err
+				}
+				result["Valid"] = valid
+			}
+
+			asJSON, _ := json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+}
+
 func accountSub() []*cobra.Command {
 	return []*cobra.Command{
 		accountBalanceCmd(),
@@ -901,6 +1011,8 @@ func accountSub() []*cobra.Command {
 		accountPermissionCmd(),
 		accountSignCmd(),
 		accountVerifyCmd(),
+		accountSignTypedCmd(),
+		accountVerifyTypedCmd(),
 	}
 }
 
@@ -1,18 +1,24 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math"
 	"math/big"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/fbsobreira/gotron-sdk/pkg/abi"
 	"github.com/fbsobreira/gotron-sdk/pkg/address"
 	"github.com/fbsobreira/gotron-sdk/pkg/client/transaction"
 	"github.com/fbsobreira/gotron-sdk/pkg/common"
 	"github.com/fbsobreira/gotron-sdk/pkg/contract"
+	"github.com/fbsobreira/gotron-sdk/pkg/contract/bind"
+	"github.com/fbsobreira/gotron-sdk/pkg/contract/proxy"
 	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
 	"github.com/fbsobreira/gotron-sdk/pkg/store"
@@ -26,21 +32,40 @@ var (
 	bcSTR             string
 	bcFile            string
 	feeLimit          int64
+	autoFeeLimit      float64
 	curPercent        int64
 	oeLimit           int64
 	tAmount           float64
 	tTokenID          string
 	tTokenAmount      float64
 	estimate          bool
+	estimateCost      bool
 	constructorParams string
+	artifactFile      string
+	deployArgs        []string
+	deployLinks       []string
+	bindPkg           string
+	bindType          string
+	bindOut           string
 )
 
 func contractDeployCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "deploy <CONTRACT_NAME>",
+		Use:   "deploy [CONTRACT_NAME]",
 		Short: "deploy smart contract",
-		Args:  cobra.ExactArgs(1),
+		Long: `Deploy a smart contract from an ABI and bytecode, or from a Hardhat,
+Foundry or TronBox artifact file with --artifact. The contract name defaults
+to the artifact's contract name.`,
+		Args: cobra.RangeArgs(0, 1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			var artifact *contract.Artifact
+			if artifactFile != "" {
+				var err error
+				if artifact, err = contract.LoadArtifactFile(artifactFile); err != nil {
+					return err
+				}
+				abiSTR, bcSTR = artifact.ABI, artifact.Bytecode
+			}
 
 			if abiSTR == "" {
 				if abiFile != "" {
@@ -73,6 +98,9 @@ func contractDeployCmd() *cobra.Command {
 			if signerAddress.String() == "" {
 				return fmt.Errorf("no signer specified")
 			}
+			if constructorParams != "" && len(deployArgs) > 0 {
+				return fmt.Errorf("use either --params or --args, not both")
+			}
 
 			// Encode constructor arguments if provided
 			if constructorParams != "" {
@@ -104,8 +132,39 @@ func contractDeployCmd() *cobra.Command {
 				}
 			}
 
-			tx, err := conn.DeployContract(signerAddress.String(), args[0],
-				ABI, bcSTR, feeLimit, curPercent, oeLimit)
+			deployment := contract.Deploy(conn, abiSTR, bcSTR)
+			if artifact != nil {
+				// Keeps the artifact's link references; --params appends
+				// after them.
+				artifact.Bytecode = bcSTR
+				deployment = artifact.Deploy(conn)
+			}
+			if len(args) > 0 {
+				deployment.Name(args[0])
+			} else if artifact == nil {
+				return fmt.Errorf("contract name required")
+			}
+			if len(deployArgs) > 0 {
+				values, err := parseConstructorArgs(abiSTR, deployArgs)
+				if err != nil {
+					return err
+				}
+				deployment.Args(values...)
+			}
+			for _, l := range deployLinks {
+				name, addr, ok := strings.Cut(l, "=")
+				if !ok {
+					return fmt.Errorf("invalid --link %q, expected NAME=ADDRESS", l)
+				}
+				deployment.Link(name, addr)
+			}
+
+			tx, err := deployment.
+				From(signerAddress.String()).
+				WithFeeLimit(feeLimit).
+				WithConsumeUserResourcePercent(curPercent).
+				WithOriginEnergyLimit(oeLimit).
+				Build(context.Background())
 			if err != nil {
 				return err
 			}
@@ -162,6 +221,9 @@ func contractDeployCmd() *cobra.Command {
 	cmd.Flags().StringVar(&bcSTR, "bc", "", "bytecode HEX string")
 	cmd.Flags().StringVar(&bcFile, "bcFile", "", "bytecode file location")
 	cmd.Flags().StringVar(&constructorParams, "params", "", "constructor parameters as JSON (e.g. '[1000000]')")
+	cmd.Flags().StringVar(&artifactFile, "artifact", "", "Hardhat, Foundry or TronBox artifact JSON file")
+	cmd.Flags().StringArrayVar(&deployArgs, "args", nil, "constructor argument, repeat once per parameter in order (e.g. --args MyToken --args 1000000); arrays as JSON")
+	cmd.Flags().StringArrayVar(&deployLinks, "link", nil, "library address as NAME=ADDRESS, repeat for more libraries")
 	cmd.Flags().Int64Var(&feeLimit, "feeLimit", 1000000000, "fee limit")
 	cmd.Flags().Int64Var(&curPercent, "curPercent", 100, "consume user resource percentage")
 	cmd.Flags().Int64Var(&oeLimit, "oeLimit", 1000000, "origin energy limit")
@@ -169,6 +231,48 @@ func contractDeployCmd() *cobra.Command {
 	return cmd
 }
 
+// parseConstructorArgs converts command-line constructor arguments to values
+// for the constructor's parameter types. Booleans and bytes are parsed here;
+// arrays are given as JSON; everything else is left to abi.ConvertValue.
+func parseConstructorArgs(abiJSON string, values []string) ([]interface{}, error) {
+	parsed, err := abi.ParseABI(abiJSON)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse ABI: %v", err)
+	}
+	inputs := parsed.Constructor.Inputs
+	if len(values) != len(inputs) {
+		return nil, fmt.Errorf("constructor expects %d arguments, got %d", len(inputs), len(values))
+	}
+	out := make([]interface{}, len(values))
+	for i, v := range values {
+		switch inputs[i].Type.T {
+		case eABI.BoolTy:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("argument %d: %w", i, err)
+			}
+			out[i] = b
+		case eABI.BytesTy, eABI.FixedBytesTy:
+			b, err := common.FromHex(v)
+			if err != nil {
+				return nil, fmt.Errorf("argument %d: %w", i, err)
+			}
+			out[i] = b
+		case eABI.SliceTy, eABI.ArrayTy:
+			dec := json.NewDecoder(strings.NewReader(v))
+			dec.UseNumber()
+			var arr []interface{}
+			if err := dec.Decode(&arr); err != nil {
+				return nil, fmt.Errorf("argument %d: expected JSON array: %w", i, err)
+			}
+			out[i] = arr
+		default:
+			out[i] = v
+		}
+	}
+	return out, nil
+}
+
 func contractConstantCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "constant <CONTRACT_ADDRESS> <METHOD> [PARAMETER]",
@@ -339,12 +443,35 @@ func contractTriggerCmd() *cobra.Command {
 				fmt.Println(common.JSONPrettyFormat(string(asJSON)))
 			}
 
+			limit := feeLimit
+			if cmd.Flags().Changed("autoFeeLimit") {
+				params, err := abi.LoadFromJSONWithMethod(args[1], param)
+				if err != nil {
+					return err
+				}
+				data, err := abi.Pack(args[1], params)
+				if err != nil {
+					return err
+				}
+				// An explicit --feeLimit caps the estimate.
+				ceiling := int64(0)
+				if cmd.Flags().Changed("feeLimit") {
+					ceiling = feeLimit
+				}
+				est, err := conn.EstimateFeeLimit(signerAddress.String(), addr.String(), data,
+					valueInt, tTokenID, tokenInt, autoFeeLimit, ceiling)
+				if err != nil {
+					return err
+				}
+				limit = est.FeeLimit
+			}
+
 			tx, err := conn.TriggerContract(
 				signerAddress.String(),
 				addr.String(),
 				args[1],
 				param,
-				feeLimit,
+				limit,
 				valueInt,
 				tTokenID,
 				tokenInt,
@@ -353,6 +480,10 @@ func contractTriggerCmd() *cobra.Command {
 				return err
 			}
 
+			if estimateCost {
+				return printCostEstimate(tx.Transaction)
+			}
+
 			var ctrlr *transaction.Controller
 			if useLedgerWallet {
 				account := keystore.Account{Address: signerAddress.GetAddress()}
@@ -399,10 +530,152 @@ func contractTriggerCmd() *cobra.Command {
 		},
 	}
 	cmd.Flags().Int64Var(&feeLimit, "feeLimit", 10000000, "fee limit")
+	cmd.Flags().Float64Var(&autoFeeLimit, "autoFeeLimit", 0.2, "estimate the fee limit with this safety margin (0.2 = 20%)")
 	cmd.Flags().Float64Var(&tAmount, "value", 0, "trx amount")
 	cmd.Flags().StringVar(&tTokenID, "token", "", "token id")
 	cmd.Flags().Float64Var(&tTokenAmount, "tokenValue", 0, "token amount")
 	cmd.Flags().BoolVar(&estimate, "estimate", false, "estimate energy required")
+	cmd.Flags().BoolVar(&estimateCost, "estimate-cost", false, "estimate the transaction cost without sending it")
+	return cmd
+}
+
+func contractBindCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bind [CONTRACT_ADDRESS]",
+		Short: "generate a typed Go binding from a contract ABI",
+		Long: `Generate a Go package with typed methods, view calls and event parsers for a
+contract. The ABI is read from --abi/--abiFile, or fetched from the network
+when only a contract address is given.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if abiSTR == "" && abiFile != "" {
+				abiBytes, err := os.ReadFile(abiFile)
+				if err != nil {
+					return fmt.Errorf("cannot read ABI file: %s %v", abiFile, err)
+				}
+				abiSTR = strings.TrimSpace(string(abiBytes))
+			}
+			if abiSTR == "" {
+				if len(args) == 0 {
+					return fmt.Errorf("no ABI string, ABI file or contract address specified")
+				}
+				contractAddr, err := findAddress(args[0])
+				if err != nil {
+					return err
+				}
+				contractABI, err := conn.GetContractABI(contractAddr.String())
+				if err != nil {
+					return fmt.Errorf("cannot fetch ABI: %w", err)
+				}
+				if abiSTR, err = abi.ABIToJSON(contractABI); err != nil {
+					return err
+				}
+			}
+
+			typeName := bindType
+			if typeName == "" && abiFile != "" {
+				base := filepath.Base(abiFile)
+				typeName = eABI.ToCamelCase(strings.TrimSuffix(base, filepath.Ext(base)))
+			}
+			if typeName == "" {
+				return fmt.Errorf("no binding type name specified (use --type)")
+			}
+			pkgName := bindPkg
+			if pkgName == "" {
+				pkgName = strings.ToLower(typeName)
+			}
+
+			code, err := bind.Generate(abiSTR, pkgName, typeName)
+			if err != nil {
+				return err
+			}
+			if bindOut == "" {
+				fmt.Print(string(code))
+				return nil
+			}
+			if err := os.WriteFile(bindOut, code, 0600); err != nil {
+				return fmt.Errorf("cannot write binding: %w", err)
+			}
+			fmt.Printf("binding %s written to %s\n", typeName, bindOut)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&abiSTR, "abi", "", "abi JSON string")
+	cmd.Flags().StringVar(&abiFile, "abiFile", "", "abi file location")
+	cmd.Flags().StringVar(&bindPkg, "pkg", "", "package name of the generated file (default: lowercased type)")
+	cmd.Flags().StringVar(&bindType, "type", "", "Go type name of the binding (default: derived from --abiFile)")
+	cmd.Flags().StringVar(&bindOut, "out", "", "output file (default: stdout)")
+
+	return cmd
+}
+
+func contractProxyCmd() *cobra.Command {
+	var jsonRPC string
+	var showABI bool
+	cmd := &cobra.Command{
+		Use:   "proxy <CONTRACT_ADDRESS>",
+		Short: "detect the proxy pattern and implementation of a contract",
+		Long: `Detect whether a contract is an EIP-1967, UUPS, beacon, EIP-1167 or
+diamond (EIP-2535) proxy and report its implementation or facets. Proxies
+that keep their implementation only in a storage slot need --jsonrpc.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var opts []proxy.Option
+			if jsonRPC != "" {
+				storage := proxy.NewJSONRPCStorage(jsonRPC)
+				storage.APIKey = apiKey
+				opts = append(opts, proxy.WithStorage(storage))
+			}
+			contractABI, info, err := proxy.New(conn, opts...).ResolveABI(context.Background(), args[0])
+			if err != nil {
+				return err
+			}
+
+			if showABI {
+				abiJSON, err := abi.ABIToJSON(contractABI)
+				if err != nil {
+					return err
+				}
+				fmt.Println(common.JSONPrettyFormat(abiJSON))
+				return nil
+			}
+			if noPrettyOutput {
+				fmt.Println(info.Kind, info.Implementation)
+				return nil
+			}
+
+			result := map[string]interface{}{
+				"address": info.Address,
+				"kind":    info.Kind,
+			}
+			for key, value := range map[string]string{
+				"implementation": info.Implementation,
+				"admin":          info.Admin,
+				"beacon":         info.Beacon,
+			} {
+				if value != "" {
+					result[key] = value
+				}
+			}
+			if len(info.Facets) > 0 {
+				facets := make([]map[string]interface{}, len(info.Facets))
+				for i, f := range info.Facets {
+					selectors := make([]string, len(f.Selectors))
+					for j, sel := range f.Selectors {
+						selectors[j] = common.BytesToHexString(sel[:])
+					}
+					facets[i] = map[string]interface{}{"address": f.Address, "selectors": selectors}
+				}
+				result["facets"] = facets
+			}
+			asJSON, _ := json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&jsonRPC, "jsonrpc", "", "JSON-RPC endpoint for reading storage slots (e.g. https://api.trongrid.io/jsonrpc)")
+	cmd.Flags().BoolVar(&showABI, "abi", false, "print the resolved ABI (merged facet ABIs for diamonds)")
 	return cmd
 }
 
@@ -411,6 +684,8 @@ func contractSub() []*cobra.Command {
 		contractDeployCmd(),
 		contractConstantCmd(),
 		contractTriggerCmd(),
+		contractBindCmd(),
+		contractProxyCmd(),
 	}
 }
 
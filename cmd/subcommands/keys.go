@@ -5,6 +5,8 @@ import (
 	"encoding/hex"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/fatih/color"
@@ -13,8 +15,11 @@ import (
 	"github.com/fbsobreira/gotron-sdk/pkg/address"
 	c "github.com/fbsobreira/gotron-sdk/pkg/common"
 	"github.com/fbsobreira/gotron-sdk/pkg/keys"
+	"github.com/fbsobreira/gotron-sdk/pkg/keys/hd"
+	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
 	"github.com/fbsobreira/gotron-sdk/pkg/ledger"
 	"github.com/fbsobreira/gotron-sdk/pkg/mnemonic"
+	"github.com/fbsobreira/gotron-sdk/pkg/slip39"
 	"github.com/fbsobreira/gotron-sdk/pkg/store"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
@@ -23,11 +28,23 @@ import (
 const (
 	seedPhraseWarning = "**Important** write this seed phrase in a safe place, " +
 		"it is the only way to recover your account if you ever forget your password\n\n"
+	sharesWarning = "**Important** store each share in a separate safe place, " +
+		"anyone holding enough shares can recover the secret\n\n"
 )
 
 var (
 	quietImport         bool
 	recoverFromMnemonic bool
+	hdWallet            bool
+	deriveCount         uint32
+	deriveStart         uint32
+	deriveAccount       uint32
+	deriveChange        bool
+	slip39Shares        bool
+	splitMnemonic       bool
+	splitGroupThreshold int
+	splitGroups         []string
+	splitIterationExp   uint8
 	passphrase          string
 	ppPrompt            = fmt.Sprintf(
 		"prompt for passphrase, otherwise use default passphrase: \"`%s`\"", c.DefaultPassphrase,
@@ -90,6 +107,7 @@ func keysAddCmd() *cobra.Command {
 			acc := account.Creation{
 				Name:       args[0],
 				Passphrase: passphrase,
+				HDWallet:   hdWallet,
 			}
 
 			if err := account.CreateNewLocalAccount(&acc); err != nil {
@@ -104,6 +122,7 @@ func keysAddCmd() *cobra.Command {
 			return nil
 		},
 	}
+	cmd.Flags().BoolVar(&hdWallet, "hd", false, "store the seed as an HD wallet to derive more accounts with keys derive")
 	return cmd
 }
 
@@ -154,11 +173,23 @@ func keysRecoverMnemonicCmd() *cobra.Command {
 			acc := account.Creation{
 				Name:       args[0],
 				Passphrase: passphrase,
+				HDWallet:   hdWallet,
 			}
-			fmt.Println("Enter mnemonic to recover keys from")
 			scanner := bufio.NewScanner(os.Stdin)
-			scanner.Scan()
-			m := scanner.Text()
+			var m string
+			if slip39Shares {
+				shares, sharePassphrase, err := readShares(scanner)
+				if err != nil {
+					return err
+				}
+				if m, err = slip39.CombineMnemonic(shares, sharePassphrase); err != nil {
+					return err
+				}
+			} else {
+				fmt.Println("Enter mnemonic to recover keys from")
+				scanner.Scan()
+				m = scanner.Text()
+			}
 			if !bip39.IsMnemonicValid(m) {
 				return fmt.Errorf("invalid mnemonic given")
 			}
@@ -179,6 +210,191 @@ func keysRecoverMnemonicCmd() *cobra.Command {
 			return nil
 		},
 	}
+	cmd.Flags().BoolVar(&hdWallet, "hd", false, "store the seed as an HD wallet to derive more accounts with keys derive")
+	cmd.Flags().BoolVar(&slip39Shares, "slip39", false, "recover the mnemonic from SLIP-0039 shares created with keys split --mnemonic")
+	return cmd
+}
+
+func keysDeriveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "derive <ACCOUNT_NAME>",
+		Short: "Derive and pin accounts of an HD wallet",
+		Long: "Derive accounts m/44'/195'/<account>'/<change>/<index> of an HD wallet created with --hd " +
+			"and pin them, so they can be used as --signer",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deriveCount == 0 {
+				return fmt.Errorf("--count must be at least 1")
+			}
+			if deriveAccount >= keystore.HardenedKeyStart ||
+				uint64(deriveStart)+uint64(deriveCount) > keystore.HardenedKeyStart {
+				return fmt.Errorf("--account and address indexes must be below %d", uint32(keystore.HardenedKeyStart))
+			}
+			w, err := store.HDWallet(args[0])
+			if err != nil {
+				return fmt.Errorf("account %s has no HD wallet: %w", args[0], err)
+			}
+			passphrase, err := getPassphrase()
+			if err != nil {
+				return err
+			}
+			if err := w.Open(passphrase); err != nil {
+				return err
+			}
+			defer func() { _ = w.Close() }()
+
+			var change uint32
+			if deriveChange {
+				change = 1
+			}
+			fmt.Printf("%-24s\t%s\n", "PATH", "ADDRESS")
+			for i := uint32(0); i < deriveCount; i++ {
+				path := keystore.TronDerivationPath(deriveAccount, change, deriveStart+i)
+				acct, err := w.Derive(path, true)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("%-24s\t%s\n", path, acct.Address)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().Uint32Var(&deriveCount, "count", 1, "number of accounts to derive")
+	cmd.Flags().Uint32Var(&deriveStart, "start", 0, "first address index")
+	cmd.Flags().Uint32Var(&deriveAccount, "account", 0, "BIP-44 account number")
+	cmd.Flags().BoolVar(&deriveChange, "change", false, "derive from the internal (change) chain")
+	return cmd
+}
+
+func keysXpubCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "xpub [ACCOUNT_NAME]",
+		Short: "Export the extended public key of an HD account",
+		Long: "Export the xpub of m/44'/195'/<account>' from an HD wallet account, the Ledger (--ledger) " +
+			"or, without arguments, a mnemonic read from stdin. Addresses are derived from it with keys derive-address",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deriveAccount >= keystore.HardenedKeyStart {
+				return fmt.Errorf("--account must be below %d", uint32(keystore.HardenedKeyStart))
+			}
+			xpub, err := exportXpub(args)
+			if err != nil {
+				return err
+			}
+			if noPrettyOutput {
+				fmt.Println(xpub)
+				return nil
+			}
+			fmt.Printf("Path: m/44'/195'/%d'\n", deriveAccount)
+			fmt.Printf("xpub: %s\n", xpub)
+			return nil
+		},
+	}
+	cmd.Flags().Uint32Var(&deriveAccount, "account", 0, "BIP-44 account number")
+	return cmd
+}
+
+// exportXpub reads the account xpub from the Ledger, the named HD wallet or
+// a mnemonic on stdin.
+func exportXpub(args []string) (*hd.ExtendedPublicKey, error) {
+	if useLedgerWallet {
+		dev, err := ledger.OpenDevice()
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = dev.Close() }()
+		keyDev, ok := dev.(ledger.ExtendedKeyDevice)
+		if !ok {
+			return nil, fmt.Errorf("ledger device does not export public keys")
+		}
+		return ledger.ExtendedPublicKey(keyDev, deriveAccount)
+	}
+
+	path := keystore.DerivationPath{
+		keystore.HardenedKeyStart + 44,
+		keystore.HardenedKeyStart + 195,
+		keystore.HardenedKeyStart + deriveAccount,
+	}
+	if len(args) == 1 {
+		w, err := store.HDWallet(args[0])
+		if err != nil {
+			return nil, fmt.Errorf("account %s has no HD wallet: %w", args[0], err)
+		}
+		passphrase, err := getPassphrase()
+		if err != nil {
+			return nil, err
+		}
+		if err := w.Open(passphrase); err != nil {
+			return nil, err
+		}
+		defer func() { _ = w.Close() }()
+		return w.ExtendedPublicKey(path)
+	}
+
+	fmt.Println("Enter mnemonic to export the xpub from")
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Scan()
+	m := scanner.Text()
+	fmt.Println("Enter mnemonic password [optional]")
+	scanner.Scan()
+	seed, err := mnemonic.Seed(m, scanner.Text())
+	if err != nil {
+		return nil, err
+	}
+	defer c.ZeroBytes(seed)
+	master, chainCode := hd.ComputeMastersFromSeed(seed, []byte("Bitcoin seed"))
+	defer c.ZeroBytes(master[:])
+	defer c.ZeroBytes(chainCode[:])
+	return hd.ExtendedPublicKeyForPath(master, chainCode, path.String())
+}
+
+func keysDeriveAddressCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "derive-address <XPUB>",
+		Short: "Derive watch-only addresses from an extended public key",
+		Long:  "Derive the addresses <change>/<index> below an account xpub exported with keys xpub",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if deriveCount == 0 {
+				return fmt.Errorf("--count must be at least 1")
+			}
+			if uint64(deriveStart)+uint64(deriveCount) > keystore.HardenedKeyStart {
+				return fmt.Errorf("address indexes must be below %d", uint32(keystore.HardenedKeyStart))
+			}
+			xpub, err := hd.ParseExtendedPublicKey(args[0])
+			if err != nil {
+				return err
+			}
+			var change uint32
+			if deriveChange {
+				change = 1
+			}
+			chain, err := xpub.Child(change)
+			if err != nil {
+				return err
+			}
+
+			if !noPrettyOutput {
+				fmt.Printf("%-24s\t%s\n", "PATH", "ADDRESS")
+			}
+			for i := uint32(0); i < deriveCount; i++ {
+				child, err := chain.Child(deriveStart + i)
+				if err != nil {
+					return err
+				}
+				addr := address.PubkeyToAddress(*child.ToECDSA())
+				if noPrettyOutput {
+					fmt.Println(addr)
+					continue
+				}
+				fmt.Printf("%-24s\t%s\n", fmt.Sprintf("%d/%d", change, deriveStart+i), addr)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().Uint32Var(&deriveCount, "count", 1, "number of addresses to derive")
+	cmd.Flags().Uint32Var(&deriveStart, "start", 0, "first address index")
+	cmd.Flags().BoolVar(&deriveChange, "change", false, "derive from the internal (change) chain")
 	return cmd
 }
 
@@ -213,17 +429,35 @@ func keysImportPKCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "import-private-key <secp256k1_PRIVATE_KEY> [ACCOUNT_NAME]",
 		Short: "Import an existing keystore key (only accept secp256k1 private keys)",
-		Args:  cobra.RangeArgs(1, 2),
+		Long: "Import a secp256k1 private key; with --slip39 the key is recovered from " +
+			"SLIP-0039 shares read from stdin and the only argument is the account name",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if slip39Shares {
+				return cobra.MaximumNArgs(1)(cmd, args)
+			}
+			return cobra.RangeArgs(1, 2)(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			userName := ""
-			if len(args) == 2 {
+			if slip39Shares && len(args) == 1 {
+				userName = args[0]
+			} else if len(args) == 2 {
 				userName = args[1]
 			}
 			passphrase, err := getPassphrase()
 			if err != nil {
 				return err
 			}
-			name, err := account.ImportFromPrivateKey(args[0], userName, passphrase)
+			var name string
+			if slip39Shares {
+				shares, sharePassphrase, e := readShares(bufio.NewScanner(os.Stdin))
+				if e != nil {
+					return e
+				}
+				name, err = account.ImportFromShares(shares, sharePassphrase, userName, passphrase)
+			} else {
+				name, err = account.ImportFromPrivateKey(args[0], userName, passphrase)
+			}
 			if !quietImport && err == nil {
 				fmt.Printf("Imported keystore given account alias of `%s`\n", name)
 				addr, _ := store.AddressFromAccountName(name)
@@ -233,6 +467,7 @@ func keysImportPKCmd() *cobra.Command {
 		},
 	}
 	cmd.Flags().BoolVar(&quietImport, "quiet", false, "do not print out imported account name")
+	cmd.Flags().BoolVar(&slip39Shares, "slip39", false, "recover the private key from SLIP-0039 shares created with keys split")
 	return cmd
 }
 
@@ -324,6 +559,157 @@ func keysAddressPKCmd() *cobra.Command {
 	return cmd
 }
 
+func keysSplitCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "split",
+		Short: "Split a private key or mnemonic into SLIP-0039 shares",
+		Long: "Split a secp256k1 private key, or a mnemonic with --mnemonic, into SLIP-0039 shares. " +
+			"Each --group THRESHOLD/COUNT adds a group of COUNT shares of which THRESHOLD are needed; " +
+			"--group-threshold groups are needed to recover the secret with keys combine",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			groups, err := parseShareGroups(splitGroups)
+			if err != nil {
+				return err
+			}
+			scanner := bufio.NewScanner(os.Stdin)
+			var secret []byte
+			if splitMnemonic {
+				fmt.Println("Enter mnemonic to split")
+				scanner.Scan()
+				m := scanner.Text()
+				if !bip39.IsMnemonicValid(m) {
+					return fmt.Errorf("invalid mnemonic given")
+				}
+				if secret, err = bip39.EntropyFromMnemonic(m); err != nil {
+					return err
+				}
+			} else {
+				fmt.Println("Enter private key hex format:")
+				data, err := term.ReadPassword(int(os.Stdin.Fd()))
+				if err != nil {
+					return err
+				}
+				if secret, err = hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(data)), "0x")); err != nil {
+					return err
+				}
+				if len(secret) != c.Secp256k1PrivateKeyBytesLength {
+					return c.ErrBadKeyLength
+				}
+			}
+			defer c.ZeroBytes(secret)
+
+			fmt.Println("Enter share passphrase [optional]")
+			scanner.Scan()
+			shares, err := slip39.Split(secret, scanner.Text(), splitGroupThreshold, groups,
+				slip39.WithIterationExponent(splitIterationExp))
+			if err != nil {
+				return err
+			}
+
+			if noPrettyOutput {
+				for i, group := range shares {
+					if i > 0 {
+						fmt.Println()
+					}
+					for _, share := range group {
+						fmt.Println(share)
+					}
+				}
+				return nil
+			}
+			color.Red(sharesWarning)
+			fmt.Printf("%d of %d groups are required\n", splitGroupThreshold, len(groups))
+			for i, group := range shares {
+				fmt.Printf("\nGroup %d (%d of %d shares required):\n", i+1, groups[i].MemberThreshold, groups[i].MemberCount)
+				for j, share := range group {
+					fmt.Printf("%d. %s\n", j+1, share)
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().IntVar(&splitGroupThreshold, "group-threshold", 1, "number of groups required to recover the secret")
+	cmd.Flags().StringArrayVar(&splitGroups, "group", nil, "group as THRESHOLD/COUNT shares, repeat for more groups")
+	cmd.Flags().BoolVar(&splitMnemonic, "mnemonic", false, "split a mnemonic instead of a private key")
+	cmd.Flags().Uint8Var(&splitIterationExp, "iteration-exponent", 1, "PBKDF2 iteration exponent of the share encryption")
+	return cmd
+}
+
+func keysCombineCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "combine",
+		Short: "Recover a private key or mnemonic from SLIP-0039 shares",
+		Long: "Recover the secret of shares created with keys split and print it; " +
+			"use --mnemonic for shares of a mnemonic",
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			shares, sharePassphrase, err := readShares(bufio.NewScanner(os.Stdin))
+			if err != nil {
+				return err
+			}
+			if splitMnemonic {
+				m, err := slip39.CombineMnemonic(shares, sharePassphrase)
+				if err != nil {
+					return err
+				}
+				fmt.Println(m)
+				return nil
+			}
+			secret, err := slip39.Combine(shares, sharePassphrase)
+			if err != nil {
+				return err
+			}
+			defer c.ZeroBytes(secret)
+			fmt.Println(hex.EncodeToString(secret))
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&splitMnemonic, "mnemonic", false, "print the recovered secret as a mnemonic")
+	return cmd
+}
+
+// parseShareGroups parses THRESHOLD/COUNT group specifications.
+func parseShareGroups(specs []string) ([]slip39.Group, error) {
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("at least one --group is required")
+	}
+	groups := make([]slip39.Group, len(specs))
+	for i, spec := range specs {
+		threshold, count, ok := strings.Cut(spec, "/")
+		t, errT := strconv.Atoi(threshold)
+		n, errN := strconv.Atoi(count)
+		if !ok || errT != nil || errN != nil {
+			return nil, fmt.Errorf("invalid group %q, want THRESHOLD/COUNT", spec)
+		}
+		groups[i] = slip39.Group{MemberThreshold: t, MemberCount: n}
+	}
+	return groups, nil
+}
+
+// readShares reads SLIP-0039 shares, one per line up to an empty line, and
+// the passphrase they were created with.
+func readShares(scanner *bufio.Scanner) ([]string, string, error) {
+	fmt.Println("Enter shares, one per line, followed by an empty line")
+	var shares []string
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			break
+		}
+		shares = append(shares, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, "", err
+	}
+	if len(shares) == 0 {
+		return nil, "", fmt.Errorf("no shares given")
+	}
+	fmt.Println("Enter share passphrase [optional]")
+	scanner.Scan()
+	return shares, scanner.Text(), nil
+}
+
 func keysSub() []*cobra.Command {
 	return []*cobra.Command{
 		keysListCmd(),
@@ -332,6 +718,11 @@ func keysSub() []*cobra.Command {
 		keysRemoveCmd(),
 		keysMnemonicCmd(),
 		keysRecoverMnemonicCmd(),
+		keysDeriveCmd(),
+		keysXpubCmd(),
+		keysDeriveAddressCmd(),
+		keysSplitCmd(),
+		keysCombineCmd(),
 		keysImportKSCmd(),
 		keysImportPKCmd(),
 		keysExportKSCmd(),
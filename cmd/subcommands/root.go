@@ -15,6 +15,7 @@ import (
 	"github.com/fbsobreira/gotron-sdk/pkg/client"
 	"github.com/fbsobreira/gotron-sdk/pkg/client/transaction"
 	c "github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
 	"github.com/fbsobreira/gotron-sdk/pkg/store"
 	"github.com/spf13/cobra"
 	"github.com/spf13/cobra/doc"
@@ -266,6 +267,36 @@ func opts(ctlr *transaction.Controller) {
 	}
 }
 
+// printCostEstimate prints the expected cost of tx signed by the signer
+// alone, for the --estimate flag of send commands.
+func printCostEstimate(tx *core.Transaction) error {
+	est, err := conn.EstimateCost(tx, 1)
+	if err != nil {
+		return err
+	}
+	if noPrettyOutput {
+		fmt.Printf("%+v\n", *est)
+		return nil
+	}
+
+	result := make(map[string]interface{})
+	result["size"] = est.Size
+	result["stakedBandwidth"] = est.StakedBandwidth
+	result["freeBandwidth"] = est.FreeBandwidth
+	result["bandwidthFee"] = est.BandwidthFee
+	result["energy"] = est.Energy
+	result["availableEnergy"] = est.AvailableEnergy
+	result["energyFee"] = est.EnergyFee
+	result["activationFee"] = est.ActivationFee
+	result["memoFee"] = est.MemoFee
+	result["multiSignFee"] = est.MultiSignFee
+	result["total"] = est.Total
+
+	asJSON, _ := json.Marshal(result)
+	fmt.Println(c.JSONPrettyFormat(string(asJSON)))
+	return nil
+}
+
 // getPassphrase fetches the correct passphrase depending on if a file is available to
 // read from or if the user wants to enter in their own passphrase. Otherwise, just use
 // the default passphrase. No confirmation of passphrase
@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"regexp"
+	"syscall"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	signing "github.com/fbsobreira/gotron-sdk/pkg/signer"
+	"github.com/fbsobreira/gotron-sdk/pkg/store"
+	"github.com/spf13/cobra"
+)
+
+var (
+	signerSocket   string
+	signerListen   string
+	signerTLSCert  string
+	signerTLSKey   string
+	signerClientCA string
+	signerPolicy   string
+)
+
+// signerPolicyFile is the JSON policy file of signer serve. Omitted entries
+// impose no restriction.
+type signerPolicyFile struct {
+	// Destinations lists the only addresses value may be sent to.
+	Destinations []string `json:"destinations"`
+	// DailyLimits maps an asset (TRX, a TRC-10 ID or a TRC-20 contract
+	// address) to the amount, in its base unit, allowed per 24 hours.
+	DailyLimits map[string]string `json:"daily_limits"`
+	// MaxPerTransaction maps an asset to the amount one transaction may move.
+	MaxPerTransaction map[string]string `json:"max_per_transaction"`
+	// ContractMethods maps a contract address to the callable methods.
+	ContractMethods map[string][]string `json:"contract_methods"`
+	// ForbiddenContractTypes lists contract types that are never signed,
+	// e.g. AccountPermissionUpdateContract.
+	ForbiddenContractTypes []string `json:"forbidden_contract_types"`
+	// MaxExpiration is how far ahead, e.g. "10m", a transaction may expire.
+	MaxExpiration string `json:"max_expiration"`
+	// PermissionIDs lists the permissions transactions may be signed under.
+	PermissionIDs []int32 `json:"permission_ids"`
+	// MemoPattern is a regular expression every memo must match.
+	MemoPattern string `json:"memo_pattern"`
+}
+
+func loadSignerPolicy(path string) ([]signing.Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var p signerPolicyFile
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&p); err != nil {
+		return nil, fmt.Errorf("invalid policy file: %w", err)
+	}
+
+	var rules []signing.Rule
+	if p.Destinations != nil {
+		addrs := make([]address.Address, len(p.Destinations))
+		for i, d := range p.Destinations {
+			if addrs[i], err = address.Base58ToAddress(d); err != nil {
+				return nil, fmt.Errorf("invalid destination %s: %w", d, err)
+			}
+		}
+		rules = append(rules, signing.AllowDestinations(addrs...))
+	}
+	for asset, limit := range p.DailyLimits {
+		amount, ok := new(big.Int).SetString(limit, 10)
+		if !ok || amount.Sign() < 0 {
+			return nil, fmt.Errorf("invalid daily limit %q for %s", limit, asset)
+		}
+		rules = append(rules, signing.DailyLimit(asset, amount))
+	}
+	for asset, limit := range p.MaxPerTransaction {
+		amount, ok := new(big.Int).SetString(limit, 10)
+		if !ok || amount.Sign() < 0 {
+			return nil, fmt.Errorf("invalid per-transaction limit %q for %s", limit, asset)
+		}
+		rules = append(rules, signing.MaxPerTransaction(asset, amount))
+	}
+	if p.ContractMethods != nil {
+		rules = append(rules, signing.AllowContractMethods(p.ContractMethods))
+	}
+	if len(p.ForbiddenContractTypes) > 0 {
+		types := make([]core.Transaction_Contract_ContractType, len(p.ForbiddenContractTypes))
+		for i, name := range p.ForbiddenContractTypes {
+			v, ok := core.Transaction_Contract_ContractType_value[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown contract type %s", name)
+			}
+			types[i] = core.Transaction_Contract_ContractType(v)
+		}
+		rules = append(rules, signing.ForbidContractTypes(types...))
+	}
+	if p.MaxExpiration != "" {
+		window, err := time.ParseDuration(p.MaxExpiration)
+		if err != nil || window <= 0 {
+			return nil, fmt.Errorf("invalid max expiration %q", p.MaxExpiration)
+		}
+		rules = append(rules, signing.ExpirationWindow(window))
+	}
+	if p.PermissionIDs != nil {
+		rules = append(rules, signing.AllowPermissionIDs(p.PermissionIDs...))
+	}
+	if p.MemoPattern != "" {
+		re, err := regexp.Compile(p.MemoPattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid memo pattern: %w", err)
+		}
+		rules = append(rules, signing.RequireMemo(re))
+	}
+	return rules, nil
+}
+
+func signerListener() (net.Listener, error) {
+	if (signerSocket == "") == (signerListen == "") {
+		return nil, fmt.Errorf("set exactly one of --socket or --listen")
+	}
+	if signerSocket != "" {
+		l, err := net.Listen("unix", signerSocket)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.Chmod(signerSocket, 0o600); err != nil {
+			_ = l.Close()
+			return nil, err
+		}
+		return l, nil
+	}
+
+	if signerTLSCert == "" || signerTLSKey == "" || signerClientCA == "" {
+		return nil, fmt.Errorf("--listen requires --tls-cert, --tls-key and --client-ca for mutual TLS")
+	}
+	cert, err := tls.LoadX509KeyPair(signerTLSCert, signerTLSKey)
+	if err != nil {
+		return nil, err
+	}
+	caPEM, err := os.ReadFile(signerClientCA)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no certificates found in %s", signerClientCA)
+	}
+	return tls.Listen("tcp", signerListen, &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	})
+}
+
+func signerServeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve <ACCOUNT_NAME>...",
+		Short: "Sign transactions of local accounts for remote clients",
+		Long: "Unlock the given keystore accounts and sign transactions for signer.Remote clients " +
+			"over a unix socket (--socket) or mutual TLS (--listen). Every transaction is checked " +
+			"against the --policy file before it is signed",
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var rules []signing.Rule
+			if signerPolicy != "" {
+				var err error
+				if rules, err = loadSignerPolicy(signerPolicy); err != nil {
+					return err
+				}
+			} else {
+				fmt.Fprintln(os.Stderr, "warning: no --policy given, every transaction will be signed")
+			}
+
+			passphrase, err := getPassphrase()
+			if err != nil {
+				return err
+			}
+			signers := make([]signing.Signer, 0, len(args))
+			for _, name := range args {
+				addr, err := store.AddressFromAccountName(name)
+				if err != nil {
+					return err
+				}
+				ks, acct, err := store.UnlockedKeystore(addr, passphrase)
+				if err != nil {
+					return err
+				}
+				defer ks.Close()
+				signers = append(signers, signing.NewKeystoreSigner(ks, *acct))
+			}
+
+			l, err := signerListener()
+			if err != nil {
+				return err
+			}
+			srv := &http.Server{
+				Handler:           signing.NewServer(signers, rules...),
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			go func() {
+				<-ctx.Done()
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				_ = srv.Shutdown(shutdownCtx)
+			}()
+
+			fmt.Printf("Signing for %d account(s) with %d policy rule(s) on %s\n", len(signers), len(rules), l.Addr())
+			if err := srv.Serve(l); !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&signerSocket, "socket", "", "unix socket path to listen on")
+	cmd.Flags().StringVar(&signerListen, "listen", "", "TCP address to listen on with mutual TLS, e.g. :8443")
+	cmd.Flags().StringVar(&signerTLSCert, "tls-cert", "", "server certificate (PEM) for --listen")
+	cmd.Flags().StringVar(&signerTLSKey, "tls-key", "", "server private key (PEM) for --listen")
+	cmd.Flags().StringVar(&signerClientCA, "client-ca", "", "CA certificates (PEM) that client certificates must chain to")
+	cmd.Flags().StringVar(&signerPolicy, "policy", "", "JSON policy file")
+	return cmd
+}
+
+func init() {
+	cmdSigner := &cobra.Command{
+		Use:   "signer",
+		Short: "Remote signing service",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmdSigner.AddCommand(signerServeCmd())
+	RootCmd.AddCommand(cmdSigner)
+}
@@ -163,7 +163,7 @@ func trc10IssueCmd() *cobra.Command {
 }
 
 func trc10SendCmd() *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:     "send <ADDRESS_TO> <AMOUNT> <TOKEN_ID or TOKEN_NAME> ",
 		Short:   "send TOKEN to an address",
 		Args:    cobra.ExactArgs(3),
@@ -212,6 +212,10 @@ func trc10SendCmd() *cobra.Command {
 				return err
 			}
 
+			if estimate {
+				return printCostEstimate(tx.Transaction)
+			}
+
 			var ctrlr *transaction.Controller
 			if useLedgerWallet {
 				account := keystore.Account{Address: signerAddress.GetAddress()}
@@ -247,6 +251,8 @@ func trc10SendCmd() *cobra.Command {
 			return nil
 		},
 	}
+	cmd.Flags().BoolVar(&estimate, "estimate", false, "estimate the transaction cost without sending it")
+	return cmd
 }
 
 func trc10ICOCmd() *cobra.Command {
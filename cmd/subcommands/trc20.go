@@ -6,6 +6,7 @@ import (
 	"math/big"
 
 	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
 	"github.com/fbsobreira/gotron-sdk/pkg/client/transaction"
 	"github.com/fbsobreira/gotron-sdk/pkg/common"
 	"github.com/fbsobreira/gotron-sdk/pkg/common/decimals"
@@ -42,11 +43,24 @@ func trc20SendCmd() *cobra.Command {
 			}
 
 			amount, _ := decimals.ApplyDecimals(value, tokenDecimals.Int64())
-			tx, err := conn.TRC20Send(signerAddress.String(), addr.String(), contract.String(), amount, feeLimit)
+			limit := feeLimit
+			var sendOpts []client.TRC20Option
+			if cmd.Flags().Changed("autoFeeLimit") {
+				sendOpts = append(sendOpts, client.WithAutoFeeLimit(autoFeeLimit))
+				// An explicit --feeLimit caps the estimate.
+				if !cmd.Flags().Changed("feeLimit") {
+					limit = 0
+				}
+			}
+			tx, err := conn.TRC20Send(signerAddress.String(), addr.String(), contract.String(), amount, limit, sendOpts...)
 			if err != nil {
 				return err
 			}
 
+			if estimate {
+				return printCostEstimate(tx.Transaction)
+			}
+
 			var ctrlr *transaction.Controller
 			if useLedgerWallet {
 				account := keystore.Account{Address: signerAddress.GetAddress()}
@@ -92,6 +106,8 @@ func trc20SendCmd() *cobra.Command {
 		},
 	}
 	cmd.Flags().Int64Var(&feeLimit, "feeLimit", 10000000, "fee limit")
+	cmd.Flags().Float64Var(&autoFeeLimit, "autoFeeLimit", 0.2, "estimate the fee limit with this safety margin (0.2 = 20%)")
+	cmd.Flags().BoolVar(&estimate, "estimate", false, "estimate the transaction cost without sending it")
 	return cmd
 }
 
@@ -0,0 +1,327 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/client/transaction"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/contract"
+	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
+	"github.com/fbsobreira/gotron-sdk/pkg/standards/trc721"
+	"github.com/fbsobreira/gotron-sdk/pkg/store"
+	"github.com/spf13/cobra"
+)
+
+var (
+	nftData   string
+	nftRevoke bool
+)
+
+// parseTokenID parses a decimal or 0x-prefixed token ID.
+func parseTokenID(value string) (*big.Int, error) {
+	id, ok := new(big.Int).SetString(value, 0)
+	if !ok || id.Sign() < 0 {
+		return nil, fmt.Errorf("invalid token id %s", value)
+	}
+	return id, nil
+}
+
+// nftToken resolves the contract argument and returns a TRC-721 handle.
+func nftToken(contractArg string) (*trc721.Token, error) {
+	contractAddr, err := findAddress(contractArg)
+	if err != nil {
+		return nil, err
+	}
+	return trc721.New(conn, contractAddr.String()), nil
+}
+
+// executeNFTCall builds, signs and broadcasts a TRC-721 write, or prints its
+// cost with --estimate.
+func executeNFTCall(call *contract.ContractCall) error {
+	tx, err := call.Apply(contract.WithFeeLimit(feeLimit)).Build(context.Background())
+	if err != nil {
+		return err
+	}
+	if estimate {
+		return printCostEstimate(tx.Transaction)
+	}
+
+	var ctrlr *transaction.Controller
+	if useLedgerWallet {
+		account := keystore.Account{Address: signerAddress.GetAddress()}
+		ctrlr = transaction.NewController(conn, nil, &account, tx.Transaction, opts)
+	} else {
+		ks, acct, err := store.UnlockedKeystore(signerAddress.String(), passphrase)
+		if err != nil {
+			return err
+		}
+		ctrlr = transaction.NewController(conn, ks, acct, tx.Transaction, opts)
+	}
+	if err = ctrlr.ExecuteTransaction(); err != nil {
+		return err
+	}
+
+	if noPrettyOutput {
+		fmt.Println(tx)
+		return nil
+	}
+
+	result := make(map[string]interface{})
+	result["txID"] = common.BytesToHexString(tx.GetTxid())
+	result["blockNumber"] = ctrlr.Receipt.BlockNumber
+	result["message"] = string(ctrlr.Result.Message)
+	result["contractAddress"] = address.Address(ctrlr.Receipt.ContractAddress).String()
+	result["success"] = ctrlr.GetResultError() == nil
+	result["resMessage"] = string(ctrlr.Receipt.ResMessage)
+	result["receipt"] = map[string]interface{}{
+		"fee":              ctrlr.Receipt.Fee,
+		"energyFee":        ctrlr.Receipt.Receipt.EnergyFee,
+		"energyUsage":      ctrlr.Receipt.Receipt.EnergyUsage,
+		"energyUsageTotal": ctrlr.Receipt.Receipt.EnergyUsageTotal,
+		"netFee":           ctrlr.Receipt.Receipt.NetFee,
+		"netUsage":         ctrlr.Receipt.Receipt.NetUsage,
+	}
+
+	asJSON, _ := json.Marshal(result)
+	fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+	return nil
+}
+
+func addNFTWriteFlags(cmd *cobra.Command) {
+	cmd.Flags().Int64Var(&feeLimit, "feeLimit", 100000000, "fee limit")
+	cmd.Flags().BoolVar(&estimate, "estimate", false, "estimate the transaction cost without sending it")
+}
+
+func trc721SendCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "send <ADDRESS_TO> <TOKEN_ID> <CONTRACT_ADDRESS>",
+		Short:   "safely transfer a TRC721 token to an address",
+		Args:    cobra.ExactArgs(3),
+		PreRunE: validateAddress,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if signerAddress.String() == "" {
+				return fmt.Errorf("no signer specified")
+			}
+			tokenID, err := parseTokenID(args[1])
+			if err != nil {
+				return err
+			}
+			token, err := nftToken(args[2])
+			if err != nil {
+				return err
+			}
+
+			from := signerAddress.String()
+			if nftData == "" {
+				return executeNFTCall(token.SafeTransferFrom(from, from, addr.String(), tokenID))
+			}
+			data, err := common.FromHex(nftData)
+			if err != nil {
+				return fmt.Errorf("invalid --data: %w", err)
+			}
+			return executeNFTCall(token.SafeTransferFromWithData(from, from, addr.String(), tokenID, data))
+		},
+	}
+	cmd.Flags().StringVar(&nftData, "data", "", "hex data passed to the recipient's onTRC721Received")
+	addNFTWriteFlags(cmd)
+	return cmd
+}
+
+func trc721ApproveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "approve <ADDRESS> <TOKEN_ID> <CONTRACT_ADDRESS>",
+		Short:   "approve an address to transfer a TRC721 token",
+		Args:    cobra.ExactArgs(3),
+		PreRunE: validateAddress,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if signerAddress.String() == "" {
+				return fmt.Errorf("no signer specified")
+			}
+			tokenID, err := parseTokenID(args[1])
+			if err != nil {
+				return err
+			}
+			token, err := nftToken(args[2])
+			if err != nil {
+				return err
+			}
+			return executeNFTCall(token.Approve(signerAddress.String(), addr.String(), tokenID))
+		},
+	}
+	addNFTWriteFlags(cmd)
+	return cmd
+}
+
+func trc721ApproveAllCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "approve-all <OPERATOR> <CONTRACT_ADDRESS>",
+		Short:   "allow an operator to transfer all of the signer's TRC721 tokens",
+		Args:    cobra.ExactArgs(2),
+		PreRunE: validateAddress,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if signerAddress.String() == "" {
+				return fmt.Errorf("no signer specified")
+			}
+			token, err := nftToken(args[1])
+			if err != nil {
+				return err
+			}
+			return executeNFTCall(token.SetApprovalForAll(signerAddress.String(), addr.String(), !nftRevoke))
+		},
+	}
+	cmd.Flags().BoolVar(&nftRevoke, "revoke", false, "revoke the operator instead")
+	addNFTWriteFlags(cmd)
+	return cmd
+}
+
+func trc721OwnerCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "owner <TOKEN_ID> <CONTRACT_ADDRESS>",
+		Short: "get the owner, approval and URI of a TRC721 token",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tokenID, err := parseTokenID(args[0])
+			if err != nil {
+				return err
+			}
+			token, err := nftToken(args[1])
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			owner, err := token.OwnerOf(ctx, tokenID)
+			if err != nil {
+				return err
+			}
+
+			if noPrettyOutput {
+				fmt.Println(owner)
+				return nil
+			}
+
+			result := make(map[string]interface{})
+			result["tokenId"] = tokenID.String()
+			result["owner"] = owner
+			if approved, err := token.GetApproved(ctx, tokenID); err == nil {
+				result["approved"] = approved
+			}
+			if uri, err := token.TokenURI(ctx, tokenID); err == nil {
+				result["tokenURI"] = uri
+			}
+
+			asJSON, _ := json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+}
+
+func trc721BalanceCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "balance <ADDRESS> <CONTRACT_ADDRESS>",
+		Short:   "list the TRC721 tokens held by an address",
+		Args:    cobra.ExactArgs(2),
+		PreRunE: validateAddress,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token, err := nftToken(args[1])
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			balance, err := token.BalanceOf(ctx, addr.String())
+			if err != nil {
+				return err
+			}
+
+			if noPrettyOutput {
+				fmt.Println(balance.String())
+				return nil
+			}
+
+			result := make(map[string]interface{})
+			result["balance"] = balance.String()
+			// Token IDs are only listed for enumerable contracts.
+			if tokens, err := token.TokensOfOwner(ctx, addr.String()); err == nil {
+				ids := make([]string, len(tokens))
+				for i, id := range tokens {
+					ids[i] = id.String()
+				}
+				result["tokens"] = ids
+			}
+
+			asJSON, _ := json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+}
+
+func trc721InfoCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "info <CONTRACT_ADDRESS>",
+		Short: "get TRC721 collection information and supported interfaces",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token, err := nftToken(args[0])
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			interfaces, err := token.Interfaces(ctx)
+			if err != nil {
+				return err
+			}
+
+			result := make(map[string]interface{})
+			result["trc721"] = interfaces.TRC721
+			result["metadata"] = interfaces.Metadata
+			result["enumerable"] = interfaces.Enumerable
+			if name, err := token.Name(ctx); err == nil {
+				result["name"] = name
+			}
+			if symbol, err := token.Symbol(ctx); err == nil {
+				result["symbol"] = symbol
+			}
+			if supply, err := token.TotalSupply(ctx); err == nil {
+				result["totalSupply"] = supply.String()
+			}
+
+			if noPrettyOutput {
+				fmt.Println(result)
+				return nil
+			}
+
+			asJSON, _ := json.Marshal(result)
+			fmt.Println(common.JSONPrettyFormat(string(asJSON)))
+			return nil
+		},
+	}
+}
+
+func trc721Sub() []*cobra.Command {
+	return []*cobra.Command{
+		trc721SendCmd(),
+		trc721ApproveCmd(),
+		trc721ApproveAllCmd(),
+		trc721OwnerCmd(),
+		trc721BalanceCmd(),
+		trc721InfoCmd(),
+	}
+}
+
+func init() {
+	cmdTrc721 := &cobra.Command{
+		Use:   "trc721",
+		Short: "TRC721 NFT Manager",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmdTrc721.AddCommand(trc721Sub()...)
+	RootCmd.AddCommand(cmdTrc721)
+}
@@ -3,7 +3,9 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
 	"github.com/spf13/cobra"
 )
 
@@ -53,6 +55,61 @@ func init() {
 			return nil
 		},
 	}}...)
+	cmdUtilities.AddCommand(create2Cmd())
 
 	RootCmd.AddCommand(cmdUtilities)
 }
+
+func create2Cmd() *cobra.Command {
+	var initCode string
+	cmd := &cobra.Command{
+		Use:   "create2 <DEPLOYER> <SALT> [INIT_CODE_HASH]",
+		Short: "address of a contract created with CREATE2",
+		Long: `Compute the address of a contract created with CREATE2 by DEPLOYER.
+SALT is hex, left-padded to 32 bytes. Give either the keccak256 hash of the
+creation code (including constructor arguments) or the code itself with
+--init-code.`,
+		Args: cobra.RangeArgs(2, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			deployer, err := address.Base58ToAddress(args[0])
+			if err != nil {
+				return err
+			}
+			salt, err := common.FromHex(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid salt: %w", err)
+			}
+			if len(salt) > address.HashLength {
+				return fmt.Errorf("salt longer than %d bytes", address.HashLength)
+			}
+			salt = append(make([]byte, address.HashLength-len(salt)), salt...)
+
+			var hash []byte
+			switch {
+			case len(args) == 3 && initCode != "":
+				return fmt.Errorf("give INIT_CODE_HASH or --init-code, not both")
+			case len(args) == 3:
+				if hash, err = common.FromHex(args[2]); err != nil {
+					return fmt.Errorf("invalid init code hash: %w", err)
+				}
+			case initCode != "":
+				code, err := common.FromHex(initCode)
+				if err != nil {
+					return fmt.Errorf("invalid init code: %w", err)
+				}
+				hash = crypto.Keccak256(code)
+			default:
+				return fmt.Errorf("INIT_CODE_HASH or --init-code required")
+			}
+
+			addr, err := address.Create2Address(deployer, salt, hash)
+			if err != nil {
+				return err
+			}
+			fmt.Println(addr)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&initCode, "init-code", "", "creation code HEX, hashed to compute the address")
+	return cmd
+}
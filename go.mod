@@ -21,6 +21,7 @@ require (
 	github.com/fatih/structs v1.1.0
 	github.com/fbsobreira/go-bip39 v1.2.0
 	github.com/joho/godotenv v1.5.1
+	github.com/miekg/pkcs11 v1.1.1
 	github.com/pborman/uuid v1.2.1
 	github.com/rjeczalik/notify v0.9.3
 	github.com/shengdoushi/base58 v1.0.0
@@ -741,7 +741,8 @@ func convertOutputValue(v interface{}) interface{} {
 // DecodeRevertReason extracts the human-readable error string from
 // ABI-encoded revert data. Supports both Error(string) (selector 0x08c379a0)
 // from revert/require and Panic(uint256) (selector 0x4e487b71) from
-// assertion failures and arithmetic errors.
+// assertion failures and arithmetic errors. Use DecodeRevert to also decode
+// custom errors declared in a contract ABI.
 func DecodeRevertReason(data []byte) (string, error) {
 	if len(data) < 4 {
 		return "", fmt.Errorf("data too short for revert selector: %d bytes", len(data))
@@ -0,0 +1,95 @@
+package abi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// trcTokenType is the TRON-specific Solidity type for TRC10 token IDs. It is
+// ABI-encoded exactly like uint256.
+const trcTokenType = "trcToken"
+
+// ParseABI parses a JSON ABI (as produced by solc, TronBox, Hardhat or
+// Foundry) into a go-ethereum ABI. Unlike the proto representation returned
+// by JSONtoABI, the result preserves tuple components, so it can describe
+// struct parameters. The TRON-specific trcToken type is mapped to uint256.
+func ParseABI(abiJSON string) (eABI.ABI, error) {
+	var entries []map[string]interface{}
+	if err := json.Unmarshal([]byte(abiJSON), &entries); err != nil {
+		return eABI.ABI{}, fmt.Errorf("parse ABI JSON: %w", err)
+	}
+	for _, entry := range entries {
+		normalizeParams(entry["inputs"])
+		normalizeParams(entry["outputs"])
+	}
+	normalized, err := json.Marshal(entries)
+	if err != nil {
+		return eABI.ABI{}, fmt.Errorf("normalize ABI JSON: %w", err)
+	}
+	parsed, err := eABI.JSON(strings.NewReader(string(normalized)))
+	if err != nil {
+		return eABI.ABI{}, fmt.Errorf("parse ABI: %w", err)
+	}
+	return parsed, nil
+}
+
+// FindMethod looks up a method in a parsed ABI. method is either a canonical
+// signature such as "transfer(address,uint256)" or a name. A plain name is
+// rejected for overloaded methods, though the suffixed keys go-ethereum
+// assigns to overloads (e.g. "transfer0") are accepted.
+func FindMethod(parsed eABI.ABI, method string) (eABI.Method, error) {
+	if strings.Contains(method, "(") {
+		sig := strings.ReplaceAll(strings.ReplaceAll(method, " ", ""), trcTokenType, "uint256")
+		for _, m := range parsed.Methods {
+			if m.Sig == sig {
+				return m, nil
+			}
+		}
+		return eABI.Method{}, fmt.Errorf("method %s not found in ABI", method)
+	}
+	m, ok := parsed.Methods[method]
+	if !ok {
+		return eABI.Method{}, fmt.Errorf("method %s not found in ABI", method)
+	}
+	for key, other := range parsed.Methods {
+		if method == m.RawName && key != method && other.RawName == m.RawName {
+			return eABI.Method{}, fmt.Errorf("method %s is overloaded, use its signature", method)
+		}
+	}
+	return m, nil
+}
+
+// normalizeParams rewrites trcToken types to uint256 in a decoded params
+// list, recursing into tuple components.
+func normalizeParams(v interface{}) {
+	params, ok := v.([]interface{})
+	if !ok {
+		return
+	}
+	for _, p := range params {
+		param, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if ty, ok := param["type"].(string); ok && strings.HasPrefix(ty, trcTokenType) {
+			param["type"] = "uint256" + strings.TrimPrefix(ty, trcTokenType)
+		}
+		normalizeParams(param["components"])
+	}
+}
+
+// ABIToJSON renders an on-chain proto ABI (as returned by GetContractABI) as
+// a canonical JSON ABI string. The proto format does not carry tuple
+// components, so contracts with struct parameters cannot be fully described
+// this way.
+func ABIToJSON(contractABI *core.SmartContract_ABI) (string, error) {
+	out, err := json.Marshal(FormatABI(contractABI))
+	if err != nil {
+		return "", fmt.Errorf("marshal ABI: %w", err)
+	}
+	return string(out), nil
+}
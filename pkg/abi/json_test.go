@@ -0,0 +1,56 @@
+package abi
+
+import (
+	"testing"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseABI_TuplesAndTrcToken(t *testing.T) {
+	abiJSON := `[{"type":"function","name":"deposit","stateMutability":"payable",
+		"inputs":[
+			{"name":"id","type":"trcToken"},
+			{"name":"ids","type":"trcToken[]"},
+			{"name":"order","type":"tuple","components":[
+				{"name":"maker","type":"address"},
+				{"name":"token","type":"trcToken"}]}],
+		"outputs":[]}]`
+
+	parsed, err := ParseABI(abiJSON)
+	require.NoError(t, err)
+
+	m, ok := parsed.Methods["deposit"]
+	require.True(t, ok)
+	assert.Equal(t, "deposit(uint256,uint256[],(address,uint256))", m.Sig)
+	assert.Equal(t, eABI.TupleTy, m.Inputs[2].Type.T)
+	assert.Equal(t, []string{"maker", "token"}, m.Inputs[2].Type.TupleRawNames)
+}
+
+func TestParseABI_Invalid(t *testing.T) {
+	_, err := ParseABI(`{`)
+	assert.Error(t, err)
+	_, err = ParseABI(`[{"type":"function","name":"f","inputs":[{"name":"x","type":"strin"}]}]`)
+	assert.Error(t, err)
+}
+
+func TestABIToJSON_RoundTrip(t *testing.T) {
+	contractABI := &core.SmartContract_ABI{Entrys: []*core.SmartContract_ABI_Entry{{
+		Type:            core.SmartContract_ABI_Entry_Function,
+		Name:            "balanceOf",
+		Constant:        true,
+		StateMutability: core.SmartContract_ABI_Entry_View,
+		Inputs:          []*core.SmartContract_ABI_Entry_Param{{Name: "owner", Type: "address"}},
+		Outputs:         []*core.SmartContract_ABI_Entry_Param{{Type: "uint256"}},
+	}}}
+
+	abiJSON, err := ABIToJSON(contractABI)
+	require.NoError(t, err)
+
+	parsed, err := ParseABI(abiJSON)
+	require.NoError(t, err)
+	assert.Equal(t, "balanceOf(address)", parsed.Methods["balanceOf"].Sig)
+	assert.True(t, parsed.Methods["balanceOf"].IsConstant())
+}
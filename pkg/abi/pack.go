@@ -0,0 +1,233 @@
+package abi
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	eCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+)
+
+// PackArgs ABI-encodes native Go values for args (typically a method's
+// inputs), without the 4-byte selector. See ConvertValue for the accepted
+// Go types.
+func PackArgs(args eABI.Arguments, values ...interface{}) ([]byte, error) {
+	if len(values) != len(args) {
+		return nil, fmt.Errorf("expected %d arguments, got %d", len(args), len(values))
+	}
+	converted := make([]interface{}, len(values))
+	for i, v := range values {
+		c, err := ConvertValue(args[i].Type, v)
+		if err != nil {
+			return nil, fmt.Errorf("argument %s: %w", argName(args[i], i), err)
+		}
+		converted[i] = c
+	}
+	return args.Pack(converted...)
+}
+
+// ConvertValue converts a native Go value into the representation
+// go-ethereum uses to encode ty:
+//
+//   - address: address.Address, a base58 string or an eCommon.Address
+//   - intN/uintN: *big.Int, big.Int, any Go integer, or a decimal/0x string;
+//     values out of range for N bits are rejected
+//   - bool, string: the Go equivalent
+//   - bytes: []byte or a byte array; bytesN: [N]byte or an N-byte slice
+//   - T[] and T[k]: slices or arrays of convertible elements
+//   - tuple: a struct whose fields are matched to components as in
+//     CopyValues (by `abi:"name"` tag, by name, then by position)
+//
+// Pointers are dereferenced.
+func ConvertValue(ty eABI.Type, v interface{}) (interface{}, error) {
+	out, err := toABIValue(ty, reflect.ValueOf(v))
+	if err != nil {
+		return nil, err
+	}
+	return out.Interface(), nil
+}
+
+func toABIValue(ty eABI.Type, v reflect.Value) (reflect.Value, error) {
+	for v.IsValid() && (v.Kind() == reflect.Interface || (v.Kind() == reflect.Ptr && v.Type() != bigIntPtrType)) {
+		if v.IsNil() {
+			return reflect.Value{}, fmt.Errorf("nil value for %s", ty.String())
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() || (v.Type() == bigIntPtrType && v.IsNil()) {
+		return reflect.Value{}, fmt.Errorf("nil value for %s", ty.String())
+	}
+
+	target := ty.GetType()
+	switch ty.T {
+	case eABI.AddressTy:
+		return toEthAddress(v)
+
+	case eABI.IntTy, eABI.UintTy:
+		n, err := toBigInt(v)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("%s: %w", ty.String(), err)
+		}
+		if !fitsInt(n, ty) {
+			return reflect.Value{}, fmt.Errorf("value %s out of range for %s", n, ty.String())
+		}
+		if target == bigIntPtrType {
+			return reflect.ValueOf(n), nil
+		}
+		out := reflect.New(target).Elem()
+		if ty.T == eABI.IntTy {
+			out.SetInt(n.Int64())
+		} else {
+			out.SetUint(n.Uint64())
+		}
+		return out, nil
+
+	case eABI.BoolTy, eABI.StringTy:
+		if v.Kind() != target.Kind() {
+			return reflect.Value{}, fmt.Errorf("cannot use %s as %s", v.Type(), ty.String())
+		}
+		return v.Convert(target), nil
+
+	case eABI.BytesTy:
+		if !isByteSequence(v) {
+			return reflect.Value{}, fmt.Errorf("cannot use %s as bytes", v.Type())
+		}
+		out := make([]byte, v.Len())
+		reflect.Copy(reflect.ValueOf(out), v)
+		return reflect.ValueOf(out), nil
+
+	case eABI.FixedBytesTy, eABI.FunctionTy:
+		if !isByteSequence(v) || v.Len() != target.Len() {
+			return reflect.Value{}, fmt.Errorf("cannot use %s as %s", v.Type(), ty.String())
+		}
+		out := reflect.New(target).Elem()
+		reflect.Copy(out, v)
+		return out, nil
+
+	case eABI.SliceTy, eABI.ArrayTy:
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return reflect.Value{}, fmt.Errorf("cannot use %s as %s", v.Type(), ty.String())
+		}
+		if ty.T == eABI.ArrayTy && v.Len() != ty.Size {
+			return reflect.Value{}, fmt.Errorf("expected %d elements for %s, got %d", ty.Size, ty.String(), v.Len())
+		}
+		var out reflect.Value
+		if ty.T == eABI.SliceTy {
+			out = reflect.MakeSlice(target, v.Len(), v.Len())
+		} else {
+			out = reflect.New(target).Elem()
+		}
+		for i := 0; i < v.Len(); i++ {
+			elem, err := toABIValue(*ty.Elem, v.Index(i))
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("element %d: %w", i, err)
+			}
+			out.Index(i).Set(elem)
+		}
+		return out, nil
+
+	case eABI.TupleTy:
+		if !isPlainStruct(v.Type()) {
+			return reflect.Value{}, fmt.Errorf("cannot use %s as %s", v.Type(), ty.String())
+		}
+		fields, err := matchFields(v.Type(), ty.TupleRawNames)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		out := reflect.New(target).Elem()
+		for i, elemTy := range ty.TupleElems {
+			if fields[i] < 0 {
+				return reflect.Value{}, fmt.Errorf("%s has no field for component %s", v.Type(), ty.TupleRawNames[i])
+			}
+			field, err := toABIValue(*elemTy, v.Field(fields[i]))
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("field %s: %w", ty.TupleRawNames[i], err)
+			}
+			out.Field(i).Set(field)
+		}
+		return out, nil
+	}
+
+	if v.Type().AssignableTo(target) {
+		return v, nil
+	}
+	return reflect.Value{}, fmt.Errorf("cannot use %s as %s", v.Type(), ty.String())
+}
+
+// toEthAddress converts a TRON address value into the 20-byte form used in
+// ABI encoding.
+func toEthAddress(v reflect.Value) (reflect.Value, error) {
+	switch {
+	case v.Type() == ethAddressType:
+		return v, nil
+	case v.Type() == addressType:
+		b := v.Bytes()
+		if len(b) != address.AddressLength {
+			return reflect.Value{}, fmt.Errorf("invalid address length %d", len(b))
+		}
+		return reflect.ValueOf(eCommon.BytesToAddress(b[1:])), nil
+	case v.Kind() == reflect.String:
+		a, err := address.Base58ToAddress(v.String())
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid address %q: %w", v.String(), err)
+		}
+		return reflect.ValueOf(eCommon.BytesToAddress(a.Bytes()[1:])), nil
+	}
+	return reflect.Value{}, fmt.Errorf("cannot use %s as address", v.Type())
+}
+
+// toBigInt reads an integer from a Go integer, *big.Int, big.Int or a
+// decimal or 0x-prefixed hex string.
+func toBigInt(v reflect.Value) (*big.Int, error) {
+	switch {
+	case v.Type() == bigIntPtrType:
+		return new(big.Int).Set(v.Interface().(*big.Int)), nil
+	case v.Type() == bigIntType:
+		n := v.Interface().(big.Int)
+		return new(big.Int).Set(&n), nil
+	case isSigned(v.Kind()):
+		return big.NewInt(v.Int()), nil
+	case isInteger(v.Kind()):
+		return new(big.Int).SetUint64(v.Uint()), nil
+	case v.Kind() == reflect.String:
+		return parseBigInt(v.String())
+	}
+	return nil, fmt.Errorf("cannot use %s as integer", v.Type())
+}
+
+// parseBigInt parses a decimal string, or a hex string after an explicit 0x
+// prefix. Leading zeros stay decimal, unlike big.Int.SetString with base 0,
+// which would read "010" as octal 8.
+func parseBigInt(s string) (*big.Int, error) {
+	digits, neg := strings.CutPrefix(s, "-")
+	base := 10
+	if hexDigits, ok := strings.CutPrefix(digits, "0x"); ok {
+		digits, base = hexDigits, 16
+	} else if hexDigits, ok := strings.CutPrefix(digits, "0X"); ok {
+		digits, base = hexDigits, 16
+	}
+	n, ok := new(big.Int).SetString(digits, base)
+	if !ok || strings.HasPrefix(digits, "+") || strings.HasPrefix(digits, "-") {
+		return nil, fmt.Errorf("invalid integer %q", s)
+	}
+	if neg {
+		n.Neg(n)
+	}
+	return n, nil
+}
+
+// fitsInt reports whether n is representable by the intN/uintN type ty.
+func fitsInt(n *big.Int, ty eABI.Type) bool {
+	if ty.T == eABI.UintTy {
+		return n.Sign() >= 0 && n.BitLen() <= ty.Size
+	}
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(ty.Size-1))
+	return n.Cmp(limit) < 0 && n.Cmp(new(big.Int).Neg(limit)) >= 0
+}
+
+func isByteSequence(v reflect.Value) bool {
+	return (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Type().Elem().Kind() == reflect.Uint8
+}
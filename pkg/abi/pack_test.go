@@ -0,0 +1,102 @@
+package abi
+
+import (
+	"math/big"
+	"testing"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	eCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustType(t *testing.T, ty string) eABI.Type {
+	t.Helper()
+	typ, err := eABI.NewType(ty, "", nil)
+	require.NoError(t, err)
+	return typ
+}
+
+func TestConvertValue(t *testing.T) {
+	holder, err := address.Base58ToAddress("TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1")
+	require.NoError(t, err)
+	evm := eCommon.BytesToAddress(holder.Bytes()[1:])
+	big255 := big.NewInt(255)
+
+	tests := []struct {
+		name    string
+		ty      string
+		value   interface{}
+		want    interface{}
+		wantErr string
+	}{
+		{"address", "address", holder, evm, ""},
+		{"address base58", "address", holder.String(), evm, ""},
+		{"address pointer", "address", &holder, evm, ""},
+		{"address invalid", "address", "TInvalid", nil, "invalid address"},
+		{"uint8 from int", "uint8", 200, uint8(200), ""},
+		{"uint8 overflow", "uint8", 256, nil, "out of range"},
+		{"uint256 negative", "uint256", -1, nil, "out of range"},
+		{"uint256 from string", "uint256", "0xff", big255, ""},
+		{"uint256 from decimal string", "uint256", "255", big255, ""},
+		{"leading zero is decimal", "uint256", "010", big.NewInt(10), ""},
+		{"int256 from negative hex", "int256", "-0x0a", big.NewInt(-10), ""},
+		{"octal prefix rejected", "uint256", "0o17", nil, "invalid integer"},
+		{"binary prefix rejected", "uint256", "0b101", nil, "invalid integer"},
+		{"underscores rejected", "uint256", "1_000", nil, "invalid integer"},
+		{"double sign rejected", "int256", "--5", nil, "invalid integer"},
+		{"int16 from big", "int16", big.NewInt(-32768), int16(-32768), ""},
+		{"int16 overflow", "int16", big.NewInt(32768), nil, "out of range"},
+		{"int256 from big value", "int256", *big.NewInt(-5), big.NewInt(-5), ""},
+		{"nil big", "uint256", (*big.Int)(nil), nil, "nil value"},
+		{"bool", "bool", true, true, ""},
+		{"string mismatch", "string", 1, nil, "cannot use int"},
+		{"bytes from array", "bytes", [2]byte{1, 2}, []byte{1, 2}, ""},
+		{"bytes4 from slice", "bytes4", []byte{1, 2, 3, 4}, [4]byte{1, 2, 3, 4}, ""},
+		{"bytes4 wrong length", "bytes4", []byte{1, 2, 3}, nil, "cannot use"},
+		{"uint16 array", "uint16[2]", []int{1, 2}, [2]uint16{1, 2}, ""},
+		{"array wrong length", "uint16[2]", []int{1}, nil, "expected 2 elements"},
+		{"address slice", "address[]", []string{holder.String()}, []eCommon.Address{evm}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConvertValue(mustType(t, tt.ty), tt.value)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPackArgs_TupleRoundTrip(t *testing.T) {
+	parsed, err := ParseABI(unpackTestABI)
+	require.NoError(t, err)
+	outputs := parsed.Methods["getOrder"].Outputs
+
+	holder, err := address.Base58ToAddress("TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1")
+	require.NoError(t, err)
+	in := testOrder{
+		Maker:   holder,
+		Amounts: []*big.Int{big.NewInt(10)},
+		Legs:    []testLeg{{Token: holder, Quantity: 4}},
+		Note:    "ignored",
+	}
+	data, err := PackArgs(outputs, in, true)
+	require.NoError(t, err)
+
+	var out struct {
+		Order  testOrder
+		Active bool
+	}
+	require.NoError(t, UnpackInto(outputs, data, &out))
+	in.Note = ""
+	assert.Equal(t, in, out.Order)
+	assert.True(t, out.Active)
+
+	_, err = PackArgs(outputs, in)
+	assert.ErrorContains(t, err, "expected 2 arguments")
+}
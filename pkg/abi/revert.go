@@ -0,0 +1,114 @@
+package abi
+
+import (
+	"fmt"
+	"strings"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// RevertError is a decoded contract revert. It covers the built-in
+// Error(string) and Panic(uint256) reasons as well as Solidity custom errors
+// declared in the contract ABI.
+type RevertError struct {
+	// Name is the error name: "Error", "Panic" or the custom error's name.
+	// It is empty when the revert carries no data or an unknown selector.
+	Name string
+	// Signature is the canonical error signature, e.g.
+	// "InsufficientBalance(uint256,uint256)".
+	Signature string
+	// Args holds the decoded error arguments in declaration order, with
+	// addresses converted to address.Address.
+	Args []interface{}
+	// Reason is a human-readable description: the Error(string) message, the
+	// Panic code description, or the node's message when nothing else is
+	// known.
+	Reason string
+	// Data is the raw revert data.
+	Data []byte
+
+	inputs eABI.Arguments
+	values []interface{} // Args as unpacked by go-ethereum
+}
+
+// Error implements the error interface.
+func (e *RevertError) Error() string {
+	switch {
+	case e.Name == "Error" || e.Name == "Panic":
+		return "execution reverted: " + e.Reason
+	case e.Name != "":
+		parts := make([]string, len(e.Args))
+		for i, arg := range e.Args {
+			parts[i] = fmt.Sprintf("%s=%v", e.inputs[i].Name, arg)
+		}
+		return fmt.Sprintf("execution reverted: %s(%s)", e.Name, strings.Join(parts, ", "))
+	case len(e.Data) >= 4:
+		return fmt.Sprintf("execution reverted: unknown error 0x%x", e.Data[:4])
+	case e.Reason != "":
+		return "execution reverted: " + e.Reason
+	}
+	return "execution reverted"
+}
+
+// Arg returns the custom error argument with the given ABI name.
+func (e *RevertError) Arg(name string) (interface{}, bool) {
+	for i, input := range e.inputs {
+		if input.Name == name {
+			return e.Args[i], true
+		}
+	}
+	return nil, false
+}
+
+// DecodeArgs stores the error arguments into the struct out points to,
+// matching fields as described by CopyValues.
+func (e *RevertError) DecodeArgs(out interface{}) error {
+	if e.inputs == nil {
+		return fmt.Errorf("revert has no decoded arguments")
+	}
+	return CopyValues(out, e.inputs, e.values)
+}
+
+// DecodeRevert decodes revert data returned by a failed call. The selector is
+// matched against Error(string), Panic(uint256) and, when contractABI is not
+// nil, the error entries of the contract ABI. Data that cannot be decoded
+// still yields a RevertError carrying the raw bytes.
+func DecodeRevert(contractABI *eABI.ABI, data []byte) *RevertError {
+	rerr := &RevertError{Data: data}
+	if len(data) < 4 {
+		return rerr
+	}
+
+	switch [4]byte(data[:4]) {
+	case revertSelector:
+		if reason, err := decodeErrorString(data[4:]); err == nil {
+			rerr.Name, rerr.Signature, rerr.Reason = "Error", "Error(string)", reason
+			rerr.Args = []interface{}{reason}
+		}
+		return rerr
+	case panicSelector:
+		if reason, err := decodePanicReason(data[4:]); err == nil {
+			rerr.Name, rerr.Signature, rerr.Reason = "Panic", "Panic(uint256)", reason
+		}
+		return rerr
+	}
+
+	if contractABI == nil {
+		return rerr
+	}
+	errABI, err := contractABI.ErrorByID([4]byte(data[:4]))
+	if err != nil {
+		return rerr
+	}
+	values, err := errABI.Inputs.Unpack(data[4:])
+	if err != nil {
+		return rerr
+	}
+	rerr.Name, rerr.Signature = errABI.Name, errABI.Sig
+	rerr.inputs, rerr.values = errABI.Inputs, values
+	rerr.Args = make([]interface{}, len(values))
+	for i, v := range values {
+		rerr.Args[i] = convertOutputValue(v)
+	}
+	return rerr
+}
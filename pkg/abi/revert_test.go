@@ -0,0 +1,84 @@
+package abi
+
+import (
+	"math/big"
+	"testing"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	eCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const revertTestABI = `[
+ {"type":"error","name":"InsufficientBalance","inputs":[
+  {"name":"account","type":"address"},{"name":"available","type":"uint256"},{"name":"required","type":"uint256"}]},
+ {"type":"error","name":"Paused","inputs":[]}
+]`
+
+func packError(t *testing.T, parsed eABI.ABI, name string, args ...interface{}) []byte {
+	t.Helper()
+	e := parsed.Errors[name]
+	data, err := e.Inputs.Pack(args...)
+	require.NoError(t, err)
+	return append(append([]byte{}, e.ID[:4]...), data...)
+}
+
+func TestDecodeRevert_CustomError(t *testing.T) {
+	parsed, err := ParseABI(revertTestABI)
+	require.NoError(t, err)
+	holder, err := address.Base58ToAddress("TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1")
+	require.NoError(t, err)
+
+	data := packError(t, parsed, "InsufficientBalance", eCommon.BytesToAddress(holder.Bytes()[1:]), big.NewInt(5), big.NewInt(10))
+	rerr := DecodeRevert(&parsed, data)
+
+	assert.Equal(t, "InsufficientBalance", rerr.Name)
+	assert.Equal(t, "InsufficientBalance(address,uint256,uint256)", rerr.Signature)
+	require.Len(t, rerr.Args, 3)
+	assert.Equal(t, holder, rerr.Args[0])
+	assert.Equal(t, data, rerr.Data)
+	assert.Equal(t, "execution reverted: InsufficientBalance(account="+holder.String()+", available=5, required=10)", rerr.Error())
+
+	required, ok := rerr.Arg("required")
+	require.True(t, ok)
+	assert.Equal(t, big.NewInt(10), required)
+	_, ok = rerr.Arg("missing")
+	assert.False(t, ok)
+
+	var args struct {
+		Account   address.Address
+		Available *big.Int
+		Needed    *big.Int `abi:"required"`
+	}
+	require.NoError(t, rerr.DecodeArgs(&args))
+	assert.Equal(t, holder, args.Account)
+	assert.Equal(t, int64(10), args.Needed.Int64())
+
+	paused := DecodeRevert(&parsed, packError(t, parsed, "Paused"))
+	assert.Equal(t, "execution reverted: Paused()", paused.Error())
+}
+
+func TestDecodeRevert_BuiltinsAndUnknown(t *testing.T) {
+	strTy, _ := eABI.NewType("string", "", nil)
+	reason, err := eABI.Arguments{{Type: strTy}}.Pack("not owner")
+	require.NoError(t, err)
+	rerr := DecodeRevert(nil, append(revertSelector[:], reason...))
+	assert.Equal(t, "Error", rerr.Name)
+	assert.Equal(t, "not owner", rerr.Reason)
+	assert.Equal(t, "execution reverted: not owner", rerr.Error())
+
+	panicData := append(panicSelector[:], eCommon.LeftPadBytes([]byte{0x11}, 32)...)
+	rerr = DecodeRevert(nil, panicData)
+	assert.Equal(t, "Panic", rerr.Name)
+	assert.Contains(t, rerr.Error(), "panic: arithmetic")
+
+	// A custom error without the ABI stays undecoded.
+	rerr = DecodeRevert(nil, []byte{0xde, 0xad, 0xbe, 0xef})
+	assert.Empty(t, rerr.Name)
+	assert.Equal(t, "execution reverted: unknown error 0xdeadbeef", rerr.Error())
+	assert.Error(t, rerr.DecodeArgs(&struct{}{}))
+
+	assert.Equal(t, "execution reverted", DecodeRevert(nil, nil).Error())
+}
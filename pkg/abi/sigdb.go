@@ -0,0 +1,342 @@
+package abi
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"golang.org/x/crypto/sha3"
+)
+
+//go:embed signatures.txt
+var embeddedSignatures []byte
+
+// SignatureDB maps 4-byte function selectors and 32-byte event topics back
+// to their canonical signatures, so call data and logs can be decoded
+// without the contract ABI. Several signatures may share a selector; lookups
+// return all of them in insertion order.
+//
+// A SignatureDB is safe for concurrent use.
+type SignatureDB struct {
+	mu        sync.RWMutex
+	functions map[[4]byte][]*sigEntry
+	events    map[[32]byte][]*sigEntry
+}
+
+type sigEntry struct {
+	sig    string
+	name   string
+	inputs eABI.Arguments
+}
+
+// DecodedCall is call data decoded against a signature from a SignatureDB.
+type DecodedCall struct {
+	// Name is the function name, e.g. "transfer".
+	Name string
+	// Signature is the canonical signature, e.g. "transfer(address,uint256)".
+	Signature string
+	// Args holds the decoded arguments in order, with addresses converted
+	// to address.Address. Tuples are returned as go-ethereum structs.
+	Args []interface{}
+}
+
+// NewSignatureDB returns an empty signature database.
+func NewSignatureDB() *SignatureDB {
+	return &SignatureDB{
+		functions: make(map[[4]byte][]*sigEntry),
+		events:    make(map[[32]byte][]*sigEntry),
+	}
+}
+
+var (
+	defaultDB     *SignatureDB
+	defaultDBOnce sync.Once
+)
+
+// DefaultSignatureDB returns the shared database preloaded with the embedded
+// list of common TRC-20, TRC-721, TRC-1155, proxy, multicall, DEX and lending
+// signatures. Signatures added to it are visible to every user of the
+// package, including the transaction decoder.
+func DefaultSignatureDB() *SignatureDB {
+	defaultDBOnce.Do(func() {
+		defaultDB = NewSignatureDB()
+		if err := defaultDB.Load(bytes.NewReader(embeddedSignatures)); err != nil {
+			panic(fmt.Sprintf("abi: invalid embedded signatures: %v", err))
+		}
+	})
+	return defaultDB
+}
+
+// LookupSelector returns the known function signatures for a 4-byte
+// selector in the default database.
+func LookupSelector(selector []byte) []string {
+	return DefaultSignatureDB().LookupSelector(selector)
+}
+
+// LookupEvent returns the known event signatures for a 32-byte topic0 in
+// the default database.
+func LookupEvent(topic []byte) []string {
+	return DefaultSignatureDB().LookupEvent(topic)
+}
+
+// DecodeCalldata decodes call data using the default database. See
+// SignatureDB.DecodeCalldata.
+func DecodeCalldata(data []byte) (*DecodedCall, error) {
+	return DefaultSignatureDB().DecodeCalldata(data)
+}
+
+// AddFunction registers a function signature such as
+// "transfer(address,uint256)". Parameter names are ignored and the signature
+// is stored in canonical form. Adding a known signature is a no-op.
+func (db *SignatureDB) AddFunction(signature string) error {
+	e, err := parseSignature(signature)
+	if err != nil {
+		return err
+	}
+	var selector [4]byte
+	copy(selector[:], keccak(e.sig))
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if !hasSignature(db.functions[selector], e.sig) {
+		db.functions[selector] = append(db.functions[selector], e)
+	}
+	return nil
+}
+
+// AddEvent registers an event signature such as
+// "Transfer(address,address,uint256)". Parameter names and the indexed
+// keyword are ignored. Adding a known signature is a no-op.
+func (db *SignatureDB) AddEvent(signature string) error {
+	e, err := parseSignature(signature)
+	if err != nil {
+		return err
+	}
+	var topic [32]byte
+	copy(topic[:], keccak(e.sig))
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if !hasSignature(db.events[topic], e.sig) {
+		db.events[topic] = append(db.events[topic], e)
+	}
+	return nil
+}
+
+// Load reads signatures from r, one per line, each prefixed by "function"
+// or "event":
+//
+//	# comment
+//	function transfer(address,uint256)
+//	event Transfer(address,address,uint256)
+//
+// Blank lines and lines starting with '#' are skipped.
+func (db *SignatureDB) Load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		kind, sig, _ := strings.Cut(line, " ")
+		var err error
+		switch kind {
+		case "function":
+			err = db.AddFunction(sig)
+		case "event":
+			err = db.AddEvent(sig)
+		default:
+			err = fmt.Errorf("unknown entry kind %q", kind)
+		}
+		if err != nil {
+			return fmt.Errorf("line %d: %w", lineNo, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// LookupSelector returns the function signatures matching the first four
+// bytes of selector, or nil when none are known.
+func (db *SignatureDB) LookupSelector(selector []byte) []string {
+	if len(selector) < 4 {
+		return nil
+	}
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return signatures(db.functions[[4]byte(selector[:4])])
+}
+
+// LookupEvent returns the event signatures whose topic0 is topic, or nil
+// when none are known.
+func (db *SignatureDB) LookupEvent(topic []byte) []string {
+	if len(topic) != 32 {
+		return nil
+	}
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return signatures(db.events[[32]byte(topic)])
+}
+
+// DecodeCalldata decodes call data (selector followed by ABI-encoded
+// arguments) without a contract ABI. Each signature registered for the
+// selector is tried in turn; the first one whose arguments decode and
+// re-encode to exactly the same bytes is returned.
+func (db *SignatureDB) DecodeCalldata(data []byte) (*DecodedCall, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("call data too short: %d bytes", len(data))
+	}
+	db.mu.RLock()
+	candidates := db.functions[[4]byte(data[:4])]
+	db.mu.RUnlock()
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("unknown selector 0x%x", data[:4])
+	}
+
+	for _, e := range candidates {
+		values, err := e.inputs.Unpack(data[4:])
+		if err != nil {
+			continue
+		}
+		packed, err := e.inputs.Pack(values...)
+		if err != nil || !bytes.Equal(packed, data[4:]) {
+			continue
+		}
+		args := make([]interface{}, len(values))
+		for i, v := range values {
+			args[i] = convertOutputValue(v)
+		}
+		return &DecodedCall{Name: e.name, Signature: e.sig, Args: args}, nil
+	}
+	return nil, fmt.Errorf("call data does not match any signature for selector 0x%x", data[:4])
+}
+
+// parseSignature parses "name(type,...)" into a canonical entry. Tuple
+// parameters use the "(type,...)" form, optionally followed by array
+// suffixes.
+func parseSignature(signature string) (*sigEntry, error) {
+	signature = strings.TrimSpace(signature)
+	open := strings.Index(signature, "(")
+	if open <= 0 || !strings.HasSuffix(signature, ")") {
+		return nil, fmt.Errorf("invalid signature %q", signature)
+	}
+	name := strings.TrimSpace(signature[:open])
+	types, err := parseMethodTypes(signature)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make(eABI.Arguments, len(types))
+	canonical := make([]string, len(types))
+	for i, t := range types {
+		m, err := signatureArgument(t)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature %q: %w", signature, err)
+		}
+		ty, err := eABI.NewType(m.Type, "", m.Components)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature %q: %w", signature, err)
+		}
+		args[i] = eABI.Argument{Type: ty}
+		canonical[i] = ty.String()
+	}
+	return &sigEntry{
+		sig:    name + "(" + strings.Join(canonical, ",") + ")",
+		name:   name,
+		inputs: args,
+	}, nil
+}
+
+// signatureArgument converts one signature parameter into the JSON ABI
+// form understood by eABI.NewType. Parameter names and the "indexed"
+// keyword are dropped.
+func signatureArgument(t string) (eABI.ArgumentMarshaling, error) {
+	if !strings.HasPrefix(t, "(") {
+		fields := strings.Fields(t)
+		if len(fields) == 0 {
+			return eABI.ArgumentMarshaling{}, fmt.Errorf("empty parameter type")
+		}
+		return eABI.ArgumentMarshaling{Type: normalizeTypeName(fields[0])}, nil
+	}
+
+	depth, end := 0, -1
+	for i, c := range t {
+		if c == '(' {
+			depth++
+		} else if c == ')' {
+			depth--
+			if depth == 0 {
+				end = i
+				break
+			}
+		}
+	}
+	if end < 0 {
+		return eABI.ArgumentMarshaling{}, fmt.Errorf("unbalanced parentheses in %q", t)
+	}
+
+	var components []eABI.ArgumentMarshaling
+	if inner := strings.TrimSpace(t[1:end]); inner != "" {
+		for i, ct := range splitTypes(inner) {
+			c, err := signatureArgument(ct)
+			if err != nil {
+				return eABI.ArgumentMarshaling{}, err
+			}
+			c.Name = fmt.Sprintf("f%d", i)
+			components = append(components, c)
+		}
+	}
+	suffix := t[end+1:]
+	if fields := strings.Fields(suffix); len(fields) > 0 && strings.HasPrefix(fields[0], "[") {
+		suffix = fields[0]
+	} else {
+		suffix = ""
+	}
+	return eABI.ArgumentMarshaling{Type: "tuple" + suffix, Components: components}, nil
+}
+
+// normalizeTypeName expands the uint/int aliases and maps trcToken to its
+// ABI encoding so signatures hash the way the compiler does.
+func normalizeTypeName(t string) string {
+	base, suffix := t, ""
+	if i := strings.Index(t, "["); i >= 0 {
+		base, suffix = t[:i], t[i:]
+	}
+	switch base {
+	case "uint", trcTokenType:
+		base = "uint256"
+	case "int":
+		base = "int256"
+	}
+	return base + suffix
+}
+
+func keccak(s string) []byte {
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write([]byte(s))
+	return hasher.Sum(nil)
+}
+
+func hasSignature(entries []*sigEntry, sig string) bool {
+	for _, e := range entries {
+		if e.sig == sig {
+			return true
+		}
+	}
+	return false
+}
+
+func signatures(entries []*sigEntry) []string {
+	if len(entries) == 0 {
+		return nil
+	}
+	out := make([]string, len(entries))
+	for i, e := range entries {
+		out[i] = e.sig
+	}
+	return out
+}
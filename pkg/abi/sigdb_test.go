@@ -0,0 +1,98 @@
+package abi
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultSignatureDB_Lookup(t *testing.T) {
+	assert.Equal(t, []string{"transfer(address,uint256)"}, LookupSelector([]byte{0xa9, 0x05, 0x9c, 0xbb}))
+	assert.Equal(t, []string{"aggregate3((address,bool,bytes)[])"}, LookupSelector([]byte{0x82, 0xad, 0x56, 0xcb}))
+	assert.Nil(t, LookupSelector([]byte{0xde, 0xad, 0xbe, 0xef}))
+	assert.Nil(t, LookupSelector([]byte{0xa9}))
+
+	topic, err := hex.DecodeString("ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Transfer(address,address,uint256)"}, LookupEvent(topic))
+	assert.Nil(t, LookupEvent(topic[:4]))
+}
+
+func TestSignatureDB_AddAndLoad(t *testing.T) {
+	db := NewSignatureDB()
+	require.NoError(t, db.AddFunction("transfer(address to, uint amount)"))
+	require.NoError(t, db.AddFunction("transfer(address,uint256)"))
+	assert.Equal(t, []string{"transfer(address,uint256)"}, db.LookupSelector([]byte{0xa9, 0x05, 0x9c, 0xbb}))
+
+	require.NoError(t, db.AddEvent("Transfer(address indexed from, address indexed to, uint256 value)"))
+	topic, err := hex.DecodeString("ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Transfer(address,address,uint256)"}, db.LookupEvent(topic))
+
+	assert.Error(t, db.AddFunction("transfer"))
+	assert.Error(t, db.AddFunction("transfer(strin)"))
+
+	err = db.Load(strings.NewReader("# swaps\nfunction swap(uint256,uint256,address,bytes)\n\nevent Sync(uint112,uint112)\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"swap(uint256,uint256,address,bytes)"}, db.LookupSelector(Signature("swap(uint256,uint256,address,bytes)")))
+
+	err = db.Load(strings.NewReader("function ok()\nconstructor(uint256)\n"))
+	assert.ErrorContains(t, err, "line 2")
+}
+
+func TestSignatureDB_DecodeCalldata(t *testing.T) {
+	to, err := address.Base58ToAddress("TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1")
+	require.NoError(t, err)
+
+	db := NewSignatureDB()
+	require.NoError(t, db.AddFunction("swapExactTokensForTokens(uint256,uint256,address[],address,uint256)"))
+	entry, err := parseSignature("swapExactTokensForTokens(uint256,uint256,address[],address,uint256)")
+	require.NoError(t, err)
+	args, err := PackArgs(entry.inputs, 1000, "900", []address.Address{to, to}, to, 1700000000)
+	require.NoError(t, err)
+	data := append(Signature(entry.sig), args...)
+
+	call, err := db.DecodeCalldata(data)
+	require.NoError(t, err)
+	assert.Equal(t, "swapExactTokensForTokens", call.Name)
+	assert.Equal(t, entry.sig, call.Signature)
+	require.Len(t, call.Args, 5)
+	assert.Equal(t, big.NewInt(900), call.Args[1])
+	assert.Equal(t, []address.Address{to, to}, call.Args[2])
+	assert.Equal(t, to, call.Args[3])
+
+	_, err = db.DecodeCalldata(data[:40])
+	assert.ErrorContains(t, err, "does not match")
+	_, err = db.DecodeCalldata([]byte{0xde, 0xad, 0xbe, 0xef})
+	assert.ErrorContains(t, err, "unknown selector")
+	_, err = db.DecodeCalldata([]byte{0x01})
+	assert.Error(t, err)
+}
+
+func TestSignatureDB_DecodeCalldataTuples(t *testing.T) {
+	to, err := address.Base58ToAddress("TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1")
+	require.NoError(t, err)
+
+	entry, err := parseSignature("aggregate3((address,bool,bytes)[])")
+	require.NoError(t, err)
+	type call3 struct {
+		Target       address.Address
+		AllowFailure bool
+		CallData     []byte
+	}
+	args, err := PackArgs(entry.inputs, []call3{{Target: to, AllowFailure: true, CallData: []byte{1, 2}}})
+	require.NoError(t, err)
+
+	call, err := DecodeCalldata(append(Signature(entry.sig), args...))
+	require.NoError(t, err)
+	assert.Equal(t, "aggregate3((address,bool,bytes)[])", call.Signature)
+
+	var out struct{ Calls []call3 }
+	require.NoError(t, CopyValues(&out, entry.inputs, call.Args))
+	assert.Equal(t, []call3{{Target: to, AllowFailure: true, CallData: []byte{1, 2}}}, out.Calls)
+}
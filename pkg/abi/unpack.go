@@ -0,0 +1,300 @@
+package abi
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	eCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+)
+
+var (
+	bigIntPtrType  = reflect.TypeOf((*big.Int)(nil))
+	bigIntType     = bigIntPtrType.Elem()
+	ethAddressType = reflect.TypeOf(eCommon.Address{})
+	addressType    = reflect.TypeOf(address.Address{})
+)
+
+// UnpackInto decodes ABI-encoded data described by args (typically a method's
+// outputs) into out, which must be a non-nil pointer.
+//
+// When args holds a single non-tuple value, out points to a variable of a
+// compatible Go type (e.g. *big.Int, address.Address, []string). Otherwise out
+// points to a struct; see CopyValues for how fields are matched. Addresses are
+// always converted to address.Address (or a base58 string).
+func UnpackInto(args eABI.Arguments, data []byte, out interface{}) error {
+	values, err := args.Unpack(data)
+	if err != nil {
+		return fmt.Errorf("unpack: %w", err)
+	}
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("out must be a non-nil pointer, got %T", out)
+	}
+	single := len(values) == 1 &&
+		(args[0].Type.T == eABI.TupleTy || !isPlainStruct(rv.Elem().Type()))
+	if single {
+		if err := assignValue(rv.Elem(), reflect.ValueOf(values[0])); err != nil {
+			return fmt.Errorf("%s: %w", argName(args[0], 0), err)
+		}
+		return nil
+	}
+	return CopyValues(out, args, values)
+}
+
+// CopyValues stores values unpacked for args into the struct out points to.
+//
+// Fields are matched by name: a field tagged `abi:"name"` receives the
+// argument with that ABI name, and an untagged field receives the argument
+// whose name converts to the field name (e.g. "_value" → Value). Unnamed
+// arguments fill the field at the same position. If no argument matches any
+// field by name, all fields are filled by position. Fields tagged `abi:"-"`
+// and arguments without a matching field are skipped. The same rules apply
+// recursively to tuples.
+func CopyValues(out interface{}, args eABI.Arguments, values []interface{}) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || !isPlainStruct(rv.Elem().Type()) {
+		return fmt.Errorf("out must be a non-nil pointer to struct, got %T", out)
+	}
+	if len(values) != len(args) {
+		return fmt.Errorf("got %d values for %d arguments", len(values), len(args))
+	}
+	names := make([]string, len(args))
+	srcs := make([]reflect.Value, len(values))
+	for i, arg := range args {
+		names[i] = arg.Name
+		srcs[i] = reflect.ValueOf(values[i])
+	}
+	return assignFields(rv.Elem(), names, srcs)
+}
+
+// assignFields stores srcs, named by names, into the fields of the struct dst.
+func assignFields(dst reflect.Value, names []string, srcs []reflect.Value) error {
+	t := dst.Type()
+	target, err := matchFields(t, names)
+	if err != nil {
+		return err
+	}
+	for i, idx := range target {
+		if idx < 0 {
+			continue
+		}
+		if err := assignValue(dst.Field(idx), srcs[i]); err != nil {
+			return fmt.Errorf("field %s: %w", t.Field(idx).Name, err)
+		}
+	}
+	return nil
+}
+
+// matchFields returns, for each ABI value name, the index of the field of
+// struct type t it maps to, or -1. The rules are documented on CopyValues.
+func matchFields(t reflect.Type, names []string) ([]int, error) {
+	byTag := make(map[string]int)
+	byName := make(map[string]int)
+	tagged := make(map[int]bool)
+	var positional []int // exported fields in declaration order
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag, hasTag := f.Tag.Lookup("abi")
+		switch {
+		case tag == "-":
+			continue
+		case hasTag:
+			byTag[tag] = i
+			tagged[i] = true
+		default:
+			byName[f.Name] = i
+		}
+		positional = append(positional, i)
+	}
+
+	target := make([]int, len(names))
+	matched := false
+	for i, name := range names {
+		target[i] = -1
+		if name == "" {
+			continue
+		}
+		if idx, ok := byTag[name]; ok {
+			target[i], matched = idx, true
+		} else if idx, ok := byName[eABI.ToCamelCase(name)]; ok {
+			target[i], matched = idx, true
+		}
+	}
+	for tag := range byTag {
+		if !containsString(names, tag) {
+			return nil, fmt.Errorf("%s: no ABI value named %q", t, tag)
+		}
+	}
+	taken := make(map[int]bool)
+	for _, idx := range target {
+		taken[idx] = true
+	}
+	for i, name := range names {
+		if target[i] >= 0 || (matched && name != "") || i >= len(positional) {
+			continue
+		}
+		if idx := positional[i]; !tagged[idx] && !taken[idx] {
+			target[i], taken[idx] = idx, true
+		}
+	}
+	return target, nil
+}
+
+// assignValue stores a value unpacked by go-ethereum into dst, converting EVM
+// addresses into TRON addresses and integers between compatible widths.
+func assignValue(dst, src reflect.Value) error {
+	for src.Kind() == reflect.Interface {
+		src = src.Elem()
+	}
+	if !src.IsValid() {
+		return nil
+	}
+
+	switch {
+	case dst.Kind() == reflect.Interface:
+		if src.Type() == ethAddressType {
+			src = reflect.ValueOf(ethToTronAddress(src.Interface().(eCommon.Address)))
+		}
+		if !src.Type().AssignableTo(dst.Type()) {
+			return fmt.Errorf("cannot store %s into %s", src.Type(), dst.Type())
+		}
+		dst.Set(src)
+		return nil
+
+	case src.Type() == ethAddressType:
+		tronAddr := ethToTronAddress(src.Interface().(eCommon.Address))
+		switch {
+		case dst.Type() == addressType:
+			dst.Set(reflect.ValueOf(tronAddr))
+		case dst.Kind() == reflect.String:
+			dst.SetString(tronAddr.String())
+		case dst.Type() == ethAddressType:
+			dst.Set(src)
+		default:
+			return fmt.Errorf("cannot store address into %s", dst.Type())
+		}
+		return nil
+
+	case dst.Kind() == reflect.Ptr && dst.Type() != bigIntPtrType:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return assignValue(dst.Elem(), src)
+
+	case src.Type() == bigIntPtrType || isInteger(src.Kind()):
+		return assignInteger(dst, src)
+
+	case src.Type().AssignableTo(dst.Type()):
+		dst.Set(src)
+		return nil
+
+	case dst.Kind() == reflect.Slice && (src.Kind() == reflect.Slice || src.Kind() == reflect.Array):
+		out := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			if err := assignValue(out.Index(i), src.Index(i)); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		dst.Set(out)
+		return nil
+
+	case dst.Kind() == reflect.Array && (src.Kind() == reflect.Slice || src.Kind() == reflect.Array):
+		if src.Len() != dst.Len() {
+			return fmt.Errorf("cannot store %d elements into %s", src.Len(), dst.Type())
+		}
+		for i := 0; i < src.Len(); i++ {
+			if err := assignValue(dst.Index(i), src.Index(i)); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		return nil
+
+	case isPlainStruct(dst.Type()) && src.Kind() == reflect.Struct:
+		// go-ethereum unpacks tuples into anonymous structs whose json tags
+		// carry the component names.
+		names := make([]string, src.NumField())
+		srcs := make([]reflect.Value, src.NumField())
+		for i := range names {
+			names[i] = strings.Split(src.Type().Field(i).Tag.Get("json"), ",")[0]
+			srcs[i] = src.Field(i)
+		}
+		return assignFields(dst, names, srcs)
+	}
+	return fmt.Errorf("cannot store %s into %s", src.Type(), dst.Type())
+}
+
+// assignInteger stores an unpacked integer (a sized Go integer or *big.Int)
+// into dst, failing if the value does not fit.
+func assignInteger(dst, src reflect.Value) error {
+	var n *big.Int
+	switch {
+	case src.Type() == bigIntPtrType:
+		if src.IsNil() {
+			return nil
+		}
+		n = src.Interface().(*big.Int)
+	case isSigned(src.Kind()):
+		n = big.NewInt(src.Int())
+	default:
+		n = new(big.Int).SetUint64(src.Uint())
+	}
+
+	switch {
+	case dst.Type() == bigIntPtrType:
+		dst.Set(reflect.ValueOf(new(big.Int).Set(n)))
+	case dst.Type() == bigIntType:
+		dst.Set(reflect.ValueOf(*new(big.Int).Set(n)))
+	case isSigned(dst.Kind()):
+		if !n.IsInt64() || dst.OverflowInt(n.Int64()) {
+			return fmt.Errorf("value %s overflows %s", n, dst.Type())
+		}
+		dst.SetInt(n.Int64())
+	case isInteger(dst.Kind()):
+		if !n.IsUint64() || dst.OverflowUint(n.Uint64()) {
+			return fmt.Errorf("value %s overflows %s", n, dst.Type())
+		}
+		dst.SetUint(n.Uint64())
+	case dst.Kind() == reflect.String:
+		dst.SetString(n.String())
+	default:
+		return fmt.Errorf("cannot store %s into %s", src.Type(), dst.Type())
+	}
+	return nil
+}
+
+// isPlainStruct reports whether t is a struct that should be filled field by
+// field, as opposed to a value type such as big.Int.
+func isPlainStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != bigIntType
+}
+
+func isSigned(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Int64
+}
+
+func isInteger(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Uintptr
+}
+
+func argName(arg eABI.Argument, index int) string {
+	if arg.Name != "" {
+		return arg.Name
+	}
+	return fmt.Sprintf("#%d", index)
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
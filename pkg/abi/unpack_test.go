@@ -0,0 +1,158 @@
+package abi
+
+import (
+	"math/big"
+	"testing"
+
+	eCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const unpackTestABI = `[
+ {"type":"function","name":"balanceOf","stateMutability":"view",
+  "inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+ {"type":"function","name":"getOrder","stateMutability":"view","inputs":[],
+  "outputs":[
+   {"name":"order","type":"tuple","components":[
+    {"name":"maker","type":"address"},
+    {"name":"amounts","type":"uint256[]"},
+    {"name":"legs","type":"tuple[]","components":[
+     {"name":"token","type":"address"},{"name":"qty","type":"uint64"}]}]},
+   {"name":"active","type":"bool"}]},
+ {"type":"function","name":"pair","stateMutability":"view","inputs":[],
+  "outputs":[{"name":"","type":"address"},{"name":"","type":"uint32"}]}
+]`
+
+type testLeg struct {
+	Token    address.Address
+	Quantity uint64 `abi:"qty"`
+}
+
+type testOrder struct {
+	Maker   address.Address
+	Amounts []*big.Int
+	Legs    []testLeg
+	Note    string // not part of the ABI
+}
+
+func TestUnpackInto_SingleValue(t *testing.T) {
+	parsed, err := ParseABI(unpackTestABI)
+	require.NoError(t, err)
+	outputs := parsed.Methods["balanceOf"].Outputs
+	data, err := outputs.Pack(big.NewInt(1234))
+	require.NoError(t, err)
+
+	var bal *big.Int
+	require.NoError(t, UnpackInto(outputs, data, &bal))
+	assert.Equal(t, int64(1234), bal.Int64())
+
+	var small uint16
+	require.NoError(t, UnpackInto(outputs, data, &small))
+	assert.Equal(t, uint16(1234), small)
+
+	var tiny uint8
+	assert.ErrorContains(t, UnpackInto(outputs, data, &tiny), "overflows")
+
+	var s string
+	require.NoError(t, UnpackInto(outputs, data, &s))
+	assert.Equal(t, "1234", s)
+
+	assert.ErrorContains(t, UnpackInto(outputs, data, small), "non-nil pointer")
+}
+
+func TestUnpackInto_TuplesByName(t *testing.T) {
+	parsed, err := ParseABI(unpackTestABI)
+	require.NoError(t, err)
+	outputs := parsed.Methods["getOrder"].Outputs
+
+	holder, err := address.Base58ToAddress("TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1")
+	require.NoError(t, err)
+	evm := eCommon.BytesToAddress(holder.Bytes()[1:])
+
+	type leg struct {
+		Token eCommon.Address `json:"token"`
+		Qty   uint64          `json:"qty"`
+	}
+	type order struct {
+		Maker   eCommon.Address `json:"maker"`
+		Amounts []*big.Int      `json:"amounts"`
+		Legs    []leg           `json:"legs"`
+	}
+	data, err := outputs.Pack(order{
+		Maker:   evm,
+		Amounts: []*big.Int{big.NewInt(1), big.NewInt(2)},
+		Legs:    []leg{{Token: evm, Qty: 7}, {Token: evm, Qty: 8}},
+	}, true)
+	require.NoError(t, err)
+
+	// Fields in a different order than the ABI, matched by name and tag.
+	var out struct {
+		IsActive bool `abi:"active"`
+		Order    testOrder
+	}
+	require.NoError(t, UnpackInto(outputs, data, &out))
+	assert.True(t, out.IsActive)
+	assert.Equal(t, holder, out.Order.Maker)
+	assert.Equal(t, []*big.Int{big.NewInt(1), big.NewInt(2)}, out.Order.Amounts)
+	assert.Equal(t, []testLeg{{Token: holder, Quantity: 7}, {Token: holder, Quantity: 8}}, out.Order.Legs)
+
+	var missing struct {
+		Order testOrder `abi:"nope"`
+	}
+	assert.ErrorContains(t, UnpackInto(outputs, data, &missing), `no ABI value named "nope"`)
+}
+
+func TestUnpackInto_Positional(t *testing.T) {
+	parsed, err := ParseABI(unpackTestABI)
+	require.NoError(t, err)
+	outputs := parsed.Methods["pair"].Outputs
+
+	holder, err := address.Base58ToAddress("TN3W4H6rK2ce4vX9YnFQHwKENnHjoxb3m9")
+	require.NoError(t, err)
+	data, err := outputs.Pack(eCommon.BytesToAddress(holder.Bytes()[1:]), uint32(3))
+	require.NoError(t, err)
+
+	var out struct {
+		Token string
+		Fee   *big.Int
+	}
+	require.NoError(t, UnpackInto(outputs, data, &out))
+	assert.Equal(t, holder.String(), out.Token)
+	assert.Equal(t, int64(3), out.Fee.Int64())
+
+	var values struct {
+		A interface{}
+		B interface{}
+	}
+	require.NoError(t, UnpackInto(outputs, data, &values))
+	assert.Equal(t, holder, values.A)
+	assert.Equal(t, uint32(3), values.B)
+}
+
+func TestFindMethod(t *testing.T) {
+	parsed, err := ParseABI(`[
+	 {"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"}],"outputs":[]},
+	 {"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"v","type":"uint256"}],"outputs":[]},
+	 {"type":"function","name":"deposit","inputs":[{"name":"id","type":"trcToken"}],"outputs":[]}]`)
+	require.NoError(t, err)
+
+	m, err := FindMethod(parsed, "transfer(address, uint256)")
+	require.NoError(t, err)
+	assert.Equal(t, "transfer(address,uint256)", m.Sig)
+
+	m, err = FindMethod(parsed, "deposit(trcToken)")
+	require.NoError(t, err)
+	assert.Equal(t, "deposit", m.Name)
+
+	_, err = FindMethod(parsed, "transfer")
+	assert.ErrorContains(t, err, "overloaded")
+
+	m, err = FindMethod(parsed, "transfer0")
+	require.NoError(t, err)
+	assert.Equal(t, "transfer(address,uint256)", m.Sig)
+
+	_, err = FindMethod(parsed, "missing")
+	assert.Error(t, err)
+}
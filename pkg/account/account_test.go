@@ -1,6 +1,7 @@
 package account_test
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"os"
 	"path"
@@ -11,6 +12,8 @@ import (
 	"github.com/fbsobreira/gotron-sdk/pkg/common"
 	"github.com/fbsobreira/gotron-sdk/pkg/keys"
 	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
+	"github.com/fbsobreira/gotron-sdk/pkg/mnemonic"
+	"github.com/fbsobreira/gotron-sdk/pkg/slip39"
 	"github.com/fbsobreira/gotron-sdk/pkg/store"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -118,6 +121,33 @@ func TestImportFromPrivateKey_DuplicateName(t *testing.T) {
 	assert.Contains(t, err.Error(), "already exists")
 }
 
+func TestImportFromShares(t *testing.T) {
+	setupTestStore(t)
+
+	secret, err := hex.DecodeString(testPrivateKey)
+	require.NoError(t, err)
+	shares, err := slip39.Split(secret, "share-pass", 1, []slip39.Group{{MemberThreshold: 2, MemberCount: 3}}, slip39.WithIterationExponent(0))
+	require.NoError(t, err)
+
+	name, err := account.ImportFromShares([]string{shares[0][2], shares[0][0]}, "share-pass", "from-shares", "pass")
+	require.NoError(t, err)
+	assert.Equal(t, "from-shares", name)
+
+	sk, err := keys.GetPrivateKeyFromHex(testPrivateKey)
+	require.NoError(t, err)
+	addr, err := store.AddressFromAccountName(name)
+	require.NoError(t, err)
+	assert.Equal(t, address.PubkeyToAddress(*sk.PubKey().ToECDSA()).String(), addr)
+
+	_, err = account.ImportFromShares(shares[0][:1], "share-pass", "too-few", "pass")
+	assert.ErrorIs(t, err, slip39.ErrInsufficientShares)
+
+	short, err := slip39.Split(make([]byte, 16), "", 1, []slip39.Group{{MemberThreshold: 1, MemberCount: 1}}, slip39.WithIterationExponent(0))
+	require.NoError(t, err)
+	_, err = account.ImportFromShares(short[0], "", "short-secret", "pass")
+	assert.ErrorContains(t, err, "private key")
+}
+
 func TestImportFromPrivateKey_EmptyPassphrase(t *testing.T) {
 	setupTestStore(t)
 
@@ -260,6 +290,34 @@ func TestCreateNewLocalAccount_Duplicate(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestCreateNewLocalAccount_HDWallet(t *testing.T) {
+	setupTestStore(t)
+
+	m := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	index := uint32(2)
+	creation := &account.Creation{
+		Name:          "hd-local",
+		Passphrase:    "pass",
+		Mnemonic:      m,
+		HdIndexNumber: &index,
+		HDWallet:      true,
+	}
+	require.NoError(t, account.CreateNewLocalAccount(creation))
+
+	sk, _ := mnemonic.FromSeedAndPassphrase(m, "", 2)
+	require.NotNil(t, sk)
+	addr, err := store.AddressFromAccountName("hd-local")
+	require.NoError(t, err)
+	assert.Equal(t, address.PubkeyToAddress(sk.ToECDSA().PublicKey).String(), addr)
+
+	w, err := store.HDWallet("hd-local")
+	require.NoError(t, err)
+	assert.Len(t, w.Accounts(), 1)
+
+	err = account.CreateNewLocalAccount(creation)
+	assert.ErrorIs(t, err, keystore.ErrHDWalletExists)
+}
+
 func TestNew(t *testing.T) {
 	result := account.New()
 	assert.Equal(t, "New Account", result)
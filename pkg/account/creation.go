@@ -4,7 +4,9 @@ package account
 import (
 	"fmt"
 
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
 	"github.com/fbsobreira/gotron-sdk/pkg/keys"
+	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
 	"github.com/fbsobreira/gotron-sdk/pkg/mnemonic"
 	"github.com/fbsobreira/gotron-sdk/pkg/store"
 )
@@ -17,6 +19,9 @@ type Creation struct {
 	MnemonicPassphrase string
 	HdAccountNumber    *uint32
 	HdIndexNumber      *uint32
+	// HDWallet stores the mnemonic seed as an HD wallet instead of importing
+	// a single key; the first account is derived and pinned.
+	HDWallet bool
 }
 
 // New returns the default name for a new account.
@@ -42,6 +47,9 @@ func CreateNewLocalAccount(candidate *Creation) error {
 		}
 		candidate.Mnemonic = m
 	}
+	if candidate.HDWallet {
+		return createHDWallet(ks, candidate)
+	}
 	// Hardcoded index of 0 for brandnew account.
 	private, _ := mnemonic.FromSeedAndPassphrase(candidate.Mnemonic, candidate.MnemonicPassphrase, 0)
 	if private == nil {
@@ -54,3 +62,31 @@ func CreateNewLocalAccount(candidate *Creation) error {
 	}
 	return nil
 }
+
+// createHDWallet stores the candidate's seed as the HD wallet of ks and pins
+// the account at HdAccountNumber/HdIndexNumber (0/0 by default).
+func createHDWallet(ks *keystore.KeyStore, candidate *Creation) error {
+	seed, err := mnemonic.Seed(candidate.Mnemonic, candidate.MnemonicPassphrase)
+	if err != nil {
+		return err
+	}
+	defer common.ZeroBytes(seed)
+	w, err := ks.NewHDWallet(seed, candidate.Passphrase)
+	if err != nil {
+		return err
+	}
+	if err := w.Open(candidate.Passphrase); err != nil {
+		return err
+	}
+	defer func() { _ = w.Close() }()
+
+	var accountNumber, index uint32
+	if candidate.HdAccountNumber != nil {
+		accountNumber = *candidate.HdAccountNumber
+	}
+	if candidate.HdIndexNumber != nil {
+		index = *candidate.HdIndexNumber
+	}
+	_, err = w.Derive(keystore.TronDerivationPath(accountNumber, 0, index), true)
+	return err
+}
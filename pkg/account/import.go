@@ -1,6 +1,7 @@
 package account
 
 import (
+	"encoding/hex"
 	"fmt"
 	"io"
 	"os"
@@ -8,9 +9,11 @@ import (
 	"strings"
 
 	mapset "github.com/deckarep/golang-set"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
 	"github.com/fbsobreira/gotron-sdk/pkg/keys"
 	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
 	"github.com/fbsobreira/gotron-sdk/pkg/mnemonic"
+	"github.com/fbsobreira/gotron-sdk/pkg/slip39"
 	"github.com/fbsobreira/gotron-sdk/pkg/store"
 )
 
@@ -52,6 +55,21 @@ func ImportFromPrivateKey(privateKey, name, passphrase string) (string, error) {
 	return name, err
 }
 
+// ImportFromShares recovers a private key from SLIP-0039 shares created with
+// slip39.Split and imports it like ImportFromPrivateKey. sharePassphrase is
+// the passphrase the shares were created with.
+func ImportFromShares(shares []string, sharePassphrase, name, passphrase string) (string, error) {
+	secret, err := slip39.Combine(shares, sharePassphrase)
+	if err != nil {
+		return "", err
+	}
+	defer common.ZeroBytes(secret)
+	if len(secret) != common.Secp256k1PrivateKeyBytesLength {
+		return "", fmt.Errorf("shares hold a %d-byte secret, want a %d-byte private key", len(secret), common.Secp256k1PrivateKeyBytesLength)
+	}
+	return ImportFromPrivateKey(hex.EncodeToString(secret), name, passphrase)
+}
+
 func generateName() (string, error) {
 	m, err := mnemonic.Generate()
 	if err != nil {
@@ -0,0 +1,48 @@
+package address
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ContractAddress returns the address of the contract created by a
+// CreateSmartContract transaction with the given transaction ID and owner.
+// TRON derives it as the last 20 bytes of keccak256(txID || owner), with the
+// 0x41 prefix in place of the hash's leading bytes.
+func ContractAddress(txID []byte, owner Address) (Address, error) {
+	if len(txID) != HashLength {
+		return nil, fmt.Errorf("transaction ID: got %d bytes, want %d: %w", len(txID), HashLength, ErrInvalidHashLength)
+	}
+	if len(owner) != AddressLength {
+		return nil, fmt.Errorf("owner: got %d, want %d: %w", len(owner), AddressLength, ErrInvalidAddressLength)
+	}
+	return hashToAddress(crypto.Keccak256(txID, owner)), nil
+}
+
+// Create2Address returns the address of a contract created with CREATE2 by
+// deployer. TVM hashes 0x41 || deployer || salt || initCodeHash where
+// Ethereum uses 0xff, so the deployer's 21-byte address is hashed as is.
+// salt and initCodeHash (the keccak256 of the creation code including
+// constructor arguments) must be 32 bytes.
+func Create2Address(deployer Address, salt, initCodeHash []byte) (Address, error) {
+	if len(deployer) != AddressLength || deployer[0] != TronBytePrefix {
+		return nil, fmt.Errorf("deployer: %w", ErrInvalidAddressLength)
+	}
+	if len(salt) != HashLength {
+		return nil, fmt.Errorf("salt: got %d bytes, want %d: %w", len(salt), HashLength, ErrInvalidHashLength)
+	}
+	if len(initCodeHash) != HashLength {
+		return nil, fmt.Errorf("init code hash: got %d bytes, want %d: %w", len(initCodeHash), HashLength, ErrInvalidHashLength)
+	}
+	return hashToAddress(crypto.Keccak256(deployer, salt, initCodeHash)), nil
+}
+
+// hashToAddress keeps the last 20 bytes of a 32-byte hash behind the TRON
+// prefix.
+func hashToAddress(hash []byte) Address {
+	addr := make(Address, AddressLength)
+	addr[0] = TronBytePrefix
+	copy(addr[1:], hash[HashLength-AddressLength+1:])
+	return addr
+}
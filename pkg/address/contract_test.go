@@ -0,0 +1,53 @@
+package address
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContractAddress(t *testing.T) {
+	owner, err := Base58ToAddress(testBase58Addr1)
+	require.NoError(t, err)
+	txID := bytes.Repeat([]byte{0xab}, HashLength)
+
+	addr, err := ContractAddress(txID, owner)
+	require.NoError(t, err)
+	want := append([]byte{TronBytePrefix}, crypto.Keccak256(txID, owner)[12:]...)
+	assert.Equal(t, Address(want), addr)
+	assert.True(t, addr.IsValid())
+
+	_, err = ContractAddress(txID, owner[1:])
+	assert.ErrorIs(t, err, ErrInvalidAddressLength)
+
+	_, err = ContractAddress(txID[:31], owner)
+	assert.ErrorIs(t, err, ErrInvalidHashLength)
+}
+
+func TestCreate2Address(t *testing.T) {
+	deployer, err := Base58ToAddress(testBase58Addr2)
+	require.NoError(t, err)
+	salt := make([]byte, HashLength)
+	salt[31] = 1
+	initCodeHash := crypto.Keccak256([]byte{0x60, 0x80, 0x60, 0x40})
+
+	addr, err := Create2Address(deployer, salt, initCodeHash)
+	require.NoError(t, err)
+	want := append([]byte{TronBytePrefix}, crypto.Keccak256([]byte{0x41}, deployer[1:], salt, initCodeHash)[12:]...)
+	assert.Equal(t, Address(want), addr)
+
+	salt2 := make([]byte, HashLength)
+	addr2, err := Create2Address(deployer, salt2, initCodeHash)
+	require.NoError(t, err)
+	assert.NotEqual(t, addr, addr2, "salt changes the address")
+
+	_, err = Create2Address(deployer[1:], salt, initCodeHash)
+	assert.ErrorIs(t, err, ErrInvalidAddressLength)
+	_, err = Create2Address(deployer, salt[:4], initCodeHash)
+	assert.ErrorIs(t, err, ErrInvalidHashLength)
+	_, err = Create2Address(deployer, salt, initCodeHash[:20])
+	assert.ErrorIs(t, err, ErrInvalidHashLength)
+}
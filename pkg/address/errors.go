@@ -9,4 +9,5 @@ var (
 	ErrInvalidAddressChecksum = errors.New("invalid address checksum")
 	ErrOversizeBigInt         = errors.New("big.Int too large for address")
 	ErrInvalidHex             = errors.New("invalid hex string")
+	ErrInvalidHashLength      = errors.New("invalid hash length")
 )
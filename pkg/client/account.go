@@ -39,7 +39,7 @@ func (g *GrpcClient) GetAccountCtx(ctx context.Context, addr string) (*core.Acco
 		return nil, err
 	}
 	if !bytes.Equal(acc.Address, account.Address) {
-		return nil, fmt.Errorf("account not found")
+		return nil, ErrAccountNotFound
 	}
 	return acc, nil
 }
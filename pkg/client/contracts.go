@@ -468,9 +468,32 @@ func (g *GrpcClient) DeployContractCtx(ctx context.Context, from, contractName s
 	abi *core.SmartContract_ABI, codeStr string,
 	feeLimit, curPercent, oeLimit int64,
 ) (*api.TransactionExtention, error) {
-	ctx = g.withAPIKey(ctx)
+	bc, err := common.FromHex(codeStr)
+	if err != nil {
+		return nil, err
+	}
+	return g.DeployContractWithDataCtx(ctx, from, contractName, abi, bc, feeLimit, 0, curPercent, oeLimit)
+}
 
-	var err error
+// DeployContractWithData deploys a new smart contract from raw creation
+// bytecode (with any ABI-encoded constructor arguments appended), sending
+// callValue SUN to a payable constructor, and returns the unsigned
+// transaction.
+func (g *GrpcClient) DeployContractWithData(from, contractName string,
+	abi *core.SmartContract_ABI, bytecode []byte,
+	feeLimit, callValue, curPercent, oeLimit int64,
+) (*api.TransactionExtention, error) {
+	ctx, cancel := g.newContext()
+	defer cancel()
+	return g.DeployContractWithDataCtx(ctx, from, contractName, abi, bytecode, feeLimit, callValue, curPercent, oeLimit)
+}
+
+// DeployContractWithDataCtx is the context-aware version of DeployContractWithData.
+func (g *GrpcClient) DeployContractWithDataCtx(ctx context.Context, from, contractName string,
+	abi *core.SmartContract_ABI, bytecode []byte,
+	feeLimit, callValue, curPercent, oeLimit int64,
+) (*api.TransactionExtention, error) {
+	ctx = g.withAPIKey(ctx)
 
 	fromDesc, err := address.Base58ToAddress(from)
 	if err != nil {
@@ -483,10 +506,8 @@ func (g *GrpcClient) DeployContractCtx(ctx context.Context, from, contractName s
 	if oeLimit <= 0 {
 		return nil, fmt.Errorf("origin_energy_limit must > 0")
 	}
-
-	bc, err := common.FromHex(codeStr)
-	if err != nil {
-		return nil, err
+	if callValue < 0 {
+		return nil, fmt.Errorf("call_value must be >= 0")
 	}
 
 	ct := &core.CreateSmartContract{
@@ -497,7 +518,8 @@ func (g *GrpcClient) DeployContractCtx(ctx context.Context, from, contractName s
 			Name:                       contractName,
 			ConsumeUserResourcePercent: curPercent,
 			OriginEnergyLimit:          oeLimit,
-			Bytecode:                   bc,
+			Bytecode:                   bytecode,
+			CallValue:                  callValue,
 		},
 	}
 
@@ -546,6 +568,32 @@ func (g *GrpcClient) GetContractABICtx(ctx context.Context, contractAddress stri
 	return sm.Abi, nil
 }
 
+// GetContractInfo returns a deployed contract together with its runtime
+// bytecode and state.
+func (g *GrpcClient) GetContractInfo(contractAddress string) (*core.SmartContractDataWrapper, error) {
+	ctx, cancel := g.newContext()
+	defer cancel()
+	return g.GetContractInfoCtx(ctx, contractAddress)
+}
+
+// GetContractInfoCtx is the context-aware version of GetContractInfo.
+func (g *GrpcClient) GetContractInfoCtx(ctx context.Context, contractAddress string) (*core.SmartContractDataWrapper, error) {
+	ctx = g.withAPIKey(ctx)
+
+	contractDesc, err := address.Base58ToAddress(contractAddress)
+	if err != nil {
+		return nil, err
+	}
+	info, err := g.Client.GetContractInfo(ctx, GetMessageBytes(contractDesc))
+	if err != nil {
+		return nil, err
+	}
+	if info == nil {
+		return nil, fmt.Errorf("invalid contract info")
+	}
+	return info, nil
+}
+
 // proxySelectors lists the EVM function selectors tried (in order) when
 // resolving a proxy contract's implementation address.  Each entry is a
 // 4-byte keccak256 prefix of a well-known getter exposed by different
@@ -874,3 +874,48 @@ func TestUpdateBrokerage_BadTransaction(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "bad transaction")
 }
+
+func TestDeployContractWithData(t *testing.T) {
+	bytecode := []byte{0x60, 0x80, 0x60, 0x40}
+	mock := &mockWalletServer{
+		DeployContractFunc: func(_ context.Context, in *core.CreateSmartContract) (*api.TransactionExtention, error) {
+			assert.Equal(t, bytecode, in.NewContract.Bytecode)
+			assert.Equal(t, int64(5_000_000), in.NewContract.CallValue)
+			assert.Equal(t, int64(100), in.NewContract.ConsumeUserResourcePercent)
+			return &api.TransactionExtention{
+				Result:      &api.Return{Result: true, Code: api.Return_SUCCESS},
+				Txid:        []byte{0x01},
+				Transaction: &core.Transaction{RawData: &core.TransactionRaw{}},
+			}, nil
+		},
+	}
+
+	c := newMockClient(t, mock)
+	tx, err := c.DeployContractWithData("TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b", "Payable",
+		&core.SmartContract_ABI{}, bytecode, 100_000_000, 5_000_000, 100, 10000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100_000_000), tx.Transaction.RawData.FeeLimit)
+
+	_, err = c.DeployContractWithData("TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b", "Payable",
+		&core.SmartContract_ABI{}, bytecode, 0, -1, 100, 10000)
+	assert.ErrorContains(t, err, "call_value")
+}
+
+func TestGetContractInfo(t *testing.T) {
+	mock := &mockWalletServer{
+		GetContractInfoFunc: func(_ context.Context, in *api.BytesMessage) (*core.SmartContractDataWrapper, error) {
+			return &core.SmartContractDataWrapper{
+				SmartContract: &core.SmartContract{ContractAddress: in.Value},
+				Runtimecode:   []byte{0x60, 0x80},
+			}, nil
+		},
+	}
+
+	c := newMockClient(t, mock)
+	info, err := c.GetContractInfo("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x60, 0x80}, info.Runtimecode)
+
+	_, err = c.GetContractInfo("invalid")
+	require.Error(t, err)
+}
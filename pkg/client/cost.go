@@ -0,0 +1,199 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"google.golang.org/protobuf/proto"
+)
+
+// Chain parameter keys used for cost estimation.
+const (
+	ChainParamTransactionFee                      = "getTransactionFee"
+	ChainParamCreateAccountFee                    = "getCreateAccountFee"
+	ChainParamCreateNewAccountFeeInSystemContract = "getCreateNewAccountFeeInSystemContract"
+	ChainParamMultiSignFee                        = "getMultiSignFee"
+)
+
+const (
+	// signatureSize is the encoded size of one 65-byte signature in a
+	// transaction: field tag, length and the signature itself.
+	signatureSize = 67
+	// maxResultSize is the space the node reserves for the result of each
+	// contract when charging bandwidth.
+	maxResultSize = 64
+)
+
+// CostEstimate is the expected cost of a transaction. Resource figures are in
+// bandwidth or energy units, fees in SUN.
+type CostEstimate struct {
+	// Size is the signed transaction size in bytes, the bandwidth it
+	// consumes.
+	Size int64
+	// StakedBandwidth and FreeBandwidth are the sender's unused bandwidth.
+	StakedBandwidth int64
+	FreeBandwidth   int64
+	// BandwidthFee is the TRX burned because neither staked nor free
+	// bandwidth covers Size.
+	BandwidthFee int64
+
+	// Energy is the estimated energy of a contract call, including the
+	// contract's dynamic energy factor.
+	Energy int64
+	// AvailableEnergy is the sender's unused staked energy.
+	AvailableEnergy int64
+	// EnergyFee is the TRX burned for energy not covered by staked energy.
+	EnergyFee int64
+
+	// ActivationFee is charged when a transfer creates the recipient account.
+	ActivationFee int64
+	// MemoFee is charged when the transaction carries a memo.
+	MemoFee int64
+	// MultiSignFee is charged when more than one signature is attached.
+	MultiSignFee int64
+
+	// Total is the sum of all fees.
+	Total int64
+}
+
+// EstimateCost estimates what tx will cost once signed by signerCount keys.
+func (g *GrpcClient) EstimateCost(tx *core.Transaction, signerCount int) (*CostEstimate, error) {
+	ctx, cancel := g.newContext()
+	defer cancel()
+	return g.EstimateCostCtx(ctx, tx, signerCount)
+}
+
+// EstimateCostCtx estimates the bandwidth, energy and fees of tx once signed
+// by signerCount keys, using the sender's current resources and the
+// network's current prices. Signatures already on tx are ignored.
+//
+// Bandwidth is paid from staked bandwidth, then free bandwidth, and burned at
+// getTransactionFee per byte when neither covers the whole transaction. A
+// transfer or account creation that creates its recipient instead pays the
+// activation fee and uses no regular bandwidth. Energy is only estimated for contract calls
+// (TriggerSmartContract); deployments report zero energy.
+func (g *GrpcClient) EstimateCostCtx(ctx context.Context, tx *core.Transaction, signerCount int) (*CostEstimate, error) {
+	ctx = g.withAPIKey(ctx)
+
+	if signerCount < 1 {
+		return nil, fmt.Errorf("signer count must be at least 1, got %d", signerCount)
+	}
+	contracts := tx.GetRawData().GetContract()
+	if len(contracts) == 0 {
+		return nil, errors.New("transaction has no contract")
+	}
+	owner := extractOwnerAddress(tx)
+	if len(owner) == 0 {
+		return nil, errors.New("transaction has no owner address")
+	}
+	from := address.Address(owner).String()
+
+	params, err := g.GetChainParametersCtx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get chain parameters: %w", err)
+	}
+	resources, err := g.GetAccountResourceCtx(ctx, from)
+	if err != nil {
+		return nil, fmt.Errorf("get account resources: %w", err)
+	}
+
+	unsigned := proto.Clone(tx).(*core.Transaction)
+	unsigned.Signature = nil
+	unsigned.Ret = nil
+	est := &CostEstimate{
+		Size:            int64(proto.Size(unsigned) + signerCount*signatureSize + len(contracts)*maxResultSize),
+		StakedBandwidth: max(resources.GetNetLimit()-resources.GetNetUsed(), 0),
+		FreeBandwidth:   max(resources.GetFreeNetLimit()-resources.GetFreeNetUsed(), 0),
+	}
+
+	activates, err := g.activatesRecipient(ctx, contracts[0])
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case activates:
+		est.ActivationFee, _ = ChainParameter(params, ChainParamCreateNewAccountFeeInSystemContract)
+		if est.StakedBandwidth < est.Size {
+			fee, _ := ChainParameter(params, ChainParamCreateAccountFee)
+			est.ActivationFee += fee
+		}
+	case est.StakedBandwidth >= est.Size, est.FreeBandwidth >= est.Size:
+		// Covered by bandwidth, nothing is burned.
+	default:
+		price, _ := ChainParameter(params, ChainParamTransactionFee)
+		est.BandwidthFee = est.Size * price
+	}
+
+	if contracts[0].GetType() == core.Transaction_Contract_TriggerSmartContract {
+		var trigger core.TriggerSmartContract
+		if err := contracts[0].GetParameter().UnmarshalTo(&trigger); err != nil {
+			return nil, fmt.Errorf("unmarshal contract call: %w", err)
+		}
+		tokenID := ""
+		if trigger.GetTokenId() != 0 {
+			tokenID = strconv.FormatInt(trigger.GetTokenId(), 10)
+		}
+		energy, err := g.EstimateFeeLimitCtx(ctx, from, address.Address(trigger.GetContractAddress()).String(), trigger.GetData(),
+			trigger.GetCallValue(), tokenID, trigger.GetCallTokenValue(), 0, 0)
+		if err != nil {
+			return nil, err
+		}
+		est.Energy = energy.Energy
+		est.AvailableEnergy = energy.AvailableEnergy
+		est.EnergyFee = energy.Burn
+	}
+
+	if len(tx.GetRawData().GetData()) > 0 {
+		memo, err := g.GetMemoFeeHistoryCtx(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("get memo fee: %w", err)
+		}
+		if len(memo) > 0 {
+			est.MemoFee = memo[len(memo)-1].Price
+		}
+	}
+	if signerCount > 1 {
+		est.MultiSignFee, _ = ChainParameter(params, ChainParamMultiSignFee)
+	}
+
+	est.Total = est.BandwidthFee + est.EnergyFee + est.ActivationFee + est.MemoFee + est.MultiSignFee
+	return est, nil
+}
+
+// activatesRecipient reports whether a TRX or TRC10 transfer, or an account
+// creation, targets an account that does not exist yet.
+func (g *GrpcClient) activatesRecipient(ctx context.Context, contract *core.Transaction_Contract) (bool, error) {
+	var to []byte
+	switch contract.GetType() {
+	case core.Transaction_Contract_TransferContract:
+		var transfer core.TransferContract
+		if err := contract.GetParameter().UnmarshalTo(&transfer); err != nil {
+			return false, fmt.Errorf("unmarshal transfer: %w", err)
+		}
+		to = transfer.GetToAddress()
+	case core.Transaction_Contract_TransferAssetContract:
+		var transfer core.TransferAssetContract
+		if err := contract.GetParameter().UnmarshalTo(&transfer); err != nil {
+			return false, fmt.Errorf("unmarshal transfer: %w", err)
+		}
+		to = transfer.GetToAddress()
+	case core.Transaction_Contract_AccountCreateContract:
+		var create core.AccountCreateContract
+		if err := contract.GetParameter().UnmarshalTo(&create); err != nil {
+			return false, fmt.Errorf("unmarshal account creation: %w", err)
+		}
+		to = create.GetAccountAddress()
+	default:
+		return false, nil
+	}
+
+	acc, err := g.Client.GetAccount(ctx, &core.Account{Address: to})
+	if err != nil {
+		return false, fmt.Errorf("get recipient account: %w", err)
+	}
+	return len(acc.GetAddress()) == 0, nil
+}
@@ -0,0 +1,128 @@
+package client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func costMock(recipientExists bool, resources *api.AccountResourceMessage) *mockWalletServer {
+	return &mockWalletServer{
+		GetChainParametersFunc: func(_ context.Context, _ *api.EmptyMessage) (*core.ChainParameters, error) {
+			return &core.ChainParameters{ChainParameter: []*core.ChainParameters_ChainParameter{
+				{Key: client.ChainParamTransactionFee, Value: 1000},
+				{Key: client.ChainParamCreateAccountFee, Value: 100_000},
+				{Key: client.ChainParamCreateNewAccountFeeInSystemContract, Value: 1_000_000},
+				{Key: client.ChainParamMultiSignFee, Value: 1_000_000},
+				{Key: client.ChainParamEnergyFee, Value: 210},
+			}}, nil
+		},
+		GetAccountResourceFunc: func(_ context.Context, _ *core.Account) (*api.AccountResourceMessage, error) {
+			return resources, nil
+		},
+		GetAccountFunc: func(_ context.Context, in *core.Account) (*core.Account, error) {
+			if recipientExists {
+				return &core.Account{Address: in.Address}, nil
+			}
+			return &core.Account{}, nil
+		},
+		GetMemoFeeFunc: func(_ context.Context, _ *api.EmptyMessage) (*api.PricesResponseMessage, error) {
+			return &api.PricesResponseMessage{Prices: "0:0,1675492680000:1000000"}, nil
+		},
+	}
+}
+
+func transferTx(t *testing.T, memo string) *core.Transaction {
+	from, err := address.Base58ToAddress(feeLimitFrom)
+	require.NoError(t, err)
+	to, err := address.Base58ToAddress("TLyqzVGLV1srkB7dToTAEqgDSfPtXRJZYH")
+	require.NoError(t, err)
+	param, err := anypb.New(&core.TransferContract{OwnerAddress: from, ToAddress: to, Amount: 1_000_000})
+	require.NoError(t, err)
+	return &core.Transaction{RawData: &core.TransactionRaw{
+		Data: []byte(memo),
+		Contract: []*core.Transaction_Contract{
+			{Type: core.Transaction_Contract_TransferContract, Parameter: param},
+		},
+	}}
+}
+
+func TestEstimateCostTransfer(t *testing.T) {
+	tx := transferTx(t, "")
+	size := int64(proto.Size(tx) + 67 + 64)
+
+	// Free bandwidth covers the transfer.
+	c := newMockClient(t, costMock(true, &api.AccountResourceMessage{FreeNetLimit: 600}))
+	est, err := c.EstimateCost(tx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, size, est.Size)
+	assert.Equal(t, int64(600), est.FreeBandwidth)
+	assert.Zero(t, est.Total)
+
+	// Without bandwidth the size is burned; a memo and extra signer add fees.
+	tx = transferTx(t, "hello")
+	c = newMockClient(t, costMock(true, &api.AccountResourceMessage{FreeNetLimit: 600, FreeNetUsed: 600}))
+	est, err = c.EstimateCost(tx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(proto.Size(tx)+2*67+64), est.Size)
+	assert.Equal(t, est.Size*1000, est.BandwidthFee)
+	assert.Equal(t, int64(1_000_000), est.MemoFee)
+	assert.Equal(t, int64(1_000_000), est.MultiSignFee)
+	assert.Equal(t, est.BandwidthFee+2_000_000, est.Total)
+
+	_, err = c.EstimateCost(tx, 0)
+	assert.ErrorContains(t, err, "signer count")
+}
+
+func TestEstimateCostActivation(t *testing.T) {
+	tx := transferTx(t, "")
+
+	c := newMockClient(t, costMock(false, &api.AccountResourceMessage{FreeNetLimit: 600}))
+	est, err := c.EstimateCost(tx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1_100_000), est.ActivationFee)
+	assert.Zero(t, est.BandwidthFee)
+	assert.Equal(t, int64(1_100_000), est.Total)
+
+	// Staked bandwidth pays the account creation bandwidth.
+	c = newMockClient(t, costMock(false, &api.AccountResourceMessage{NetLimit: 1000}))
+	est, err = c.EstimateCost(tx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1_000_000), est.ActivationFee)
+}
+
+func TestEstimateCostContractCall(t *testing.T) {
+	from, err := address.Base58ToAddress(feeLimitFrom)
+	require.NoError(t, err)
+	contractAddr, err := address.Base58ToAddress(feeLimitContract)
+	require.NoError(t, err)
+	param, err := anypb.New(&core.TriggerSmartContract{OwnerAddress: from, ContractAddress: contractAddr, Data: []byte{0xa9, 0x05, 0x9c, 0xbb}})
+	require.NoError(t, err)
+	tx := &core.Transaction{RawData: &core.TransactionRaw{Contract: []*core.Transaction_Contract{
+		{Type: core.Transaction_Contract_TriggerSmartContract, Parameter: param},
+	}}}
+
+	mock := costMock(true, &api.AccountResourceMessage{FreeNetLimit: 600, EnergyLimit: 4000})
+	mock.EstimateEnergyFunc = func(_ context.Context, _ *core.TriggerSmartContract) (*api.EstimateEnergyMessage, error) {
+		return &api.EstimateEnergyMessage{Result: &api.Return{Result: true}, EnergyRequired: 10000}, nil
+	}
+	mock.GetContractInfoFunc = func(_ context.Context, _ *api.BytesMessage) (*core.SmartContractDataWrapper, error) {
+		return &core.SmartContractDataWrapper{}, nil
+	}
+	c := newMockClient(t, mock)
+
+	est, err := c.EstimateCost(tx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10000), est.Energy)
+	assert.Equal(t, int64(4000), est.AvailableEnergy)
+	assert.Equal(t, int64(6000*210), est.EnergyFee)
+	assert.Equal(t, est.EnergyFee, est.Total)
+}
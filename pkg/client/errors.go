@@ -19,4 +19,7 @@ var (
 	ErrInsufficientFee = errors.New("fee limit must be greater than zero")
 	// ErrNoSigner is returned when a signing operation has no signer address.
 	ErrNoSigner = errors.New("signer address required")
+	// ErrAccountNotFound is returned when an account does not exist on chain,
+	// as for addresses that were never activated.
+	ErrAccountNotFound = errors.New("account not found")
 )
@@ -0,0 +1,181 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// Chain parameter keys used for fee estimation.
+const (
+	ChainParamEnergyFee   = "getEnergyFee"
+	ChainParamMaxFeeLimit = "getMaxFeeLimit"
+)
+
+// energyFactorPrecision is the denominator of a contract's dynamic energy
+// factor: a factor of 2000 adds 20% to the energy a call consumes.
+const energyFactorPrecision = 10_000
+
+// FeeLimitEstimate is the breakdown behind an automatically chosen fee limit.
+// Energy figures are in energy units, TRX figures in SUN.
+type FeeLimitEstimate struct {
+	// Energy is the estimated energy of the call after applying the
+	// contract's dynamic energy factor.
+	Energy int64
+	// EnergyFactor is the contract's dynamic energy factor, in 1/10000.
+	EnergyFactor int64
+	// EnergyPrice is the current price of energy (getEnergyFee).
+	EnergyPrice int64
+	// AvailableEnergy is the sender's unused staked energy.
+	AvailableEnergy int64
+	// Burn is the TRX expected to be burned for the energy that staked
+	// energy does not cover.
+	Burn int64
+	// FeeLimit is the fee limit to set: Energy plus margin at EnergyPrice,
+	// capped at the ceiling.
+	FeeLimit int64
+	// Ceiling is the cap applied to FeeLimit.
+	Ceiling int64
+}
+
+// GetChainParameters returns the network's current chain parameters.
+func (g *GrpcClient) GetChainParameters() (*core.ChainParameters, error) {
+	ctx, cancel := g.newContext()
+	defer cancel()
+	return g.GetChainParametersCtx(ctx)
+}
+
+// GetChainParametersCtx is the context-aware version of GetChainParameters.
+func (g *GrpcClient) GetChainParametersCtx(ctx context.Context) (*core.ChainParameters, error) {
+	ctx = g.withAPIKey(ctx)
+	return g.Client.GetChainParameters(ctx, new(api.EmptyMessage))
+}
+
+// ChainParameter returns the value of the chain parameter key, such as
+// ChainParamEnergyFee.
+func ChainParameter(params *core.ChainParameters, key string) (int64, bool) {
+	for _, p := range params.GetChainParameter() {
+		if p.GetKey() == key {
+			return p.GetValue(), true
+		}
+	}
+	return 0, false
+}
+
+// EstimateFeeLimit estimates the fee limit for a contract call.
+func (g *GrpcClient) EstimateFeeLimit(from, contractAddress string, data []byte,
+	tAmount int64, tTokenID string, tTokenAmount int64, margin float64, ceiling int64) (*FeeLimitEstimate, error) {
+	ctx, cancel := g.newContext()
+	defer cancel()
+	return g.EstimateFeeLimitCtx(ctx, from, contractAddress, data, tAmount, tTokenID, tTokenAmount, margin, ceiling)
+}
+
+// EstimateFeeLimitCtx estimates the energy of a contract call and derives a
+// fee limit from it. The energy comes from EstimateEnergy or, when the node
+// does not support it, from the energy_used of a constant call; the
+// contract's dynamic energy factor is applied and margin (e.g. 0.2 for 20%)
+// added before pricing at getEnergyFee.
+//
+// The fee limit caps all energy a call may use, staked energy included, so
+// the sender's available energy is not subtracted from it; it only lowers
+// the expected Burn. ceiling caps the fee limit; zero means the network's
+// getMaxFeeLimit. An error is returned when the estimate without margin
+// already exceeds the ceiling, as the call would run out of energy.
+func (g *GrpcClient) EstimateFeeLimitCtx(ctx context.Context, from, contractAddress string, data []byte,
+	tAmount int64, tTokenID string, tTokenAmount int64, margin float64, ceiling int64) (*FeeLimitEstimate, error) {
+	ctx = g.withAPIKey(ctx)
+
+	if margin < 0 {
+		return nil, fmt.Errorf("fee limit margin must not be negative, got %v", margin)
+	}
+	energy, err := g.estimateBaseEnergy(ctx, from, contractAddress, data, tAmount, tTokenID, tTokenAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := g.GetContractInfoCtx(ctx, contractAddress)
+	if err != nil {
+		return nil, fmt.Errorf("get energy factor: %w", err)
+	}
+	params, err := g.GetChainParametersCtx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get chain parameters: %w", err)
+	}
+	resources, err := g.GetAccountResourceCtx(ctx, from)
+	if err != nil {
+		return nil, fmt.Errorf("get account resources: %w", err)
+	}
+
+	est := &FeeLimitEstimate{
+		EnergyFactor:    info.GetContractState().GetEnergyFactor(),
+		AvailableEnergy: max(resources.GetEnergyLimit()-resources.GetEnergyUsed(), 0),
+		Ceiling:         ceiling,
+	}
+	price, ok := ChainParameter(params, ChainParamEnergyFee)
+	if !ok || price <= 0 {
+		return nil, fmt.Errorf("chain parameter %s not available", ChainParamEnergyFee)
+	}
+	est.EnergyPrice = price
+	if maxFeeLimit, ok := ChainParameter(params, ChainParamMaxFeeLimit); ok && (est.Ceiling <= 0 || est.Ceiling > maxFeeLimit) {
+		est.Ceiling = maxFeeLimit
+	}
+
+	est.Energy = energy * (energyFactorPrecision + est.EnergyFactor) / energyFactorPrecision
+	est.Burn = max(est.Energy-est.AvailableEnergy, 0) * price
+	required := est.Energy * price
+	if est.Ceiling > 0 && required > est.Ceiling {
+		return est, fmt.Errorf("estimated fee %d SUN exceeds fee limit ceiling %d SUN", required, est.Ceiling)
+	}
+	// The margin is applied in basis points to avoid float rounding up a
+	// whole energy unit.
+	marginBP := int64(math.Round(margin * energyFactorPrecision))
+	withMargin := (est.Energy*(energyFactorPrecision+marginBP) + energyFactorPrecision - 1) / energyFactorPrecision
+	est.FeeLimit = withMargin * price
+	if est.Ceiling > 0 && est.FeeLimit > est.Ceiling {
+		est.FeeLimit = est.Ceiling
+	}
+	return est, nil
+}
+
+// estimateBaseEnergy returns the energy of a call before the dynamic energy
+// penalty, from EstimateEnergy or, if the node has it disabled, from a
+// constant call.
+func (g *GrpcClient) estimateBaseEnergy(ctx context.Context, from, contractAddress string, data []byte,
+	tAmount int64, tTokenID string, tTokenAmount int64) (int64, error) {
+	est, err := g.EstimateEnergyWithDataCtx(ctx, from, contractAddress, data, tAmount, tTokenID, tTokenAmount)
+	if err == nil {
+		return est.GetEnergyRequired(), nil
+	}
+	if !errors.Is(err, ErrEstimateEnergyNotSupported) && !strings.Contains(err.Error(), ErrEstimateEnergyNotSupported.Error()) {
+		return 0, fmt.Errorf("estimate energy: %w", err)
+	}
+
+	var opts []ConstantCallOption
+	if tAmount > 0 {
+		opts = append(opts, WithCallValue(tAmount))
+	}
+	if tTokenID != "" && tTokenAmount > 0 {
+		opt, err := WithTokenValue(tTokenID, tTokenAmount)
+		if err != nil {
+			return 0, err
+		}
+		opts = append(opts, opt)
+	}
+	if _, err := address.Base58ToAddress(from); err != nil {
+		return 0, err
+	}
+	tx, err := g.TriggerConstantContractWithDataCtx(ctx, from, contractAddress, data, opts...)
+	if err != nil {
+		return 0, fmt.Errorf("estimate energy: %w", err)
+	}
+	if res := tx.GetResult(); res.GetCode() != 0 || !res.GetResult() {
+		return 0, fmt.Errorf("estimate energy: %s", res.GetMessage())
+	}
+	return tx.GetEnergyUsed() - tx.GetEnergyPenalty(), nil
+}
@@ -0,0 +1,104 @@
+package client_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	feeLimitFrom     = "TTGhREx2pDSxFX555NWz1YwGpiBVPvQA7e"
+	feeLimitContract = "TVSvjZdyDSNocHm7dP3jvCmMNsCnMTPa5W"
+)
+
+func feeLimitMock(energyFactor int64) *mockWalletServer {
+	return &mockWalletServer{
+		EstimateEnergyFunc: func(_ context.Context, _ *core.TriggerSmartContract) (*api.EstimateEnergyMessage, error) {
+			return &api.EstimateEnergyMessage{Result: &api.Return{Result: true}, EnergyRequired: 10000}, nil
+		},
+		GetContractInfoFunc: func(_ context.Context, _ *api.BytesMessage) (*core.SmartContractDataWrapper, error) {
+			return &core.SmartContractDataWrapper{ContractState: &core.ContractState{EnergyFactor: energyFactor}}, nil
+		},
+		GetChainParametersFunc: func(_ context.Context, _ *api.EmptyMessage) (*core.ChainParameters, error) {
+			return &core.ChainParameters{ChainParameter: []*core.ChainParameters_ChainParameter{
+				{Key: client.ChainParamEnergyFee, Value: 210},
+				{Key: client.ChainParamMaxFeeLimit, Value: 15_000_000_000},
+			}}, nil
+		},
+		GetAccountResourceFunc: func(_ context.Context, _ *core.Account) (*api.AccountResourceMessage, error) {
+			return &api.AccountResourceMessage{EnergyLimit: 5000, EnergyUsed: 1000}, nil
+		},
+	}
+}
+
+func TestEstimateFeeLimit(t *testing.T) {
+	c := newMockClient(t, feeLimitMock(2000))
+
+	est, err := c.EstimateFeeLimit(feeLimitFrom, feeLimitContract, []byte{0xa9, 0x05, 0x9c, 0xbb}, 0, "", 0, 0.1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12000), est.Energy)
+	assert.Equal(t, int64(2000), est.EnergyFactor)
+	assert.Equal(t, int64(210), est.EnergyPrice)
+	assert.Equal(t, int64(4000), est.AvailableEnergy)
+	assert.Equal(t, int64(8000*210), est.Burn)
+	assert.Equal(t, int64(13200*210), est.FeeLimit)
+	assert.Equal(t, int64(15_000_000_000), est.Ceiling)
+
+	// The margin is clamped to the ceiling.
+	est, err = c.EstimateFeeLimit(feeLimitFrom, feeLimitContract, []byte{0xa9, 0x05, 0x9c, 0xbb}, 0, "", 0, 0.1, 2_600_000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2_600_000), est.FeeLimit)
+
+	// An estimate above the ceiling fails.
+	_, err = c.EstimateFeeLimit(feeLimitFrom, feeLimitContract, []byte{0xa9, 0x05, 0x9c, 0xbb}, 0, "", 0, 0.1, 1_000_000)
+	assert.ErrorContains(t, err, "exceeds fee limit ceiling")
+
+	_, err = c.EstimateFeeLimit(feeLimitFrom, feeLimitContract, nil, 0, "", 0, -1, 0)
+	assert.ErrorContains(t, err, "must not be negative")
+}
+
+func TestEstimateFeeLimitFallback(t *testing.T) {
+	mock := feeLimitMock(0)
+	mock.EstimateEnergyFunc = func(_ context.Context, _ *core.TriggerSmartContract) (*api.EstimateEnergyMessage, error) {
+		return nil, status.Error(codes.Unimplemented, "method not found")
+	}
+	mock.TriggerConstantContractFunc = func(_ context.Context, in *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+		assert.Equal(t, int64(5), in.CallValue)
+		return &api.TransactionExtention{
+			Result:        &api.Return{Result: true},
+			EnergyUsed:    9000,
+			EnergyPenalty: 1000,
+		}, nil
+	}
+	c := newMockClient(t, mock)
+
+	est, err := c.EstimateFeeLimit(feeLimitFrom, feeLimitContract, []byte{0xa9, 0x05, 0x9c, 0xbb}, 5, "", 0, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8000), est.Energy)
+	assert.Equal(t, int64(8000*210), est.FeeLimit)
+}
+
+func TestTRC20SendAutoFeeLimit(t *testing.T) {
+	mock := feeLimitMock(0)
+	mock.TriggerContractFunc = func(_ context.Context, _ *core.TriggerSmartContract) (*api.TransactionExtention, error) {
+		return &api.TransactionExtention{
+			Result:      &api.Return{Result: true, Code: api.Return_SUCCESS},
+			Txid:        []byte{0x01},
+			Transaction: &core.Transaction{RawData: &core.TransactionRaw{}},
+		}, nil
+	}
+	c := newMockClient(t, mock)
+
+	tx, err := c.TRC20Send(feeLimitFrom, "TLyqzVGLV1srkB7dToTAEqgDSfPtXRJZYH", feeLimitContract,
+		big.NewInt(1_000_000), 0, client.WithAutoFeeLimit(0.5))
+	require.NoError(t, err)
+	assert.Equal(t, int64(15000*210), tx.Transaction.RawData.FeeLimit)
+}
@@ -25,6 +25,7 @@ type mockWalletServer struct {
 	TriggerConstantContractFunc func(context.Context, *core.TriggerSmartContract) (*api.TransactionExtention, error)
 	TriggerContractFunc         func(context.Context, *core.TriggerSmartContract) (*api.TransactionExtention, error)
 	GetContractFunc             func(context.Context, *api.BytesMessage) (*core.SmartContract, error)
+	GetContractInfoFunc         func(context.Context, *api.BytesMessage) (*core.SmartContractDataWrapper, error)
 	EstimateEnergyFunc          func(context.Context, *core.TriggerSmartContract) (*api.EstimateEnergyMessage, error)
 
 	// Account
@@ -78,6 +79,7 @@ type mockWalletServer struct {
 	GetEnergyPricesFunc            func(context.Context, *api.EmptyMessage) (*api.PricesResponseMessage, error)
 	GetBandwidthPricesFunc         func(context.Context, *api.EmptyMessage) (*api.PricesResponseMessage, error)
 	GetMemoFeeFunc                 func(context.Context, *api.EmptyMessage) (*api.PricesResponseMessage, error)
+	GetChainParametersFunc         func(context.Context, *api.EmptyMessage) (*core.ChainParameters, error)
 
 	// Assets
 	GetAssetIssueByAccountFunc     func(context.Context, *core.Account) (*api.AssetIssueList, error)
@@ -150,6 +152,13 @@ func (m *mockWalletServer) GetContract(ctx context.Context, in *api.BytesMessage
 	return m.UnimplementedWalletServer.GetContract(ctx, in)
 }
 
+func (m *mockWalletServer) GetContractInfo(ctx context.Context, in *api.BytesMessage) (*core.SmartContractDataWrapper, error) {
+	if m.GetContractInfoFunc != nil {
+		return m.GetContractInfoFunc(ctx, in)
+	}
+	return m.UnimplementedWalletServer.GetContractInfo(ctx, in)
+}
+
 func (m *mockWalletServer) EstimateEnergy(ctx context.Context, in *core.TriggerSmartContract) (*api.EstimateEnergyMessage, error) {
 	if m.EstimateEnergyFunc != nil {
 		return m.EstimateEnergyFunc(ctx, in)
@@ -423,6 +432,13 @@ func (m *mockWalletServer) GetMemoFee(ctx context.Context, in *api.EmptyMessage)
 	return m.UnimplementedWalletServer.GetMemoFee(ctx, in)
 }
 
+func (m *mockWalletServer) GetChainParameters(ctx context.Context, in *api.EmptyMessage) (*core.ChainParameters, error) {
+	if m.GetChainParametersFunc != nil {
+		return m.GetChainParametersFunc(ctx, in)
+	}
+	return m.UnimplementedWalletServer.GetChainParameters(ctx, in)
+}
+
 func (m *mockWalletServer) GetAssetIssueByAccount(ctx context.Context, in *core.Account) (*api.AssetIssueList, error) {
 	if m.GetAssetIssueByAccountFunc != nil {
 		return m.GetAssetIssueByAccountFunc(ctx, in)
@@ -4,7 +4,11 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math/big"
+	"reflect"
 
+	eCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/abi"
 	"github.com/fbsobreira/gotron-sdk/pkg/address"
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
 	proto "google.golang.org/protobuf/proto"
@@ -106,14 +110,25 @@ func decodeTriggerSmartContract(data []byte) (*ContractData, error) {
 	if err := proto.Unmarshal(data, &c); err != nil {
 		return nil, fmt.Errorf("%w: %v", ErrUnmarshalContract, err)
 	}
+	fields := map[string]any{
+		"owner_address":    address.Address(c.GetOwnerAddress()).String(),
+		"contract_address": address.Address(c.GetContractAddress()).String(),
+		"data":             hex.EncodeToString(c.GetData()),
+		"call_value":       sunToTRX(c.GetCallValue()),
+	}
+	// Without the contract ABI, fall back to the offline signature database
+	// so common token, DEX and lending calls are still readable.
+	if call, err := abi.DecodeCalldata(c.GetData()); err == nil {
+		args := make([]any, len(call.Args))
+		for i, arg := range call.Args {
+			args[i] = displayValue(reflect.ValueOf(arg))
+		}
+		fields["method"] = call.Signature
+		fields["args"] = args
+	}
 	return &ContractData{
-		Type: "TriggerSmartContract",
-		Fields: map[string]any{
-			"owner_address":    address.Address(c.GetOwnerAddress()).String(),
-			"contract_address": address.Address(c.GetContractAddress()).String(),
-			"data":             hex.EncodeToString(c.GetData()),
-			"call_value":       sunToTRX(c.GetCallValue()),
-		},
+		Type:   "TriggerSmartContract",
+		Fields: fields,
 	}, nil
 }
 
@@ -233,3 +248,45 @@ func sunToTRX(sun int64) string {
 	}
 	return fmt.Sprintf("%d.%06d", whole, frac)
 }
+
+var (
+	tronAddressType = reflect.TypeOf(address.Address{})
+	ethAddressType  = reflect.TypeOf(eCommon.Address{})
+	bigIntPtrType   = reflect.TypeOf((*big.Int)(nil))
+)
+
+// displayValue converts a decoded ABI value into a human-readable form:
+// base58 addresses, decimal integers, hex bytes, and slices or maps for
+// arrays and tuples.
+func displayValue(v reflect.Value) any {
+	switch {
+	case !v.IsValid():
+		return nil
+	case v.Type() == tronAddressType:
+		return address.Address(v.Bytes()).String()
+	case v.Type() == ethAddressType:
+		return address.Address(append([]byte{address.TronBytePrefix}, v.Interface().(eCommon.Address).Bytes()...)).String()
+	case v.Type() == bigIntPtrType:
+		return v.Interface().(*big.Int).String()
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			return hex.EncodeToString(b)
+		}
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = displayValue(v.Index(i))
+		}
+		return out
+	case reflect.Struct:
+		out := make(map[string]any, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			out[v.Type().Field(i).Name] = displayValue(v.Field(i))
+		}
+		return out
+	}
+	return v.Interface()
+}
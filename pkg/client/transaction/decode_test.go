@@ -1,6 +1,7 @@
 package transaction
 
 import (
+	"encoding/hex"
 	"testing"
 
 	"github.com/fbsobreira/gotron-sdk/pkg/address"
@@ -168,6 +169,24 @@ func TestDecodeContractData_TriggerSmartContract(t *testing.T) {
 	assert.Equal(t, "10.000000", result.Fields["call_value"])
 }
 
+func TestDecodeContractData_TriggerSmartContract_KnownSelector(t *testing.T) {
+	to := testAddr(0x05)
+	data, err := hex.DecodeString("a9059cbb" +
+		"000000000000000000000000" + hex.EncodeToString(to[1:]) +
+		"00000000000000000000000000000000000000000000000000000000000f4240")
+	require.NoError(t, err)
+	tx := buildTx(core.Transaction_Contract_TriggerSmartContract, &core.TriggerSmartContract{
+		OwnerAddress:    testAddr(0x01),
+		ContractAddress: testAddr(0x03),
+		Data:            data,
+	})
+
+	result, err := DecodeContractData(tx)
+	require.NoError(t, err)
+	assert.Equal(t, "transfer(address,uint256)", result.Fields["method"])
+	assert.Equal(t, []any{addrString(to), "1000000"}, result.Fields["args"])
+}
+
 func TestDecodeContractData_TriggerSmartContract_EmptyData(t *testing.T) {
 	tx := buildTx(core.Transaction_Contract_TriggerSmartContract, &core.TriggerSmartContract{
 		OwnerAddress:    testAddr(0x01),
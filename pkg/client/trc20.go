@@ -19,7 +19,8 @@ import (
 type TRC20Option func(*trc20Config)
 
 type trc20Config struct {
-	estimate bool
+	estimate       bool
+	feeLimitMargin *float64
 }
 
 func applyTRC20Options(opts []TRC20Option) trc20Config {
@@ -42,6 +43,32 @@ func WithEstimate() TRC20Option {
 	}
 }
 
+// WithAutoFeeLimit replaces the feeLimit argument with one estimated by
+// EstimateFeeLimitCtx, adding margin (e.g. 0.2 for 20%) to the estimated
+// energy. A positive feeLimit argument becomes the ceiling.
+func WithAutoFeeLimit(margin float64) TRC20Option {
+	return func(c *trc20Config) {
+		c.feeLimitMargin = &margin
+	}
+}
+
+// trc20FeeLimit returns the fee limit for a TRC20 write, estimating it when
+// WithAutoFeeLimit is set.
+func (g *GrpcClient) trc20FeeLimit(ctx context.Context, cfg trc20Config, from, contract, data string, feeLimit int64) (int64, error) {
+	if cfg.estimate || cfg.feeLimitMargin == nil {
+		return feeLimit, nil
+	}
+	dataBytes, err := common.FromHex(data)
+	if err != nil {
+		return 0, err
+	}
+	est, err := g.EstimateFeeLimitCtx(ctx, from, contract, dataBytes, 0, "", 0, *cfg.feeLimitMargin, feeLimit)
+	if err != nil {
+		return 0, err
+	}
+	return est.FeeLimit, nil
+}
+
 const (
 	trc20TransferMethodSignature     = "0xa9059cbb"
 	trc20ApproveMethodSignature      = "0x095ea7b3"
@@ -262,6 +289,9 @@ func (g *GrpcClient) TRC20SendCtx(ctx context.Context, from, to, contract string
 	ab := common.LeftPadBytes(amount.Bytes(), 32)
 	req := trc20TransferMethodSignature + "0000000000000000000000000000000000000000000000000000000000000000"[len(addrB.Hex())-4:] + addrB.Hex()[4:]
 	req += common.Bytes2Hex(ab)
+	if feeLimit, err = g.trc20FeeLimit(ctx, cfg, from, contract, req, feeLimit); err != nil {
+		return nil, err
+	}
 	return g.TRC20CallCtx(ctx, from, contract, req, cfg.estimate, feeLimit)
 }
 
@@ -293,6 +323,9 @@ func (g *GrpcClient) TRC20TransferFromCtx(ctx context.Context, owner, from, to,
 		"0000000000000000000000000000000000000000000000000000000000000000"[len(addrA.Hex())-4:] + addrA.Hex()[4:] +
 		"0000000000000000000000000000000000000000000000000000000000000000"[len(addrB.Hex())-4:] + addrB.Hex()[4:]
 	req += common.Bytes2Hex(ab)
+	if feeLimit, err = g.trc20FeeLimit(ctx, cfg, owner, contract, req, feeLimit); err != nil {
+		return nil, err
+	}
 	return g.TRC20CallCtx(ctx, owner, contract, req, cfg.estimate, feeLimit)
 }
 
@@ -315,5 +348,8 @@ func (g *GrpcClient) TRC20ApproveCtx(ctx context.Context, from, to, contract str
 	ab := common.LeftPadBytes(amount.Bytes(), 32)
 	req := trc20ApproveMethodSignature + "0000000000000000000000000000000000000000000000000000000000000000"[len(addrB.Hex())-4:] + addrB.Hex()[4:]
 	req += common.Bytes2Hex(ab)
+	if feeLimit, err = g.trc20FeeLimit(ctx, cfg, from, contract, req, feeLimit); err != nil {
+		return nil, err
+	}
 	return g.TRC20CallCtx(ctx, from, contract, req, cfg.estimate, feeLimit)
 }
@@ -0,0 +1,176 @@
+package contract
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ArtifactFormat identifies the toolchain that produced a compiler artifact.
+type ArtifactFormat string
+
+// Supported artifact formats.
+const (
+	ArtifactHardhat ArtifactFormat = "hardhat"
+	ArtifactFoundry ArtifactFormat = "foundry"
+	ArtifactTronBox ArtifactFormat = "tronbox"
+)
+
+// LinkReference is the position of a library address in creation bytecode,
+// as a byte offset and length.
+type LinkReference struct {
+	Start  int `json:"start"`
+	Length int `json:"length"`
+}
+
+// Artifact is a compiled contract loaded from a Hardhat, Foundry or TronBox
+// build file.
+type Artifact struct {
+	Format       ArtifactFormat
+	ContractName string
+	// ABI is the contract ABI as a JSON array.
+	ABI string
+	// Bytecode is the hex-encoded creation bytecode without 0x prefix. It may
+	// contain library placeholders.
+	Bytecode string
+	// LinkReferences maps fully qualified library names ("source:Name") to
+	// their positions in Bytecode. TronBox artifacts carry no link references;
+	// their libraries are linked by placeholder only.
+	LinkReferences map[string][]LinkReference
+}
+
+// rawArtifact covers the fields of all supported formats. Bytecode is a hex
+// string for Hardhat and TronBox and an object for Foundry; metadata is an
+// object for Foundry and a string elsewhere.
+type rawArtifact struct {
+	Format         string                                `json:"_format"`
+	ContractName   string                                `json:"contractName"`
+	ABI            json.RawMessage                       `json:"abi"`
+	Bytecode       json.RawMessage                       `json:"bytecode"`
+	LinkReferences map[string]map[string][]LinkReference `json:"linkReferences"`
+	Metadata       json.RawMessage                       `json:"metadata"`
+}
+
+type foundryBytecode struct {
+	Object         string                                `json:"object"`
+	LinkReferences map[string]map[string][]LinkReference `json:"linkReferences"`
+}
+
+type foundryMetadata struct {
+	Settings struct {
+		CompilationTarget map[string]string `json:"compilationTarget"`
+	} `json:"settings"`
+}
+
+// LoadArtifact parses a Hardhat, Foundry or TronBox artifact. The format is
+// detected from the file's structure.
+func LoadArtifact(data []byte) (*Artifact, error) {
+	var raw rawArtifact
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse artifact: %w", err)
+	}
+	if len(raw.ABI) == 0 || bytes.Equal(raw.ABI, []byte("null")) {
+		return nil, errors.New("artifact has no abi")
+	}
+	if len(raw.Bytecode) == 0 {
+		return nil, errors.New("artifact has no bytecode")
+	}
+
+	a := &Artifact{ContractName: raw.ContractName, ABI: string(raw.ABI)}
+	var refs map[string]map[string][]LinkReference
+	if raw.Bytecode[0] == '{' {
+		var bc foundryBytecode
+		if err := json.Unmarshal(raw.Bytecode, &bc); err != nil {
+			return nil, fmt.Errorf("parse artifact bytecode: %w", err)
+		}
+		a.Format = ArtifactFoundry
+		a.Bytecode = bc.Object
+		refs = bc.LinkReferences
+		if a.ContractName == "" {
+			a.ContractName = foundryContractName(raw.Metadata)
+		}
+	} else {
+		if err := json.Unmarshal(raw.Bytecode, &a.Bytecode); err != nil {
+			return nil, fmt.Errorf("parse artifact bytecode: %w", err)
+		}
+		a.Format = ArtifactTronBox
+		if strings.HasPrefix(raw.Format, "hh-") {
+			a.Format = ArtifactHardhat
+		}
+		refs = raw.LinkReferences
+	}
+
+	a.Bytecode = strings.TrimPrefix(strings.TrimSpace(a.Bytecode), "0x")
+	if a.Bytecode == "" {
+		return nil, fmt.Errorf("%s has no creation bytecode (abstract contract or interface?)", a.describe())
+	}
+	for source, libs := range refs {
+		for name, positions := range libs {
+			if a.LinkReferences == nil {
+				a.LinkReferences = make(map[string][]LinkReference)
+			}
+			a.LinkReferences[source+":"+name] = positions
+		}
+	}
+	return a, nil
+}
+
+// LoadArtifactFile reads and parses an artifact file. Foundry artifacts
+// without compilation metadata take their contract name from the file name.
+func LoadArtifactFile(path string) (*Artifact, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	a, err := LoadArtifact(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if a.ContractName == "" {
+		a.ContractName = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return a, nil
+}
+
+// Libraries returns the fully qualified names of the libraries that must be
+// linked before deployment, sorted.
+func (a *Artifact) Libraries() []string {
+	libs := make([]string, 0, len(a.LinkReferences))
+	for name := range a.LinkReferences {
+		libs = append(libs, name)
+	}
+	sort.Strings(libs)
+	return libs
+}
+
+// Deploy starts a deployment builder for the artifact. The contract name
+// recorded on chain defaults to the artifact's contract name, and libraries
+// passed to Link may be given by fully qualified or plain name.
+func (a *Artifact) Deploy(c Deployer) *Deployment {
+	d := Deploy(c, a.ABI, a.Bytecode).Name(a.ContractName)
+	d.linkRefs = a.LinkReferences
+	return d
+}
+
+func (a *Artifact) describe() string {
+	if a.ContractName == "" {
+		return string(a.Format) + " artifact"
+	}
+	return a.ContractName
+}
+
+func foundryContractName(metadata json.RawMessage) string {
+	var md foundryMetadata
+	if len(metadata) == 0 || json.Unmarshal(metadata, &md) != nil {
+		return ""
+	}
+	for _, name := range md.Settings.CompilationTarget {
+		return name
+	}
+	return ""
+}
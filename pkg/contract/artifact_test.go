@@ -0,0 +1,109 @@
+package contract
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const artifactABI = `[{"type":"constructor","inputs":[{"name":"supply","type":"uint256"}],"stateMutability":"nonpayable"}]`
+
+func TestLoadArtifact(t *testing.T) {
+	placeholder := libraryPlaceholders("contracts/Math.sol:Math")[0]
+	code := "6080" + placeholder + "00"
+
+	tests := []struct {
+		name   string
+		json   string
+		format ArtifactFormat
+		refs   int
+	}{
+		{
+			name: "hardhat",
+			json: `{"_format":"hh-sol-artifact-1","contractName":"Token","sourceName":"contracts/Token.sol",
+			 "abi":` + artifactABI + `,"bytecode":"0x` + code + `","deployedBytecode":"0x00",
+			 "linkReferences":{"contracts/Math.sol":{"Math":[{"start":2,"length":20}]}}}`,
+			format: ArtifactHardhat,
+			refs:   1,
+		},
+		{
+			name: "foundry",
+			json: `{"abi":` + artifactABI + `,
+			 "bytecode":{"object":"0x` + code + `","sourceMap":"","linkReferences":{"contracts/Math.sol":{"Math":[{"start":2,"length":20}]}}},
+			 "deployedBytecode":{"object":"0x00"},
+			 "metadata":{"settings":{"compilationTarget":{"src/Token.sol":"Token"}}}}`,
+			format: ArtifactFoundry,
+			refs:   1,
+		},
+		{
+			name: "tronbox",
+			json: `{"contractName":"Token","abi":` + artifactABI + `,"bytecode":"` + code + `",
+			 "deployedBytecode":"00","metadata":"{}","sourcePath":"contracts/Token.sol"}`,
+			format: ArtifactTronBox,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := LoadArtifact([]byte(tt.json))
+			require.NoError(t, err)
+			assert.Equal(t, tt.format, a.Format)
+			assert.Equal(t, "Token", a.ContractName)
+			assert.Equal(t, code, a.Bytecode)
+			assert.JSONEq(t, artifactABI, a.ABI)
+			assert.Len(t, a.LinkReferences, tt.refs)
+		})
+	}
+}
+
+func TestLoadArtifactErrors(t *testing.T) {
+	_, err := LoadArtifact([]byte(`not json`))
+	assert.ErrorContains(t, err, "parse artifact")
+
+	_, err = LoadArtifact([]byte(`{"bytecode":"0x6080"}`))
+	assert.ErrorContains(t, err, "no abi")
+
+	_, err = LoadArtifact([]byte(`{"_format":"hh-sol-artifact-1","contractName":"IToken","abi":[],"bytecode":"0x"}`))
+	assert.ErrorContains(t, err, "IToken has no creation bytecode")
+}
+
+func TestLoadArtifactFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "Counter.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"abi":[],"bytecode":{"object":"0x6080"}}`), 0o600))
+
+	a, err := LoadArtifactFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, ArtifactFoundry, a.Format)
+	assert.Equal(t, "Counter", a.ContractName, "name falls back to the file name")
+
+	_, err = LoadArtifactFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestArtifactDeployLinksByReference(t *testing.T) {
+	lib := "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	libAddr, err := address.Base58ToAddress(lib)
+	require.NoError(t, err)
+	libHex := hex.EncodeToString(libAddr.Bytes()[1:])
+
+	a := &Artifact{
+		ContractName:   "Token",
+		ABI:            artifactABI,
+		Bytecode:       "6080" + libraryPlaceholders("contracts/Math.sol:Math")[0] + "00",
+		LinkReferences: map[string][]LinkReference{"contracts/Math.sol:Math": {{Start: 2, Length: 20}}},
+	}
+	assert.Equal(t, []string{"contracts/Math.sol:Math"}, a.Libraries())
+
+	md := &mockDeployer{}
+	bc, err := a.Deploy(md).Link("Math", lib).Args(1).Bytecode()
+	require.NoError(t, err)
+	assert.Equal(t, "6080"+libHex+"00", hex.EncodeToString(bc[:23]))
+	assert.Len(t, bc, 23+32)
+
+	_, err = a.Deploy(md).Args(1).Bytecode()
+	assert.ErrorContains(t, err, "unlinked library")
+}
@@ -0,0 +1,285 @@
+package bind
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	eCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/contract"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// ErrEventMismatch is returned by UnpackLog when the log does not carry the
+// requested event's signature.
+var ErrEventMismatch = errors.New("log does not match event signature")
+
+// BoundContract is the runtime used by generated bindings. It packs typed
+// arguments, decodes typed results and parses event logs for a single
+// deployed contract.
+type BoundContract struct {
+	client  contract.Client
+	address string
+	evmAddr []byte // 20-byte address as it appears in TransactionInfo logs
+	abiJSON string
+	abi     eABI.ABI
+}
+
+// NewBoundContract parses abiJSON and binds it to the contract deployed at
+// contractAddress.
+func NewBoundContract(client contract.Client, contractAddress, abiJSON string) (*BoundContract, error) {
+	addr, err := address.Base58ToAddress(contractAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid contract address %s: %w", contractAddress, err)
+	}
+	parsed, err := abi.ParseABI(abiJSON)
+	if err != nil {
+		return nil, err
+	}
+	return &BoundContract{
+		client:  client,
+		address: contractAddress,
+		evmAddr: addr.Bytes()[1:],
+		abiJSON: abiJSON,
+		abi:     parsed,
+	}, nil
+}
+
+// Address returns the base58 address of the bound contract.
+func (b *BoundContract) Address() string {
+	return b.address
+}
+
+// ABI returns the parsed contract ABI.
+func (b *BoundContract) ABI() eABI.ABI {
+	return b.abi
+}
+
+// Pack encodes a call to the named method, including the 4-byte selector.
+// Overloaded methods are addressed by their generated name (e.g. "transfer0").
+func (b *BoundContract) Pack(method string, args ...interface{}) ([]byte, error) {
+	m, ok := b.abi.Methods[method]
+	if !ok {
+		return nil, fmt.Errorf("method %s not found in ABI", method)
+	}
+	encoded, err := abi.PackArgs(m.Inputs, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", m.Sig, err)
+	}
+	return append(append([]byte{}, m.ID...), encoded...), nil
+}
+
+// Transact returns a ContractCall for a state-changing method. Encoding
+// errors are deferred to the call's terminal operation.
+func (b *BoundContract) Transact(from, method string, args ...interface{}) *contract.ContractCall {
+	call := contract.New(b.client, b.address).From(from).WithABI(b.abiJSON)
+	data, err := b.Pack(method, args...)
+	if err != nil {
+		return call.SetError(err)
+	}
+	return call.WithData(data)
+}
+
+// Call executes a read-only method and decodes its outputs into out (see
+// abi.UnpackInto). A nil out discards the result.
+func (b *BoundContract) Call(ctx context.Context, out interface{}, method string, args ...interface{}) error {
+	data, err := b.Pack(method, args...)
+	if err != nil {
+		return err
+	}
+	result, err := contract.New(b.client, b.address).WithData(data).Call(ctx)
+	if err != nil {
+		return err
+	}
+	if out == nil {
+		return nil
+	}
+	if len(result.RawResults) == 0 {
+		return fmt.Errorf("%s: %w", method, contract.ErrEmptyResult)
+	}
+	return b.Unpack(out, method, result.RawResults[0])
+}
+
+// Unpack decodes the return data of the named method into out.
+func (b *BoundContract) Unpack(out interface{}, method string, data []byte) error {
+	m, ok := b.abi.Methods[method]
+	if !ok {
+		return fmt.Errorf("method %s not found in ABI", method)
+	}
+	if err := abi.UnpackInto(m.Outputs, data, out); err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	return nil
+}
+
+// UnpackLog decodes an event log emitted by this contract into out, a
+// pointer to a struct whose fields are matched to the event inputs as
+// described by abi.CopyValues. A Raw *core.TransactionInfo_Log field, if
+// present, receives the log itself.
+func (b *BoundContract) UnpackLog(out interface{}, event string, log *core.TransactionInfo_Log) error {
+	ev, ok := b.abi.Events[event]
+	if !ok {
+		return fmt.Errorf("event %s not found in ABI", event)
+	}
+	topics := log.GetTopics()
+	if !ev.Anonymous {
+		if len(topics) == 0 || !bytes.Equal(topics[0], ev.ID.Bytes()) {
+			return fmt.Errorf("%s: %w", ev.Sig, ErrEventMismatch)
+		}
+		topics = topics[1:]
+	}
+
+	nonIndexed, err := ev.Inputs.NonIndexed().Unpack(log.GetData())
+	if err != nil {
+		return fmt.Errorf("%s: unpack data: %w", ev.Sig, err)
+	}
+
+	values := make([]interface{}, 0, len(ev.Inputs))
+	for _, input := range ev.Inputs {
+		if !input.Indexed {
+			values = append(values, nonIndexed[0])
+			nonIndexed = nonIndexed[1:]
+			continue
+		}
+		if len(topics) == 0 {
+			return fmt.Errorf("%s: missing topic for %s", ev.Sig, input.Name)
+		}
+		v, err := decodeTopic(input.Type, topics[0])
+		if err != nil {
+			return fmt.Errorf("%s: topic %s: %w", ev.Sig, input.Name, err)
+		}
+		values = append(values, v)
+		topics = topics[1:]
+	}
+	if err := abi.CopyValues(out, ev.Inputs, values); err != nil {
+		return fmt.Errorf("%s: %w", ev.Sig, err)
+	}
+
+	rv := reflect.ValueOf(out).Elem()
+	if raw := rv.FieldByName("Raw"); raw.IsValid() && raw.Type() == reflect.TypeOf(log) {
+		raw.Set(reflect.ValueOf(log))
+	}
+	return nil
+}
+
+// FilterLogs returns the logs emitted by this contract for the named event
+// whose indexed parameters match query. Each query entry corresponds to one
+// indexed parameter in declaration order; an empty entry matches any value,
+// and multiple values within an entry are OR-ed.
+//
+// TRON nodes expose logs through TransactionInfo (see GetTransactionInfoByID
+// and GetTransactionInfoByBlockNum), so callers pass those logs in directly.
+func (b *BoundContract) FilterLogs(logs []*core.TransactionInfo_Log, event string, query ...[]interface{}) ([]*core.TransactionInfo_Log, error) {
+	ev, ok := b.abi.Events[event]
+	if !ok {
+		return nil, fmt.Errorf("event %s not found in ABI", event)
+	}
+
+	var indexed eABI.Arguments
+	for _, input := range ev.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if len(query) > len(indexed) {
+		return nil, fmt.Errorf("%s: %d topic filters for %d indexed parameters", ev.Sig, len(query), len(indexed))
+	}
+
+	rules := make([][]interface{}, len(query))
+	for i, q := range query {
+		for _, v := range q {
+			converted, err := abi.ConvertValue(indexed[i].Type, v)
+			if err != nil {
+				return nil, fmt.Errorf("%s: filter %s: %w", ev.Sig, indexed[i].Name, err)
+			}
+			rules[i] = append(rules[i], converted)
+		}
+	}
+	topicRules, err := eABI.MakeTopics(rules...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ev.Sig, err)
+	}
+
+	var matched []*core.TransactionInfo_Log
+	for _, log := range logs {
+		if !b.isOwnLog(log) {
+			continue
+		}
+		topics := log.GetTopics()
+		if !ev.Anonymous {
+			if len(topics) == 0 || !bytes.Equal(topics[0], ev.ID.Bytes()) {
+				continue
+			}
+			topics = topics[1:]
+		}
+		if matchTopics(topics, topicRules) {
+			matched = append(matched, log)
+		}
+	}
+	return matched, nil
+}
+
+// Rule converts a typed filter slice into a topic rule for FilterLogs.
+func Rule[T any](values []T) []interface{} {
+	rule := make([]interface{}, len(values))
+	for i, v := range values {
+		rule[i] = v
+	}
+	return rule
+}
+
+// isOwnLog reports whether log was emitted by the bound contract. Logs carry
+// the 20-byte EVM form of the address, but a 21-byte TRON form is accepted
+// as well.
+func (b *BoundContract) isOwnLog(log *core.TransactionInfo_Log) bool {
+	addr := log.GetAddress()
+	if len(addr) == address.AddressLength {
+		addr = addr[1:]
+	}
+	return bytes.Equal(addr, b.evmAddr)
+}
+
+// matchTopics reports whether the indexed topics satisfy every rule set.
+func matchTopics(topics [][]byte, rules [][]eCommon.Hash) bool {
+	for i, rule := range rules {
+		if len(rule) == 0 {
+			continue
+		}
+		if i >= len(topics) {
+			return false
+		}
+		found := false
+		for _, want := range rule {
+			if bytes.Equal(topics[i], want.Bytes()) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// decodeTopic decodes an indexed event parameter. Value types are stored
+// ABI-encoded in the topic; strings, bytes, arrays and tuples are stored as
+// their Keccak-256 hash, which is returned as a [32]byte.
+func decodeTopic(ty eABI.Type, topic []byte) (interface{}, error) {
+	switch ty.T {
+	case eABI.StringTy, eABI.BytesTy, eABI.SliceTy, eABI.ArrayTy, eABI.TupleTy:
+		var h [32]byte
+		copy(h[:], topic)
+		return h, nil
+	}
+	values, err := eABI.Arguments{{Type: ty}}.Unpack(topic)
+	if err != nil {
+		return nil, err
+	}
+	return values[0], nil
+}
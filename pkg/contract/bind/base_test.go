@@ -0,0 +1,305 @@
+package bind
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	eCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/contract"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	testContract = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	testHolder   = "TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1"
+)
+
+// mockClient implements contract.Client, recording the data of the last
+// call and replying with constantResult.
+type mockClient struct {
+	constantResult [][]byte
+	lastData       []byte
+}
+
+func (m *mockClient) TriggerConstantContractCtx(_ context.Context, _, _, _, _ string, _ ...client.ConstantCallOption) (*api.TransactionExtention, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClient) TriggerContractCtx(_ context.Context, _, _, _, _ string, _, _ int64, _ string, _ int64) (*api.TransactionExtention, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClient) TriggerConstantContractWithDataCtx(_ context.Context, _, _ string, data []byte, _ ...client.ConstantCallOption) (*api.TransactionExtention, error) {
+	m.lastData = data
+	return &api.TransactionExtention{ConstantResult: m.constantResult, Result: &api.Return{Result: true}}, nil
+}
+
+func (m *mockClient) TriggerContractWithDataCtx(_ context.Context, _, _ string, data []byte, _, _ int64, _ string, _ int64) (*api.TransactionExtention, error) {
+	m.lastData = data
+	return &api.TransactionExtention{
+		Transaction: &core.Transaction{RawData: &core.TransactionRaw{}},
+		Result:      &api.Return{Result: true},
+	}, nil
+}
+
+func (m *mockClient) EstimateEnergyCtx(_ context.Context, _, _, _, _ string, _ int64, _ string, _ int64) (*api.EstimateEnergyMessage, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClient) EstimateEnergyWithDataCtx(_ context.Context, _, _ string, _ []byte, _ int64, _ string, _ int64) (*api.EstimateEnergyMessage, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClient) BroadcastCtx(_ context.Context, _ *core.Transaction) (*api.Return, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClient) GetTransactionInfoByIDCtx(_ context.Context, _ string) (*core.TransactionInfo, error) {
+	return nil, errors.New("not implemented")
+}
+
+// Types mirroring what the generator emits for marketABI.
+type marketLeg struct {
+	Token address.Address
+	Qty   uint64
+}
+
+type marketOrder struct {
+	Maker   address.Address
+	Amounts []*big.Int
+	Legs    []marketLeg
+}
+
+type marketGetOrderOutput struct {
+	Order  marketOrder
+	Active bool
+}
+
+type marketFilled struct {
+	Tag   [32]byte
+	Legs  [32]byte
+	Maker address.Address
+	Data  []byte
+	Raw   *core.TransactionInfo_Log
+}
+
+func mustAddr(t *testing.T, s string) address.Address {
+	t.Helper()
+	a, err := address.Base58ToAddress(s)
+	require.NoError(t, err)
+	return a
+}
+
+func evm(a address.Address) eCommon.Address {
+	return eCommon.BytesToAddress(a.Bytes()[1:])
+}
+
+func keccak(data []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func newMarket(t *testing.T, mc *mockClient) *BoundContract {
+	t.Helper()
+	b, err := NewBoundContract(mc, testContract, marketABI)
+	require.NoError(t, err)
+	return b
+}
+
+func TestNewBoundContractErrors(t *testing.T) {
+	_, err := NewBoundContract(&mockClient{}, "invalid", marketABI)
+	assert.Error(t, err)
+	_, err = NewBoundContract(&mockClient{}, testContract, "not json")
+	assert.Error(t, err)
+}
+
+func TestTransactEncodesTypedArgs(t *testing.T) {
+	mc := &mockClient{}
+	b := newMarket(t, mc)
+	holder := mustAddr(t, testHolder)
+
+	orders := []marketOrder{{
+		Maker:   holder,
+		Amounts: []*big.Int{big.NewInt(1), big.NewInt(2)},
+		Legs:    []marketLeg{{Token: holder, Qty: 7}},
+	}}
+	_, err := b.Transact(testHolder, "submit", orders, big.NewInt(1000001)).Build(context.Background())
+	require.NoError(t, err)
+
+	// Pack the same call with go-ethereum types to compare.
+	type leg struct {
+		Token eCommon.Address
+		Qty   uint64
+	}
+	type order struct {
+		Maker   eCommon.Address
+		Amounts []*big.Int
+		Legs    []leg
+	}
+	m := b.ABI().Methods["submit"]
+	want, err := m.Inputs.Pack([]order{{
+		Maker:   evm(holder),
+		Amounts: []*big.Int{big.NewInt(1), big.NewInt(2)},
+		Legs:    []leg{{Token: evm(holder), Qty: 7}},
+	}}, big.NewInt(1000001))
+	require.NoError(t, err)
+	assert.Equal(t, append(m.ID, want...), mc.lastData)
+}
+
+func TestTransactDefersErrors(t *testing.T) {
+	b := newMarket(t, &mockClient{})
+
+	call := b.Transact(testHolder, "transfer0", mustAddr(t, testHolder))
+	require.Error(t, call.Err())
+	assert.Contains(t, call.Err().Error(), "expected 2 arguments")
+
+	call = b.Transact(testHolder, "transfer0", "not an address", big.NewInt(1))
+	require.Error(t, call.Err())
+
+	call = b.Transact(testHolder, "missing")
+	assert.ErrorContains(t, call.Err(), "not found")
+}
+
+func TestCallDecodesTuples(t *testing.T) {
+	holder := mustAddr(t, testHolder)
+	mc := &mockClient{}
+	b := newMarket(t, mc)
+
+	type leg struct {
+		Token eCommon.Address
+		Qty   uint64
+	}
+	type order struct {
+		Maker   eCommon.Address
+		Amounts []*big.Int
+		Legs    []leg
+	}
+	m := b.ABI().Methods["getOrder"]
+	encoded, err := m.Outputs.Pack(order{
+		Maker:   evm(holder),
+		Amounts: []*big.Int{big.NewInt(5)},
+		Legs:    []leg{{Token: evm(holder), Qty: 9}},
+	}, true)
+	require.NoError(t, err)
+	mc.constantResult = [][]byte{encoded}
+
+	out := new(marketGetOrderOutput)
+	require.NoError(t, b.Call(context.Background(), out, "getOrder", big.NewInt(3)))
+
+	assert.Equal(t, holder, out.Order.Maker)
+	assert.Equal(t, testHolder, out.Order.Maker.String())
+	require.Len(t, out.Order.Amounts, 1)
+	assert.Equal(t, int64(5), out.Order.Amounts[0].Int64())
+	assert.Equal(t, []marketLeg{{Token: holder, Qty: 9}}, out.Order.Legs)
+	assert.True(t, out.Active)
+
+	wantData, err := b.Pack("getOrder", big.NewInt(3))
+	require.NoError(t, err)
+	assert.Equal(t, wantData, mc.lastData)
+}
+
+func TestCallSingleOutputAndEmptyResult(t *testing.T) {
+	abiJSON := `[{"type":"function","name":"owner","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"address"}]}]`
+	holder := mustAddr(t, testHolder)
+	mc := &mockClient{constantResult: [][]byte{eCommon.LeftPadBytes(holder.Bytes()[1:], 32)}}
+	b, err := NewBoundContract(mc, testContract, abiJSON)
+	require.NoError(t, err)
+
+	var out address.Address
+	require.NoError(t, b.Call(context.Background(), &out, "owner"))
+	assert.Equal(t, testHolder, out.String())
+
+	mc.constantResult = nil
+	assert.ErrorIs(t, b.Call(context.Background(), &out, "owner"), contract.ErrEmptyResult)
+	assert.NoError(t, b.Call(context.Background(), nil, "owner"))
+}
+
+func filledLog(t *testing.T, b *BoundContract, maker address.Address, tag string) *core.TransactionInfo_Log {
+	t.Helper()
+	ev := b.ABI().Events["Filled"]
+	data, err := eABI.Arguments{{Type: ev.Inputs[3].Type}}.Pack([]byte{0xca, 0xfe})
+	require.NoError(t, err)
+	return &core.TransactionInfo_Log{
+		Address: mustAddr(t, testContract).Bytes()[1:],
+		Topics: [][]byte{
+			ev.ID.Bytes(),
+			keccak([]byte(tag)),
+			keccak([]byte("legs")),
+			eCommon.LeftPadBytes(maker.Bytes()[1:], 32),
+		},
+		Data: data,
+	}
+}
+
+func TestUnpackLog(t *testing.T) {
+	b := newMarket(t, &mockClient{})
+	maker := mustAddr(t, testHolder)
+	log := filledLog(t, b, maker, "gold")
+
+	ev := new(marketFilled)
+	require.NoError(t, b.UnpackLog(ev, "Filled", log))
+	assert.Equal(t, maker, ev.Maker)
+	assert.Equal(t, keccak([]byte("gold")), ev.Tag[:])
+	assert.Equal(t, []byte{0xca, 0xfe}, ev.Data)
+	assert.Same(t, log, ev.Raw)
+
+	log.Topics[0] = make([]byte, 32)
+	assert.ErrorIs(t, b.UnpackLog(ev, "Filled", log), ErrEventMismatch)
+}
+
+func TestUnpackLogRenamedField(t *testing.T) {
+	b := newMarket(t, &mockClient{})
+	ev := b.ABI().Events["Noted"]
+	data, err := ev.Inputs.NonIndexed().Pack("memo", true)
+	require.NoError(t, err)
+	log := &core.TransactionInfo_Log{Topics: [][]byte{ev.ID.Bytes()}, Data: data}
+
+	// Mirrors the generated struct: "raw" clashes with the Raw log field.
+	var noted struct {
+		Raw0 string `abi:"raw"`
+		Ok   bool
+		Raw  *core.TransactionInfo_Log
+	}
+	require.NoError(t, b.UnpackLog(&noted, "Noted", log))
+	assert.Equal(t, "memo", noted.Raw0)
+	assert.True(t, noted.Ok)
+	assert.Same(t, log, noted.Raw)
+}
+
+func TestFilterLogs(t *testing.T) {
+	b := newMarket(t, &mockClient{})
+	maker := mustAddr(t, testHolder)
+	other := mustAddr(t, "TN3W4H6rK2ce4vX9YnFQHwKENnHjoxb3m9")
+
+	gold := filledLog(t, b, maker, "gold")
+	silver := filledLog(t, b, other, "silver")
+	foreign := filledLog(t, b, maker, "gold")
+	foreign.Address = other.Bytes()[1:]
+	unrelated := &core.TransactionInfo_Log{Address: gold.Address, Topics: [][]byte{make([]byte, 32)}}
+	logs := []*core.TransactionInfo_Log{gold, silver, foreign, unrelated}
+
+	all, err := b.FilterLogs(logs, "Filled")
+	require.NoError(t, err)
+	assert.Equal(t, []*core.TransactionInfo_Log{gold, silver}, all)
+
+	byMaker, err := b.FilterLogs(logs, "Filled", nil, nil, Rule([]address.Address{other}))
+	require.NoError(t, err)
+	assert.Equal(t, []*core.TransactionInfo_Log{silver}, byMaker)
+
+	byTag, err := b.FilterLogs(logs, "Filled", Rule([]string{"gold", "bronze"}))
+	require.NoError(t, err)
+	assert.Equal(t, []*core.TransactionInfo_Log{gold}, byTag)
+
+	_, err = b.FilterLogs(logs, "Filled", nil, nil, nil, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,408 @@
+// Package bind generates typed Go bindings for TRON smart contracts and
+// provides the runtime those bindings are built on.
+//
+// Generated bindings wrap the contract call builder: state-changing methods
+// return a *contract.ContractCall ready for Send or SendAndConfirm, view
+// methods return decoded Go values, and events get typed structs together
+// with Parse and Filter helpers that operate on TransactionInfo logs.
+package bind
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strings"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/abi"
+)
+
+// reservedParams are identifiers used by the generated method bodies, so ABI
+// parameter names must not shadow them.
+var reservedParams = map[string]bool{
+	"ctx": true, "from": true, "opts": true, "out": true, "err": true,
+	"logs": true, "log": true, "event": true, "events": true, "query": true, "matched": true,
+}
+
+// reservedMethods are method names already defined on every binding.
+var reservedMethods = map[string]bool{
+	"Address": true, "Contract": true,
+}
+
+type tmplData struct {
+	Package   string
+	Type      string
+	InputABI  string
+	Calls     []*tmplMethod
+	Transacts []*tmplMethod
+	Events    []*tmplEvent
+	Structs   []*tmplStruct
+}
+
+type tmplField struct {
+	Name string // Go identifier (exported for struct fields, lowerCamel for params)
+	Type string // Go type expression
+	Tag  string // ABI name for struct fields whose Go name was disambiguated
+}
+
+type tmplMethod struct {
+	Name       string // Go method name
+	Original   string // method key in the parsed ABI (overloads are suffixed)
+	Sig        string
+	Payable    bool
+	Inputs     []tmplField
+	Outputs    []tmplField
+	OutputType string // struct name when the method has several outputs
+}
+
+type tmplEvent struct {
+	Name     string // Go name
+	Original string
+	Sig      string
+	Fields   []tmplField
+	Filters  []tmplFilter
+}
+
+type tmplFilter struct {
+	Param string // lowerCamel parameter name, empty when the topic cannot be filtered
+	Type  string // element type of the filter slice
+}
+
+type tmplStruct struct {
+	Name   string
+	Fields []tmplField
+}
+
+// Generate returns gofmt-ed Go source for a typed binding of the contract
+// described by abiJSON. pkg is the package clause of the generated file and
+// typeName the exported name of the binding type (e.g. "TestToken").
+func Generate(abiJSON, pkg, typeName string) ([]byte, error) {
+	if !token.IsIdentifier(pkg) {
+		return nil, fmt.Errorf("invalid package name %q", pkg)
+	}
+	if !token.IsIdentifier(typeName) || !token.IsExported(typeName) {
+		return nil, fmt.Errorf("invalid type name %q: must be an exported Go identifier", typeName)
+	}
+	parsed, err := abi.ParseABI(abiJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		typeName: typeName,
+		structs:  make(map[string]*tmplStruct),
+		types:    make(map[string]bool),
+	}
+	data := &tmplData{
+		Package:  pkg,
+		Type:     typeName,
+		InputABI: strings.ReplaceAll(strings.TrimSpace(abiJSON), "`", "` + \"`\" + `"),
+	}
+
+	// Name methods and events first, so that tuple structs named later can
+	// avoid the output and event struct names.
+	for _, name := range []string{"context", "big", "address", "contract", "bind", "core",
+		typeName, "New" + typeName, typeName + "ABI"} {
+		g.types[name] = true
+	}
+	usedMethods := make(map[string]bool)
+	methodNames := make(map[string]string, len(parsed.Methods))
+	for _, name := range sortedKeys(parsed.Methods) {
+		methodNames[name] = methodName(parsed.Methods[name], usedMethods)
+		if len(parsed.Methods[name].Outputs) > 1 {
+			g.types[typeName+methodNames[name]+"Output"] = true
+		}
+	}
+	eventNames := make(map[string]string, len(parsed.Events))
+	for _, name := range sortedKeys(parsed.Events) {
+		eventNames[name] = eventName(parsed.Events[name], usedMethods)
+		g.types[typeName+eventNames[name]] = true
+	}
+
+	for _, name := range sortedKeys(parsed.Methods) {
+		m, err := g.method(parsed.Methods[name], methodNames[name])
+		if err != nil {
+			return nil, err
+		}
+		if parsed.Methods[name].IsConstant() {
+			data.Calls = append(data.Calls, m)
+		} else {
+			data.Transacts = append(data.Transacts, m)
+		}
+	}
+	for _, name := range sortedKeys(parsed.Events) {
+		ev, err := g.event(parsed.Events[name], eventNames[name])
+		if err != nil {
+			return nil, err
+		}
+		data.Events = append(data.Events, ev)
+	}
+	data.Structs = g.sortedStructs()
+
+	var buf bytes.Buffer
+	if err := bindTemplate.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("render binding: %w", err)
+	}
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format binding: %w\n%s", err, buf.String())
+	}
+	return code, nil
+}
+
+// generator accumulates the tuple structs referenced while walking the ABI.
+type generator struct {
+	typeName string
+	structs  map[string]*tmplStruct // keyed by canonical tuple signature + raw name
+	order    []string
+	types    map[string]bool // package-level identifiers already taken
+}
+
+// methodName returns the Go name of method m, unique among used.
+func methodName(m eABI.Method, used map[string]bool) string {
+	name := goName(m.Name)
+	if reservedMethods[name] {
+		name += "Method"
+	}
+	return uniqueName(name, used)
+}
+
+// eventName returns the Go name of event e. Parse<Event> and
+// Filter<Event> must not collide with bound methods.
+func eventName(e eABI.Event, used map[string]bool) string {
+	name := goName(e.Name)
+	for used["Parse"+name] || used["Filter"+name] {
+		name += "Event"
+	}
+	used["Parse"+name], used["Filter"+name] = true, true
+	return name
+}
+
+func (g *generator) method(m eABI.Method, name string) (*tmplMethod, error) {
+	tm := &tmplMethod{
+		Name:     name,
+		Original: m.Name,
+		Sig:      m.Sig,
+		Payable:  m.IsPayable(),
+	}
+	var err error
+	if tm.Inputs, err = g.params(m.Inputs); err != nil {
+		return nil, fmt.Errorf("method %s: %w", m.Sig, err)
+	}
+	if tm.Outputs, err = g.fields(m.Outputs, "Arg"); err != nil {
+		return nil, fmt.Errorf("method %s: %w", m.Sig, err)
+	}
+	if len(tm.Outputs) > 1 {
+		tm.OutputType = g.typeName + name + "Output"
+	}
+	return tm, nil
+}
+
+func (g *generator) event(e eABI.Event, name string) (*tmplEvent, error) {
+	te := &tmplEvent{Name: name, Original: e.Name, Sig: e.Sig}
+	fields, err := g.fields(e.Inputs, "Arg")
+	if err != nil {
+		return nil, fmt.Errorf("event %s: %w", e.Sig, err)
+	}
+	params := make(map[string]bool)
+	for i, input := range e.Inputs {
+		if !input.Indexed {
+			continue
+		}
+		if isHashedTopic(input.Type) {
+			fields[i].Type = "[32]byte"
+		}
+		filter := tmplFilter{Type: fields[i].Type}
+		switch input.Type.T {
+		case eABI.SliceTy, eABI.ArrayTy, eABI.TupleTy:
+			// Hashed composite topics cannot be built from a Go value.
+		default:
+			if input.Type.T == eABI.StringTy || input.Type.T == eABI.BytesTy {
+				filter.Type, _ = g.goType(input.Type)
+			}
+			filter.Param = uniqueName(paramName(input.Name, i), params)
+		}
+		te.Filters = append(te.Filters, filter)
+	}
+	te.Fields = fields
+	return te, nil
+}
+
+// params maps ABI inputs to method parameters.
+func (g *generator) params(args eABI.Arguments) ([]tmplField, error) {
+	used := make(map[string]bool)
+	out := make([]tmplField, len(args))
+	for i, arg := range args {
+		ty, err := g.goType(arg.Type)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = tmplField{Name: uniqueName(paramName(arg.Name, i), used), Type: ty}
+	}
+	return out, nil
+}
+
+// fields maps ABI arguments to exported struct fields, naming unnamed ones
+// prefix0, prefix1, ...
+func (g *generator) fields(args eABI.Arguments, prefix string) ([]tmplField, error) {
+	used := map[string]bool{"Raw": true}
+	out := make([]tmplField, len(args))
+	for i, arg := range args {
+		ty, err := g.goType(arg.Type)
+		if err != nil {
+			return nil, err
+		}
+		name := goName(arg.Name)
+		if name == "" {
+			name = fmt.Sprintf("%s%d", prefix, i)
+		}
+		out[i] = structField(arg.Name, uniqueName(name, used), ty)
+	}
+	return out, nil
+}
+
+// goType returns the Go type expression used by bindings for an ABI type.
+func (g *generator) goType(t eABI.Type) (string, error) {
+	switch t.T {
+	case eABI.IntTy, eABI.UintTy:
+		prefix := "int"
+		if t.T == eABI.UintTy {
+			prefix = "uint"
+		}
+		switch t.Size {
+		case 8, 16, 32, 64:
+			return fmt.Sprintf("%s%d", prefix, t.Size), nil
+		}
+		return "*big.Int", nil
+	case eABI.BoolTy:
+		return "bool", nil
+	case eABI.StringTy:
+		return "string", nil
+	case eABI.AddressTy:
+		return "address.Address", nil
+	case eABI.BytesTy:
+		return "[]byte", nil
+	case eABI.FixedBytesTy:
+		return fmt.Sprintf("[%d]byte", t.Size), nil
+	case eABI.FunctionTy:
+		return "[24]byte", nil
+	case eABI.SliceTy:
+		elem, err := g.goType(*t.Elem)
+		return "[]" + elem, err
+	case eABI.ArrayTy:
+		elem, err := g.goType(*t.Elem)
+		return fmt.Sprintf("[%d]%s", t.Size, elem), err
+	case eABI.TupleTy:
+		return g.tupleStruct(t)
+	}
+	return "", fmt.Errorf("unsupported ABI type %s", t.String())
+}
+
+// tupleStruct registers (once) and returns the struct name for a tuple type.
+// A name already taken by another tuple, the binding type, or an output or
+// event struct gets a numeric suffix.
+func (g *generator) tupleStruct(t eABI.Type) (string, error) {
+	if len(t.TupleElems) == 0 {
+		return "", fmt.Errorf("tuple %s has no components (the ABI must include them)", t.String())
+	}
+	key := t.TupleRawName + t.String()
+	if s, ok := g.structs[key]; ok {
+		return s.Name, nil
+	}
+	name := t.TupleRawName
+	if name == "" {
+		name = fmt.Sprintf("%sTuple%d", g.typeName, len(g.order))
+	}
+	s := &tmplStruct{Name: uniqueName(name, g.types)}
+	g.structs[key] = s
+	g.order = append(g.order, key)
+
+	used := make(map[string]bool)
+	for i, elem := range t.TupleElems {
+		ty, err := g.goType(*elem)
+		if err != nil {
+			return "", err
+		}
+		fieldName := goName(t.TupleRawNames[i])
+		if fieldName == "" {
+			fieldName = fmt.Sprintf("Field%d", i)
+		}
+		s.Fields = append(s.Fields, structField(t.TupleRawNames[i], uniqueName(fieldName, used), ty))
+	}
+	return s.Name, nil
+}
+
+func (g *generator) sortedStructs() []*tmplStruct {
+	out := make([]*tmplStruct, 0, len(g.order))
+	for _, key := range g.order {
+		out = append(out, g.structs[key])
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// structField returns a struct field for the ABI value abiName, tagging it
+// when the Go name no longer matches so decoding still finds it.
+func structField(abiName, name, ty string) tmplField {
+	f := tmplField{Name: name, Type: ty}
+	if abiName != "" && eABI.ToCamelCase(abiName) != name {
+		f.Tag = abiName
+	}
+	return f
+}
+
+// isHashedTopic reports whether an indexed parameter of type t is stored in
+// the log as a Keccak-256 hash rather than its value.
+func isHashedTopic(t eABI.Type) bool {
+	switch t.T {
+	case eABI.StringTy, eABI.BytesTy, eABI.SliceTy, eABI.ArrayTy, eABI.TupleTy:
+		return true
+	}
+	return false
+}
+
+// goName converts an ABI identifier to an exported Go identifier,
+// dropping leading underscores (e.g. "_value" → "Value").
+func goName(name string) string {
+	name = strings.TrimLeft(name, "_")
+	if name == "" {
+		return ""
+	}
+	return eABI.ToCamelCase(name)
+}
+
+// paramName converts an ABI parameter name into an unexported Go
+// identifier, falling back to argN for unnamed or conflicting names.
+func paramName(name string, index int) string {
+	camel := goName(name)
+	if camel == "" {
+		return fmt.Sprintf("arg%d", index)
+	}
+	p := strings.ToLower(camel[:1]) + camel[1:]
+	if token.IsKeyword(p) || reservedParams[p] {
+		p += "_"
+	}
+	return p
+}
+
+// uniqueName returns name, or name with a numeric suffix if it is already
+// taken, and marks the result as used.
+func uniqueName(name string, used map[string]bool) string {
+	candidate := name
+	for i := 0; used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	used[candidate] = true
+	return candidate
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,216 @@
+package bind
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// marketABI exercises tuples, nested tuple arrays, overloads, trcToken,
+// reserved identifiers and hashed indexed topics.
+const marketABI = `[
+ {"type":"function","name":"getOrder","stateMutability":"view",
+  "inputs":[{"name":"id","type":"uint256"}],
+  "outputs":[
+   {"name":"order","type":"tuple","internalType":"struct Market.Order","components":[
+    {"name":"maker","type":"address"},
+    {"name":"amounts","type":"uint256[]"},
+    {"name":"legs","type":"tuple[]","internalType":"struct Market.Leg[]","components":[
+     {"name":"token","type":"address"},{"name":"qty","type":"uint64"}]}]},
+   {"name":"active","type":"bool"}]},
+ {"type":"function","name":"submit","stateMutability":"payable",
+  "inputs":[
+   {"name":"orders","type":"tuple[]","internalType":"struct Market.Order[]","components":[
+    {"name":"maker","type":"address"},
+    {"name":"amounts","type":"uint256[]"},
+    {"name":"legs","type":"tuple[]","internalType":"struct Market.Leg[]","components":[
+     {"name":"token","type":"address"},{"name":"qty","type":"uint64"}]}]},
+   {"name":"tokenId","type":"trcToken"}],
+  "outputs":[]},
+ {"type":"function","name":"transfer","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"}],"outputs":[]},
+ {"type":"function","name":"transfer","stateMutability":"nonpayable","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[]},
+ {"type":"function","name":"address","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"address"},{"name":"","type":"bytes32"}]},
+ {"type":"function","name":"ping","stateMutability":"view","inputs":[{"name":"type","type":"string"}],"outputs":[]},
+ {"type":"event","name":"Filled","anonymous":false,"inputs":[
+  {"name":"tag","type":"string","indexed":true},
+  {"name":"legs","type":"uint256[]","indexed":true},
+  {"name":"maker","type":"address","indexed":true},
+  {"name":"data","type":"bytes","indexed":false}]},
+ {"type":"event","name":"Noted","anonymous":false,"inputs":[
+  {"name":"raw","type":"string","indexed":false},
+  {"name":"ok","type":"bool","indexed":false}]}
+]`
+
+// checkGenerated parses and type-checks a generated binding against the
+// compiled packages it imports.
+func checkGenerated(t *testing.T, code []byte) {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "binding.go", code, parser.AllErrors)
+	require.NoError(t, err, string(code))
+
+	conf := types.Config{Importer: importer.ForCompiler(fset, "gc", exportData(t, file))}
+	_, err = conf.Check(file.Name.Name, fset, []*ast.File{file}, nil)
+	require.NoError(t, err, string(code))
+}
+
+// exportData returns a lookup of the compiler export data of the packages
+// file imports and their dependencies, as built by the go command.
+func exportData(t *testing.T, file *ast.File) func(string) (io.ReadCloser, error) {
+	t.Helper()
+	args := []string{"list", "-export", "-deps", "-f", "{{.ImportPath}}\t{{.Export}}"}
+	for _, imp := range file.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		require.NoError(t, err)
+		args = append(args, path)
+	}
+	out, err := exec.Command("go", args...).Output()
+	require.NoError(t, err)
+
+	exports := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if path, export, ok := strings.Cut(line, "\t"); ok && export != "" {
+			exports[path] = export
+		}
+	}
+	return func(path string) (io.ReadCloser, error) {
+		export, ok := exports[path]
+		if !ok {
+			return nil, fmt.Errorf("no export data for %s", path)
+		}
+		return os.Open(export)
+	}
+}
+
+func TestGenerateTestToken(t *testing.T) {
+	abiJSON, err := os.ReadFile("../../../testdata/contracts/TestToken.abi")
+	require.NoError(t, err)
+
+	code, err := Generate(string(abiJSON), "testtoken", "TestToken")
+	require.NoError(t, err)
+	checkGenerated(t, code)
+
+	src := string(code)
+	for _, want := range []string{
+		"// Code generated by tronctl contract bind. DO NOT EDIT.",
+		"package testtoken",
+		"func NewTestToken(client contract.Client, contractAddress string) (*TestToken, error)",
+		"func (_TestToken *TestToken) BalanceOf(ctx context.Context, arg0 address.Address) (*big.Int, error)",
+		"func (_TestToken *TestToken) Decimals(ctx context.Context) (uint8, error)",
+		"func (_TestToken *TestToken) Name(ctx context.Context) (string, error)",
+		"func (_TestToken *TestToken) Transfer(from string, to address.Address, value *big.Int, opts ...contract.Option) *contract.ContractCall",
+		"func (_TestToken *TestToken) TransferFrom(from string, from_ address.Address, to address.Address, value *big.Int, opts ...contract.Option) *contract.ContractCall",
+		"type TestTokenTransfer struct",
+		"func (_TestToken *TestToken) ParseTransfer(log *core.TransactionInfo_Log) (*TestTokenTransfer, error)",
+		"func (_TestToken *TestToken) FilterTransfer(logs []*core.TransactionInfo_Log, from_ []address.Address, to []address.Address) ([]*TestTokenTransfer, error)",
+	} {
+		assert.Contains(t, src, want)
+	}
+}
+
+func TestGenerateComplexABI(t *testing.T) {
+	code, err := Generate(marketABI, "market", "Market")
+	require.NoError(t, err)
+	checkGenerated(t, code)
+
+	src := string(code)
+	for _, want := range []string{
+		"type MarketOrder struct",
+		"Legs    []MarketLeg",
+		"type MarketLeg struct",
+		"func (_Market *Market) GetOrder(ctx context.Context, id *big.Int) (*MarketGetOrderOutput, error)",
+		"Order  MarketOrder",
+		// trcToken is encoded as uint256.
+		"func (_Market *Market) Submit(from string, orders []MarketOrder, tokenId *big.Int, opts ...contract.Option) *contract.ContractCall",
+		"The method is payable",
+		// Overloads get a numeric suffix.
+		"func (_Market *Market) Transfer(from string, to address.Address, opts ...contract.Option)",
+		"func (_Market *Market) Transfer0(from string, to address.Address, amount *big.Int, opts ...contract.Option)",
+		`Transact(from, "transfer0", to, amount)`,
+		// Names clashing with binding helpers or Go keywords are renamed.
+		"func (_Market *Market) AddressMethod(ctx context.Context) (*MarketAddressMethodOutput, error)",
+		"func (_Market *Market) Ping(ctx context.Context, type_ string) error",
+		// Hashed indexed topics decode to [32]byte; array topics are not filterable.
+		"Tag   [32]byte",
+		"func (_Market *Market) FilterFilled(logs []*core.TransactionInfo_Log, tag []string, maker []address.Address)",
+		// Renamed fields keep their ABI name in a tag for decoding.
+		"Raw0 string `abi:\"raw\"`",
+	} {
+		assert.Contains(t, src, want)
+	}
+}
+
+func TestGenerateStructNameCollisions(t *testing.T) {
+	abiJSON := `[
+ {"type":"function","name":"get","stateMutability":"view","inputs":[],"outputs":[
+  {"name":"a","type":"tuple","internalType":"struct Market.GetOutput","components":[{"name":"x","type":"uint256"}]},
+  {"name":"b","type":"bool"}]},
+ {"type":"function","name":"fill","stateMutability":"nonpayable","outputs":[],"inputs":[
+  {"name":"f","type":"tuple","internalType":"struct Market.Filled","components":[{"name":"y","type":"uint256"}]}]},
+ {"type":"function","name":"place","stateMutability":"nonpayable","outputs":[],"inputs":[
+  {"name":"o","type":"tuple","internalType":"struct Order","components":[{"name":"maker","type":"address"}]}]},
+ {"type":"function","name":"cancel","stateMutability":"nonpayable","outputs":[],"inputs":[
+  {"name":"o","type":"tuple","internalType":"struct Order","components":[{"name":"id","type":"uint256"}]}]},
+ {"type":"event","name":"Filled","anonymous":false,"inputs":[{"name":"amount","type":"uint256","indexed":false}]}
+]`
+	code, err := Generate(abiJSON, "market", "Market")
+	require.NoError(t, err)
+	checkGenerated(t, code)
+
+	src := string(code)
+	for _, want := range []string{
+		// Generated output and event structs keep their names.
+		"type MarketGetOutput struct",
+		"type MarketFilled struct",
+		// Tuples clashing with them, or with each other, get a suffix.
+		"type MarketGetOutput0 struct",
+		"A MarketGetOutput0",
+		"type MarketFilled0 struct",
+		"func (_Market *Market) Fill(from string, f MarketFilled0,",
+		"type Order struct",
+		"type Order0 struct",
+		"func (_Market *Market) Cancel(from string, o Order,",
+		"func (_Market *Market) Place(from string, o Order0,",
+	} {
+		assert.Contains(t, src, want)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		abiJSON  string
+		pkg      string
+		typeName string
+	}{
+		{"invalid package", `[]`, "my-pkg", "Token"},
+		{"unexported type", `[]`, "token", "token"},
+		{"invalid JSON", `{`, "token", "Token"},
+		{"tuple without components", `[{"type":"function","name":"f","inputs":[{"name":"t","type":"tuple"}],"outputs":[]}]`, "token", "Token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Generate(tt.abiJSON, tt.pkg, tt.typeName)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestGenerateEscapesBackticks(t *testing.T) {
+	abiJSON := "[{\"type\":\"function\",\"name\":\"f\",\"inputs\":[],\"outputs\":[],\"stateMutability\":\"view\",\"note\":\"`x`\"}]"
+	code, err := Generate(abiJSON, "token", "Token")
+	require.NoError(t, err)
+	checkGenerated(t, code)
+}
@@ -0,0 +1,150 @@
+package bind
+
+import "text/template"
+
+// bindTemplate renders a binding from tmplData. The output is passed
+// through go/format, so whitespace here only needs to be valid Go.
+var bindTemplate = template.Must(template.New("binding").Parse(`// Code generated by tronctl contract bind. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/contract"
+	"github.com/fbsobreira/gotron-sdk/pkg/contract/bind"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = context.Background
+	_ = big.NewInt
+	_ = address.Address{}
+	_ = core.TransactionInfo_Log{}
+)
+
+// {{.Type}}ABI is the input ABI used to generate the binding from.
+const {{.Type}}ABI = ` + "`" + `{{.InputABI}}` + "`" + `
+
+{{range .Structs}}
+// {{.Name}} mirrors a tuple type declared in the {{$.Type}} ABI.
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}}{{if .Tag}} ` + "`" + `abi:"{{.Tag}}"` + "`" + `{{end}}
+{{- end}}
+}
+{{end}}
+
+// {{.Type}} is a typed binding for the {{.Type}} contract.
+type {{.Type}} struct {
+	contract *bind.BoundContract
+}
+
+// New{{.Type}} creates a {{.Type}} binding for the contract deployed at
+// contractAddress.
+func New{{.Type}}(client contract.Client, contractAddress string) (*{{.Type}}, error) {
+	c, err := bind.NewBoundContract(client, contractAddress, {{.Type}}ABI)
+	if err != nil {
+		return nil, err
+	}
+	return &{{.Type}}{contract: c}, nil
+}
+
+// Address returns the base58 address of the bound contract.
+func (_{{$.Type}} *{{.Type}}) Address() string {
+	return _{{$.Type}}.contract.Address()
+}
+
+// Contract returns the underlying generic binding.
+func (_{{$.Type}} *{{.Type}}) Contract() *bind.BoundContract {
+	return _{{$.Type}}.contract
+}
+
+{{range .Calls}}
+{{- if .OutputType}}
+// {{.OutputType}} holds the outputs of {{.Sig}}.
+type {{.OutputType}} struct {
+{{- range .Outputs}}
+	{{.Name}} {{.Type}}{{if .Tag}} ` + "`" + `abi:"{{.Tag}}"` + "`" + `{{end}}
+{{- end}}
+}
+{{end}}
+// {{.Name}} calls the read-only method {{.Sig}}.
+func (_{{$.Type}} *{{$.Type}}) {{.Name}}(ctx context.Context{{range .Inputs}}, {{.Name}} {{.Type}}{{end}}) (
+{{- if .OutputType}}*{{.OutputType}}, {{else}}{{range .Outputs}}{{.Type}}, {{end}}{{end}}error) {
+{{- if .OutputType}}
+	out := new({{.OutputType}})
+	err := _{{$.Type}}.contract.Call(ctx, out, "{{.Original}}"{{range .Inputs}}, {{.Name}}{{end}})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+{{- else if .Outputs}}
+	var out {{(index .Outputs 0).Type}}
+	err := _{{$.Type}}.contract.Call(ctx, &out, "{{.Original}}"{{range .Inputs}}, {{.Name}}{{end}})
+	return out, err
+{{- else}}
+	return _{{$.Type}}.contract.Call(ctx, nil, "{{.Original}}"{{range .Inputs}}, {{.Name}}{{end}})
+{{- end}}
+}
+{{end}}
+
+{{range .Transacts}}
+// {{.Name}} returns a ContractCall for {{.Sig}} sent from the
+// from address. Encoding errors surface when a terminal (Build, Send, ...)
+// is invoked.
+{{- if .Payable}}
+//
+// The method is payable: attach TRX with contract.WithCallValue.
+{{- end}}
+func (_{{$.Type}} *{{$.Type}}) {{.Name}}(from string{{range .Inputs}}, {{.Name}} {{.Type}}{{end}}, opts ...contract.Option) *contract.ContractCall {
+	return _{{$.Type}}.contract.Transact(from, "{{.Original}}"{{range .Inputs}}, {{.Name}}{{end}}).Apply(opts...)
+}
+{{end}}
+
+{{range .Events}}
+// {{$.Type}}{{.Name}} holds a decoded {{.Original}} event emitted by the {{$.Type}} contract.
+type {{$.Type}}{{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}}{{if .Tag}} ` + "`" + `abi:"{{.Tag}}"` + "`" + `{{end}}
+{{- end}}
+	Raw *core.TransactionInfo_Log // Log carrying the event
+}
+
+// Parse{{.Name}} decodes a log carrying the {{.Sig}} event.
+func (_{{$.Type}} *{{$.Type}}) Parse{{.Name}}(log *core.TransactionInfo_Log) (*{{$.Type}}{{.Name}}, error) {
+	event := new({{$.Type}}{{.Name}})
+	if err := _{{$.Type}}.contract.UnpackLog(event, "{{.Original}}", log); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// Filter{{.Name}} returns the {{.Sig}} events among logs
+// that were emitted by this contract and match the given indexed values.
+// Empty filters match all values.
+func (_{{$.Type}} *{{$.Type}}) Filter{{.Name}}(logs []*core.TransactionInfo_Log{{range .Filters}}{{if .Param}}, {{.Param}} []{{.Type}}{{end}}{{end}}) ([]*{{$.Type}}{{.Name}}, error) {
+	query := [][]interface{}{
+	{{- range .Filters}}
+		{{if .Param}}bind.Rule({{.Param}}){{else}}nil{{end}},
+	{{- end}}
+	}
+	matched, err := _{{$.Type}}.contract.FilterLogs(logs, "{{.Original}}", query...)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*{{$.Type}}{{.Name}}, 0, len(matched))
+	for _, log := range matched {
+		event, err := _{{$.Type}}.Parse{{.Name}}(log)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+{{end}}
+`))
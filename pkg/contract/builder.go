@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/abi"
 	"github.com/fbsobreira/gotron-sdk/pkg/client"
 	"github.com/fbsobreira/gotron-sdk/pkg/client/transaction"
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
@@ -28,8 +31,11 @@ type ContractCall struct {
 	from            string
 	method          string
 	jsonParams      string
-	data            []byte // pre-packed ABI data (alternative to method+jsonParams)
-	abiJSON         string // parsed ABI (for future use)
+	data            []byte        // pre-packed ABI data (alternative to method+jsonParams)
+	args            []interface{} // native Go arguments, packed against the ABI
+	hasArgs         bool
+	abiJSON         string    // JSON ABI used to encode Args and decode results
+	abi             *eABI.ABI // parsed (or fetched) ABI, resolved lazily
 	cfg             callConfig
 	// err holds deferred validation errors that surface at any terminal call
 	// (Call, Build, Send, etc.).
@@ -106,9 +112,27 @@ func (c *ContractCall) WithData(data []byte) *ContractCall {
 	return c
 }
 
-// WithABI stores the ABI JSON for future use (e.g. result decoding).
+// Args sets the method arguments as native Go values: address.Address,
+// *big.Int and Go integers, bool, string, []byte and [N]byte, structs for
+// tuples, and slices or arrays of those. They are packed against the
+// contract ABI with full ABI v2 semantics, so nested tuples and dynamic
+// arrays of tuples are supported (see abi.ConvertValue).
+//
+// The ABI comes from WithABI or, if unset, is fetched from the network when
+// the client implements ABIFetcher. Method must name the function by name or
+// signature. Args replaces Params; WithData takes precedence over both.
+// Encoding errors surface at the terminal operation.
+func (c *ContractCall) Args(values ...interface{}) *ContractCall {
+	c.args = values
+	c.hasArgs = true
+	return c
+}
+
+// WithABI sets the contract's JSON ABI, used to encode Args and to decode
+// results in CallInto.
 func (c *ContractCall) WithABI(abiJSON string) *ContractCall {
 	c.abiJSON = abiJSON
+	c.abi = nil
 	return c
 }
 
@@ -155,18 +179,36 @@ func (c *ContractCall) fromOrZero() string {
 	return zeroAddress
 }
 
-// Call executes a constant (read-only) contract call and returns the raw results.
+// Call executes a constant (read-only) contract call and returns the raw
+// results. If the contract reverts, the error is a *RevertError decoded
+// against the contract ABI when one is available.
 func (c *ContractCall) Call(ctx context.Context) (*CallResult, error) {
 	if c.err != nil {
 		return nil, c.err
 	}
-	var (
-		tx  *api.TransactionExtention
-		err error
-	)
+	data, err := c.callData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := c.triggerConstant(ctx, c.fromOrZero(), data)
+	if err != nil {
+		return nil, err
+	}
+	if revertData, message, reverted := constantRevert(tx); reverted {
+		return nil, c.revertError(ctx, revertData, message)
+	}
 
-	from := c.fromOrZero()
+	result := &CallResult{
+		RawResults: tx.GetConstantResult(),
+		EnergyUsed: tx.GetEnergyUsed(),
+	}
+
+	return result, nil
+}
 
+// triggerConstant runs the call as a constant call from the given address,
+// using data when set and the method+params path otherwise.
+func (c *ContractCall) triggerConstant(ctx context.Context, from string, data []byte) (*api.TransactionExtention, error) {
 	var opts []client.ConstantCallOption
 	if c.cfg.callValue > 0 {
 		opts = append(opts, client.WithCallValue(c.cfg.callValue))
@@ -179,21 +221,120 @@ func (c *ContractCall) Call(ctx context.Context) (*CallResult, error) {
 		opts = append(opts, tokenOpt)
 	}
 
-	if len(c.data) > 0 {
-		tx, err = c.client.TriggerConstantContractWithDataCtx(ctx, from, c.contractAddress, c.data, opts...)
-	} else {
-		tx, err = c.client.TriggerConstantContractCtx(ctx, from, c.contractAddress, c.method, c.jsonParams, opts...)
+	if len(data) > 0 {
+		return c.client.TriggerConstantContractWithDataCtx(ctx, from, c.contractAddress, data, opts...)
+	}
+	return c.client.TriggerConstantContractCtx(ctx, from, c.contractAddress, c.method, c.jsonParams, opts...)
+}
+
+// CallInto executes a constant call and decodes its return value into out,
+// using the ABI set with WithABI. The method is taken from Method (a name or
+// signature) or, when WithData is used, from the 4-byte selector of the data.
+// See CallResult.Decode for how outputs map onto Go values.
+func (c *ContractCall) CallInto(ctx context.Context, out interface{}) error {
+	if c.err != nil {
+		return c.err
+	}
+	m, err := c.abiMethod(ctx)
+	if err != nil {
+		return err
 	}
+	result, err := c.Call(ctx)
+	if err != nil {
+		return err
+	}
+	return result.decode(m, out)
+}
+
+// abiMethod resolves the called method in the contract ABI.
+func (c *ContractCall) abiMethod(ctx context.Context) (eABI.Method, error) {
+	parsed, err := c.resolveABI(ctx)
+	if err != nil {
+		return eABI.Method{}, err
+	}
+	if len(c.data) >= 4 {
+		m, err := parsed.MethodById(c.data[:4])
+		if err != nil {
+			return eABI.Method{}, err
+		}
+		return *m, nil
+	}
+	if c.method == "" {
+		return eABI.Method{}, ErrNoMethod
+	}
+	return abi.FindMethod(*parsed, c.method)
+}
+
+// resolveABI returns the parsed contract ABI, from WithABI or fetched from
+// the network, caching the result on the builder.
+func (c *ContractCall) resolveABI(ctx context.Context) (*eABI.ABI, error) {
+	if c.abi != nil {
+		return c.abi, nil
+	}
+	abiJSON := c.abiJSON
+	if abiJSON == "" {
+		fetcher, ok := c.client.(ABIFetcher)
+		if !ok {
+			return nil, ErrNoABI
+		}
+		contractABI, err := fetcher.GetContractABIResolvedCtx(ctx, c.contractAddress)
+		if err != nil {
+			return nil, fmt.Errorf("fetch ABI: %w", err)
+		}
+		if abiJSON, err = abi.ABIToJSON(contractABI); err != nil {
+			return nil, err
+		}
+	}
+	parsed, err := abi.ParseABI(abiJSON)
 	if err != nil {
 		return nil, err
 	}
+	c.abi = &parsed
+	return c.abi, nil
+}
 
-	result := &CallResult{
-		RawResults: tx.GetConstantResult(),
-		EnergyUsed: tx.GetEnergyUsed(),
+// callData returns the ABI-encoded call data: the WithData payload, or
+// Method packed with Args. It returns nil when the method+Params path is
+// used instead.
+func (c *ContractCall) callData(ctx context.Context) ([]byte, error) {
+	if len(c.data) > 0 || !c.hasArgs {
+		return c.data, nil
 	}
+	m, err := c.abiMethod(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("encode arguments: %w", err)
+	}
+	packed, err := abi.PackArgs(m.Inputs, c.args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", m.Sig, err)
+	}
+	return append(append([]byte{}, m.ID...), packed...), nil
+}
 
-	return result, nil
+// ContractAddress returns the address of the called contract.
+func (c *ContractCall) ContractAddress() string {
+	return c.contractAddress
+}
+
+// CallData returns the ABI-encoded call data (selector followed by the
+// arguments) that the call sends. Method and Params are encoded locally when
+// neither WithData nor Args is used.
+func (c *ContractCall) CallData(ctx context.Context) ([]byte, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	data, err := c.callData(ctx)
+	if err != nil || len(data) > 0 {
+		return data, err
+	}
+	if c.method == "" {
+		return nil, ErrNoMethod
+	}
+	param, err := abi.LoadFromJSONWithMethod(c.method, c.jsonParams)
+	if err != nil {
+		return nil, err
+	}
+	return abi.Pack(c.method, param)
 }
 
 // EstimateEnergy returns the estimated energy required for the contract call.
@@ -206,14 +347,15 @@ func (c *ContractCall) EstimateEnergy(ctx context.Context) (int64, error) {
 		return 0, fmt.Errorf("energy estimation: %w", ErrNoFromAddress)
 	}
 
-	var (
-		estimate *api.EstimateEnergyMessage
-		err      error
-	)
+	data, err := c.callData(ctx)
+	if err != nil {
+		return 0, err
+	}
 
-	if len(c.data) > 0 {
+	var estimate *api.EstimateEnergyMessage
+	if len(data) > 0 {
 		estimate, err = c.client.EstimateEnergyWithDataCtx(
-			ctx, c.from, c.contractAddress, c.data,
+			ctx, c.from, c.contractAddress, data,
 			c.cfg.callValue, c.cfg.tokenID, c.cfg.tokenAmount,
 		)
 	} else {
@@ -224,6 +366,13 @@ func (c *ContractCall) EstimateEnergy(ctx context.Context) (int64, error) {
 		)
 	}
 	if err != nil {
+		// The estimate response carries no revert data, so replay the call
+		// as a constant call to decode why it reverted.
+		if strings.Contains(err.Error(), revertMarker) {
+			if rerr := c.probeRevert(ctx, data); rerr != nil {
+				return 0, rerr
+			}
+		}
 		return 0, err
 	}
 
@@ -251,20 +400,25 @@ func (c *ContractCall) Build(ctx context.Context) (*api.TransactionExtention, er
 		return nil, fmt.Errorf("state-changing call: %w", ErrNoFromAddress)
 	}
 
-	var (
-		tx  *api.TransactionExtention
-		err error
-	)
+	data, err := c.callData(ctx)
+	if err != nil {
+		return nil, err
+	}
+	feeLimit, err := c.feeLimit(ctx, data)
+	if err != nil {
+		return nil, err
+	}
 
-	if len(c.data) > 0 {
+	var tx *api.TransactionExtention
+	if len(data) > 0 {
 		tx, err = c.client.TriggerContractWithDataCtx(
-			ctx, c.from, c.contractAddress, c.data,
-			c.cfg.feeLimit, c.cfg.callValue, c.cfg.tokenID, c.cfg.tokenAmount,
+			ctx, c.from, c.contractAddress, data,
+			feeLimit, c.cfg.callValue, c.cfg.tokenID, c.cfg.tokenAmount,
 		)
 	} else {
 		tx, err = c.client.TriggerContractCtx(
 			ctx, c.from, c.contractAddress, c.method, c.jsonParams,
-			c.cfg.feeLimit, c.cfg.callValue, c.cfg.tokenID, c.cfg.tokenAmount,
+			feeLimit, c.cfg.callValue, c.cfg.tokenID, c.cfg.tokenAmount,
 		)
 	}
 	if err != nil {
@@ -281,6 +435,30 @@ func (c *ContractCall) Build(ctx context.Context) (*api.TransactionExtention, er
 	return tx, nil
 }
 
+// feeLimit returns the configured fee limit, or the estimated one when
+// AutoFeeLimit is set.
+func (c *ContractCall) feeLimit(ctx context.Context, data []byte) (int64, error) {
+	if c.cfg.feeLimitMargin == nil {
+		return c.cfg.feeLimit, nil
+	}
+	estimator, ok := c.client.(FeeLimitEstimator)
+	if !ok {
+		return 0, fmt.Errorf("auto fee limit: client does not implement FeeLimitEstimator")
+	}
+	if len(data) == 0 {
+		var err error
+		if data, err = c.CallData(ctx); err != nil {
+			return 0, err
+		}
+	}
+	est, err := estimator.EstimateFeeLimitCtx(ctx, c.from, c.contractAddress, data,
+		c.cfg.callValue, c.cfg.tokenID, c.cfg.tokenAmount, *c.cfg.feeLimitMargin, c.cfg.feeLimit)
+	if err != nil {
+		return 0, fmt.Errorf("auto fee limit: %w", err)
+	}
+	return est.FeeLimit, nil
+}
+
 // Sign builds and signs the transaction without broadcasting. Returns the
 // signed transaction ready for deferred broadcast or inspection.
 func (c *ContractCall) Sign(ctx context.Context, s signer.Signer) (*core.Transaction, error) {
@@ -302,10 +480,19 @@ func (c *ContractCall) Send(ctx context.Context, s signer.Signer) (*Receipt, err
 
 // SendAndConfirm is like Send but additionally polls for transaction
 // confirmation on-chain. It relies on the context for timeout control.
+// If the transaction is confirmed but reverted, the receipt is returned
+// together with a *RevertError.
 func (c *ContractCall) SendAndConfirm(ctx context.Context, s signer.Signer) (*Receipt, error) {
 	tx, err := c.Build(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return txcore.SendAndConfirm(ctx, c.client, s, tx.GetTransaction(), c.cfg.pollInterval)
+	receipt, err := txcore.SendAndConfirm(ctx, c.client, s, tx.GetTransaction(), c.cfg.pollInterval)
+	if err != nil || !receipt.Confirmed || receipt.Error == "" {
+		return receipt, err
+	}
+	if len(receipt.Result) > 0 || strings.Contains(receipt.Error, revertMarker) {
+		return receipt, c.revertError(ctx, receipt.Result, receipt.Error)
+	}
+	return receipt, nil
 }
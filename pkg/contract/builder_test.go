@@ -3,8 +3,12 @@ package contract
 import (
 	"context"
 	"errors"
+	"math/big"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/abi"
 	"github.com/fbsobreira/gotron-sdk/pkg/address"
 	"github.com/fbsobreira/gotron-sdk/pkg/client"
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
@@ -260,6 +264,50 @@ func TestOptionsApplied(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// estimatingClient adds fee limit estimation to mockClient.
+type estimatingClient struct {
+	*mockClient
+	estimateFeeLimitCtxFunc func(ctx context.Context, from, contractAddress string, data []byte, tAmount int64, tTokenID string, tTokenAmount int64, margin float64, ceiling int64) (*client.FeeLimitEstimate, error)
+}
+
+func (m *estimatingClient) EstimateFeeLimitCtx(ctx context.Context, from, contractAddress string, data []byte, tAmount int64, tTokenID string, tTokenAmount int64, margin float64, ceiling int64) (*client.FeeLimitEstimate, error) {
+	return m.estimateFeeLimitCtxFunc(ctx, from, contractAddress, data, tAmount, tTokenID, tTokenAmount, margin, ceiling)
+}
+
+func TestAutoFeeLimit(t *testing.T) {
+	mc := &estimatingClient{
+		mockClient: &mockClient{
+			triggerContractCtxFunc: func(_ context.Context, _, _, _, _ string, feeLimit, _ int64, _ string, _ int64) (*api.TransactionExtention, error) {
+				assert.Equal(t, int64(2_520_000), feeLimit)
+				return &api.TransactionExtention{Transaction: &core.Transaction{RawData: &core.TransactionRaw{}}}, nil
+			},
+		},
+		estimateFeeLimitCtxFunc: func(_ context.Context, from, _ string, data []byte, tAmount int64, _ string, _ int64, margin float64, ceiling int64) (*client.FeeLimitEstimate, error) {
+			assert.Equal(t, "TFrom", from)
+			assert.Equal(t, abi.Signature("test()"), data)
+			assert.Equal(t, int64(500), tAmount)
+			assert.Equal(t, 0.2, margin)
+			assert.Equal(t, int64(100_000_000), ceiling)
+			return &client.FeeLimitEstimate{FeeLimit: 2_520_000}, nil
+		},
+	}
+
+	_, err := New(mc, "TContract").
+		Method("test()").
+		From("TFrom").
+		Apply(AutoFeeLimit(0.2), WithFeeLimit(100_000_000), WithCallValue(500)).
+		Build(context.Background())
+	require.NoError(t, err)
+
+	// Clients without estimation support are rejected.
+	_, err = New(mc.mockClient, "TContract").
+		Method("test()").
+		From("TFrom").
+		Apply(AutoFeeLimit(0.2)).
+		Build(context.Background())
+	assert.ErrorContains(t, err, "FeeLimitEstimator")
+}
+
 func TestPermissionIDApplied(t *testing.T) {
 	mc := &mockClient{
 		triggerContractCtxFunc: func(_ context.Context, _, _, _, _ string, _, _ int64, _ string, _ int64) (*api.TransactionExtention, error) {
@@ -811,3 +859,326 @@ func TestFluentWithData_PermissionAndFeeLimit(t *testing.T) {
 		assert.Equal(t, int32(3), c.PermissionId)
 	}
 }
+
+const balanceABI = `[
+ {"type":"function","name":"balanceOf","stateMutability":"view",
+  "inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"balance","type":"uint256"}]},
+ {"type":"function","name":"info","stateMutability":"view","inputs":[],
+  "outputs":[{"name":"owner","type":"address"},{"name":"decimals","type":"uint8"}]}
+]`
+
+func TestCallInto(t *testing.T) {
+	owner, err := address.Base58ToAddress("TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1")
+	require.NoError(t, err)
+	infoResult := append(common.LeftPadBytes(owner.Bytes()[1:], 32), common.LeftPadBytes([]byte{6}, 32)...)
+
+	mc := &mockClient{
+		triggerConstantContractCtxFunc: func(_ context.Context, _, _, method, _ string, _ ...client.ConstantCallOption) (*api.TransactionExtention, error) {
+			assert.Equal(t, "info()", method)
+			return &api.TransactionExtention{ConstantResult: [][]byte{infoResult}}, nil
+		},
+		triggerConstantContractWithDataCtxFunc: func(_ context.Context, _, _ string, _ []byte, _ ...client.ConstantCallOption) (*api.TransactionExtention, error) {
+			return &api.TransactionExtention{ConstantResult: [][]byte{common.LeftPadBytes([]byte{0x03, 0xe8}, 32)}}, nil
+		},
+	}
+
+	var info struct {
+		Owner    address.Address
+		Decimals uint8 `abi:"decimals"`
+	}
+	err = New(mc, "TContract").WithABI(balanceABI).Method("info()").CallInto(context.Background(), &info)
+	require.NoError(t, err)
+	assert.Equal(t, owner, info.Owner)
+	assert.Equal(t, uint8(6), info.Decimals)
+
+	// With pre-packed data the method is resolved from the selector.
+	var balance uint64
+	err = New(mc, "TContract").WithABI(balanceABI).
+		WithData(append([]byte{0x70, 0xa0, 0x82, 0x31}, make([]byte, 32)...)).
+		CallInto(context.Background(), &balance)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1000), balance)
+}
+
+func TestCallIntoErrors(t *testing.T) {
+	mc := &mockClient{
+		triggerConstantContractCtxFunc: func(context.Context, string, string, string, string, ...client.ConstantCallOption) (*api.TransactionExtention, error) {
+			return &api.TransactionExtention{}, nil
+		},
+	}
+	var out uint64
+
+	err := New(mc, "TContract").Method("info()").CallInto(context.Background(), &out)
+	assert.ErrorIs(t, err, ErrNoABI)
+
+	err = New(mc, "TContract").WithABI(balanceABI).CallInto(context.Background(), &out)
+	assert.ErrorIs(t, err, ErrNoMethod)
+
+	err = New(mc, "TContract").WithABI(balanceABI).Method("balanceOf(address)").CallInto(context.Background(), &out)
+	assert.ErrorIs(t, err, ErrEmptyResult)
+
+	err = New(mc, "TContract").WithABI(balanceABI).Method("missing()").CallInto(context.Background(), &out)
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestCallResultDecode(t *testing.T) {
+	result := &CallResult{RawResults: [][]byte{common.LeftPadBytes([]byte{0x2a}, 32)}}
+
+	var balance *big.Int
+	require.NoError(t, result.Decode(balanceABI, "balanceOf", &balance))
+	assert.Equal(t, int64(42), balance.Int64())
+
+	var wrapped struct{ Balance string }
+	require.NoError(t, result.Decode(balanceABI, "balanceOf(address)", &wrapped))
+	assert.Equal(t, "42", wrapped.Balance)
+
+	assert.Error(t, result.Decode("not json", "balanceOf", &balance))
+}
+
+var _ ABIFetcher = (*client.GrpcClient)(nil)
+
+const ordersABI = `[{"type":"function","name":"submit","stateMutability":"nonpayable",
+ "inputs":[
+  {"name":"orders","type":"tuple[]","components":[
+   {"name":"maker","type":"address"},
+   {"name":"legs","type":"tuple[]","components":[
+    {"name":"token","type":"address"},{"name":"qty","type":"uint64"}]}]},
+  {"name":"tag","type":"bytes32"}],
+ "outputs":[]}]`
+
+// fetchingClient is a mockClient that also serves an on-chain ABI.
+type fetchingClient struct {
+	mockClient
+	contractABI *core.SmartContract_ABI
+	fetches     int
+}
+
+func (f *fetchingClient) GetContractABIResolvedCtx(_ context.Context, _ string) (*core.SmartContract_ABI, error) {
+	f.fetches++
+	return f.contractABI, nil
+}
+
+func TestArgsPacksNestedTuples(t *testing.T) {
+	owner, err := address.Base58ToAddress("TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1")
+	require.NoError(t, err)
+
+	var gotData []byte
+	mc := &mockClient{
+		triggerContractWithDataCtxFunc: func(_ context.Context, _, _ string, data []byte, _, _ int64, _ string, _ int64) (*api.TransactionExtention, error) {
+			gotData = data
+			return newTestTxExt(), nil
+		},
+	}
+
+	type leg struct {
+		Token    address.Address
+		Quantity uint64 `abi:"qty"`
+	}
+	type order struct {
+		Maker address.Address
+		Legs  []leg
+	}
+	var tag [32]byte
+	tag[0] = 0xab
+	_, err = New(mc, "TContract").From("TOwner").WithABI(ordersABI).
+		Method("submit").
+		Args([]order{{Maker: owner, Legs: []leg{{Token: owner, Quantity: 3}}}}, tag).
+		Build(context.Background())
+	require.NoError(t, err)
+
+	// Pack the same call with go-ethereum types to compare.
+	type ethLeg struct {
+		Token common.Address
+		Qty   uint64
+	}
+	type ethOrder struct {
+		Maker common.Address
+		Legs  []ethLeg
+	}
+	parsed, err := abi.ParseABI(ordersABI)
+	require.NoError(t, err)
+	evm := common.BytesToAddress(owner.Bytes()[1:])
+	want, err := parsed.Pack("submit", []ethOrder{{Maker: evm, Legs: []ethLeg{{Token: evm, Qty: 3}}}}, tag)
+	require.NoError(t, err)
+	assert.Equal(t, want, gotData)
+}
+
+func TestArgsFetchesABI(t *testing.T) {
+	fc := &fetchingClient{contractABI: &core.SmartContract_ABI{Entrys: []*core.SmartContract_ABI_Entry{{
+		Type:            core.SmartContract_ABI_Entry_Function,
+		Name:            "balanceOf",
+		StateMutability: core.SmartContract_ABI_Entry_View,
+		Inputs:          []*core.SmartContract_ABI_Entry_Param{{Name: "owner", Type: "address"}},
+		Outputs:         []*core.SmartContract_ABI_Entry_Param{{Name: "", Type: "uint256"}},
+	}}}}
+	var gotData []byte
+	fc.triggerConstantContractWithDataCtxFunc = func(_ context.Context, _, _ string, data []byte, _ ...client.ConstantCallOption) (*api.TransactionExtention, error) {
+		gotData = data
+		return &api.TransactionExtention{ConstantResult: [][]byte{common.LeftPadBytes([]byte{0x07}, 32)}}, nil
+	}
+
+	var balance *big.Int
+	err := New(fc, "TContract").Method("balanceOf").
+		Args("TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1").
+		CallInto(context.Background(), &balance)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), balance.Int64())
+	assert.Equal(t, []byte{0x70, 0xa0, 0x82, 0x31}, gotData[:4])
+	assert.Len(t, gotData, 36)
+	assert.Equal(t, 1, fc.fetches, "ABI is fetched once per call builder")
+}
+
+func TestArgsErrors(t *testing.T) {
+	mc := &mockClient{
+		estimateEnergyWithDataCtxFunc: func(context.Context, string, string, []byte, int64, string, int64) (*api.EstimateEnergyMessage, error) {
+			return &api.EstimateEnergyMessage{EnergyRequired: 1}, nil
+		},
+	}
+	ctx := context.Background()
+
+	_, err := New(mc, "TContract").From("TOwner").Method("submit").Args(1).EstimateEnergy(ctx)
+	assert.ErrorIs(t, err, ErrNoABI)
+
+	_, err = New(mc, "TContract").WithABI(ordersABI).Args(1).Call(ctx)
+	assert.ErrorIs(t, err, ErrNoMethod)
+
+	_, err = New(mc, "TContract").From("TOwner").WithABI(ordersABI).Method("submit").Args("x").EstimateEnergy(ctx)
+	assert.ErrorContains(t, err, "expected 2 arguments")
+
+	_, err = New(mc, "TContract").From("TOwner").WithABI(ordersABI).Method("submit").
+		Args([]struct{ Maker string }{{Maker: "TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1"}}, [32]byte{}).
+		EstimateEnergy(ctx)
+	assert.ErrorContains(t, err, "no field for component legs")
+
+	energy, err := New(mc, "TContract").From("TOwner").WithABI(balanceABI).Method("balanceOf(address)").
+		Args("TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1").EstimateEnergy(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), energy)
+}
+
+const vaultABI = `[
+ {"type":"function","name":"withdraw","stateMutability":"nonpayable",
+  "inputs":[{"name":"amount","type":"uint256"}],"outputs":[]},
+ {"type":"error","name":"InsufficientBalance","inputs":[
+  {"name":"available","type":"uint256"},{"name":"required","type":"uint256"}]}
+]`
+
+func insufficientBalance(t *testing.T) []byte {
+	t.Helper()
+	parsed, err := abi.ParseABI(vaultABI)
+	require.NoError(t, err)
+	e := parsed.Errors["InsufficientBalance"]
+	data, err := e.Inputs.Pack(big.NewInt(1), big.NewInt(2))
+	require.NoError(t, err)
+	return append(append([]byte{}, e.ID[:4]...), data...)
+}
+
+func revertedExt(data []byte) *api.TransactionExtention {
+	return &api.TransactionExtention{
+		Transaction:    &core.Transaction{Ret: []*core.Transaction_Result{{ContractRet: core.Transaction_Result_REVERT}}},
+		ConstantResult: [][]byte{data},
+		Result:         &api.Return{Result: true},
+	}
+}
+
+func TestCallDecodesCustomRevert(t *testing.T) {
+	revertData := insufficientBalance(t)
+	mc := &mockClient{
+		triggerConstantContractWithDataCtxFunc: func(context.Context, string, string, []byte, ...client.ConstantCallOption) (*api.TransactionExtention, error) {
+			return revertedExt(revertData), nil
+		},
+	}
+
+	_, err := New(mc, "TContract").WithABI(vaultABI).Method("withdraw").Args(2).Call(context.Background())
+	var rerr *RevertError
+	require.ErrorAs(t, err, &rerr)
+	assert.Equal(t, "InsufficientBalance", rerr.Name)
+	assert.Equal(t, []interface{}{big.NewInt(1), big.NewInt(2)}, rerr.Args)
+	assert.Equal(t, revertData, rerr.Data)
+
+	// Without an ABI the custom error cannot be named but the data is kept.
+	_, err = New(mc, "TContract").WithData([]byte{0x2e, 0x1a, 0x7d, 0x4d}).Call(context.Background())
+	require.ErrorAs(t, err, &rerr)
+	assert.Empty(t, rerr.Name)
+	assert.Equal(t, revertData, rerr.Data)
+}
+
+func TestEstimateEnergyDecodesRevert(t *testing.T) {
+	mc := &mockClient{
+		estimateEnergyWithDataCtxFunc: func(context.Context, string, string, []byte, int64, string, int64) (*api.EstimateEnergyMessage, error) {
+			return nil, errors.New("REVERT opcode executed")
+		},
+		triggerConstantContractWithDataCtxFunc: func(_ context.Context, from, _ string, _ []byte, _ ...client.ConstantCallOption) (*api.TransactionExtention, error) {
+			assert.Equal(t, "TFrom", from)
+			return revertedExt(insufficientBalance(t)), nil
+		},
+	}
+
+	_, err := New(mc, "TContract").From("TFrom").WithABI(vaultABI).Method("withdraw").Args(2).EstimateEnergy(context.Background())
+	var rerr *RevertError
+	require.ErrorAs(t, err, &rerr)
+	assert.Equal(t, "InsufficientBalance", rerr.Name)
+
+	mc.estimateEnergyWithDataCtxFunc = func(context.Context, string, string, []byte, int64, string, int64) (*api.EstimateEnergyMessage, error) {
+		return nil, errors.New("connection refused")
+	}
+	_, err = New(mc, "TContract").From("TFrom").WithABI(vaultABI).Method("withdraw").Args(2).EstimateEnergy(context.Background())
+	assert.EqualError(t, err, "connection refused")
+}
+
+func TestSendAndConfirmDecodesRevert(t *testing.T) {
+	revertData := insufficientBalance(t)
+	mc := &mockClient{
+		triggerContractWithDataCtxFunc: func(context.Context, string, string, []byte, int64, int64, string, int64) (*api.TransactionExtention, error) {
+			return newTestTxExt(), nil
+		},
+		broadcastCtxFunc: func(context.Context, *core.Transaction) (*api.Return, error) {
+			return &api.Return{Result: true}, nil
+		},
+		getTransactionInfoByIDCtxFunc: func(context.Context, string) (*core.TransactionInfo, error) {
+			return &core.TransactionInfo{
+				BlockNumber:    10,
+				Result:         core.TransactionInfo_FAILED,
+				ResMessage:     []byte("REVERT opcode executed"),
+				ContractResult: [][]byte{revertData},
+			}, nil
+		},
+	}
+
+	receipt, err := New(mc, "TContract").From("TFrom").WithABI(vaultABI).Method("withdraw").Args(2).
+		Apply(WithPollInterval(time.Millisecond)).
+		SendAndConfirm(context.Background(), &mockSigner{})
+	var rerr *RevertError
+	require.ErrorAs(t, err, &rerr)
+	assert.Equal(t, "InsufficientBalance", rerr.Name)
+	require.NotNil(t, receipt)
+	assert.True(t, receipt.Confirmed)
+	assert.Equal(t, "REVERT opcode executed", receipt.Error)
+}
+
+func TestCallData(t *testing.T) {
+	ctx := context.Background()
+
+	data, err := New(&mockClient{}, "TContract").
+		Method("balanceOf(address)").
+		Params(`["TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1"]`).
+		CallData(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x70, 0xa0, 0x82, 0x31}, data[:4])
+	assert.Len(t, data, 36)
+
+	viaArgs, err := New(&mockClient{}, "TContract").WithABI(balanceABI).
+		Method("balanceOf").
+		Args("TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1").
+		CallData(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, data, viaArgs)
+
+	raw := []byte{0x18, 0x16, 0x0d, 0xdd}
+	data, err = New(&mockClient{}, "TContract").WithData(raw).CallData(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, raw, data)
+
+	_, err = New(&mockClient{}, "TContract").CallData(ctx)
+	assert.ErrorIs(t, err, ErrNoMethod)
+}
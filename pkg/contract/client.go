@@ -20,3 +20,28 @@ type Client interface {
 	BroadcastCtx(ctx context.Context, tx *core.Transaction) (*api.Return, error)
 	GetTransactionInfoByIDCtx(ctx context.Context, id string) (*core.TransactionInfo, error)
 }
+
+// ABIFetcher is implemented by clients that can fetch a contract's ABI from
+// the network, such as *client.GrpcClient. When the Client passed to New
+// implements it, Args and CallInto fall back to the on-chain ABI if WithABI
+// was not used; proxies resolve to their implementation's ABI.
+//
+// The on-chain ABI format does not carry tuple components, so calls with
+// struct parameters or results need WithABI.
+type ABIFetcher interface {
+	GetContractABIResolvedCtx(ctx context.Context, contractAddress string) (*core.SmartContract_ABI, error)
+}
+
+// FeeLimitEstimator is implemented by clients that can estimate a fee limit
+// for a contract call, such as *client.GrpcClient. It is required by
+// AutoFeeLimit.
+type FeeLimitEstimator interface {
+	EstimateFeeLimitCtx(ctx context.Context, from, contractAddress string, data []byte, tAmount int64, tTokenID string, tTokenAmount int64, margin float64, ceiling int64) (*client.FeeLimitEstimate, error)
+}
+
+// Deployer is the subset of GrpcClient that the deployment builder needs.
+type Deployer interface {
+	DeployContractWithDataCtx(ctx context.Context, from, contractName string, abi *core.SmartContract_ABI, bytecode []byte, feeLimit, callValue, curPercent, oeLimit int64) (*api.TransactionExtention, error)
+	BroadcastCtx(ctx context.Context, tx *core.Transaction) (*api.Return, error)
+	GetTransactionInfoByIDCtx(ctx context.Context, id string) (*core.TransactionInfo, error)
+}
@@ -0,0 +1,340 @@
+package contract
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/fbsobreira/gotron-sdk/pkg/signer"
+	"github.com/fbsobreira/gotron-sdk/pkg/txcore"
+	"golang.org/x/crypto/sha3"
+)
+
+// Deployment defaults, matching tronctl contract deploy.
+const (
+	DefaultConsumeUserResourcePercent = 100
+	DefaultOriginEnergyLimit          = 1_000_000
+)
+
+// placeholderLen is the length of a library placeholder in hex bytecode,
+// the same as a hex-encoded 20-byte address.
+const placeholderLen = 40
+
+// Deployment is a builder for deploying a smart contract. Use Deploy to start
+// a builder chain, configure it with the fluent setter methods, and finish
+// with a terminal operation (Build, Sign, Send, or SendAndConfirm).
+//
+// As with ContractCall, validation errors are deferred and returned by the
+// terminal operation.
+type Deployment struct {
+	client                     Deployer
+	abiJSON                    string
+	bytecode                   string
+	from                       string
+	name                       string
+	args                       []interface{}
+	libraries                  map[string]string
+	linkRefs                   map[string][]LinkReference
+	consumeUserResourcePercent int64
+	originEnergyLimit          int64
+	cfg                        callConfig
+	err                        error
+}
+
+// Deploy creates a deployment builder for the contract described by abiJSON
+// with the given hex-encoded creation bytecode (with or without 0x prefix).
+func Deploy(c Deployer, abiJSON, bytecode string) *Deployment {
+	return &Deployment{
+		client:                     c,
+		abiJSON:                    abiJSON,
+		bytecode:                   strings.TrimSpace(bytecode),
+		libraries:                  make(map[string]string),
+		consumeUserResourcePercent: DefaultConsumeUserResourcePercent,
+		originEnergyLimit:          DefaultOriginEnergyLimit,
+	}
+}
+
+// SetError records a deferred error that will be returned by any terminal
+// operation. Nil errors are ignored.
+func (d *Deployment) SetError(err error) *Deployment {
+	if err != nil {
+		d.err = errors.Join(d.err, err)
+	}
+	return d
+}
+
+// Err returns any deferred error stored in the builder, or nil if none.
+func (d *Deployment) Err() error {
+	return d.err
+}
+
+// From sets the deployer (owner) address.
+func (d *Deployment) From(addr string) *Deployment {
+	d.from = addr
+	return d
+}
+
+// Name sets the contract name recorded on chain.
+func (d *Deployment) Name(name string) *Deployment {
+	d.name = name
+	return d
+}
+
+// Args sets the constructor arguments as native Go values, encoded against
+// the constructor in the ABI as described by abi.ConvertValue.
+func (d *Deployment) Args(values ...interface{}) *Deployment {
+	d.args = values
+	return d
+}
+
+// Link replaces the placeholders of library in the bytecode with the
+// library's deployed address. library is the fully qualified name used by
+// the compiler, e.g. "contracts/Math.sol:Math"; for bytecode from older
+// compilers the plain library name also matches. A plain name that matches
+// more than one library in the artifact's link references fails the build.
+func (d *Deployment) Link(library, libraryAddress string) *Deployment {
+	if _, err := address.Base58ToAddress(libraryAddress); err != nil {
+		return d.SetError(fmt.Errorf("invalid address for library %s: %w", library, err))
+	}
+	d.libraries[library] = libraryAddress
+	return d
+}
+
+// WithConsumeUserResourcePercent sets the percentage (0-100) of the energy
+// cost paid by callers rather than by the contract owner.
+func (d *Deployment) WithConsumeUserResourcePercent(percent int64) *Deployment {
+	d.consumeUserResourcePercent = percent
+	return d
+}
+
+// WithOriginEnergyLimit sets the maximum energy the contract owner provides
+// per call.
+func (d *Deployment) WithOriginEnergyLimit(limit int64) *Deployment {
+	d.originEnergyLimit = limit
+	return d
+}
+
+// Apply applies one or more Options. Fee limit, call value, permission ID and
+// poll interval apply to deployments; token values are ignored and
+// AutoFeeLimit is not supported.
+func (d *Deployment) Apply(opts ...Option) *Deployment {
+	for _, o := range opts {
+		o(&d.cfg)
+	}
+	return d
+}
+
+// WithFeeLimit sets the maximum TRX (in SUN) to spend on energy for the
+// deployment.
+func (d *Deployment) WithFeeLimit(limit int64) *Deployment {
+	return d.Apply(WithFeeLimit(limit))
+}
+
+// WithCallValue sets the TRX amount (in SUN) sent to a payable constructor.
+func (d *Deployment) WithCallValue(value int64) *Deployment {
+	return d.Apply(WithCallValue(value))
+}
+
+// WithPermissionID sets the permission ID for multi-signature deployments.
+func (d *Deployment) WithPermissionID(id int32) *Deployment {
+	return d.Apply(WithPermissionID(id))
+}
+
+// Bytecode returns the linked creation bytecode with the encoded constructor
+// arguments appended, as sent in the deployment transaction.
+func (d *Deployment) Bytecode() ([]byte, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	code, err := linkLibraries(d.bytecode, d.libraries, d.linkRefs)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := common.FromHex(code)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bytecode: %w", err)
+	}
+
+	parsed, err := abi.ParseABI(d.abiJSON)
+	if err != nil {
+		return nil, err
+	}
+	if len(parsed.Constructor.Inputs) == 0 && len(d.args) == 0 {
+		return bc, nil
+	}
+	packed, err := abi.PackArgs(parsed.Constructor.Inputs, d.args...)
+	if err != nil {
+		return nil, fmt.Errorf("constructor: %w", err)
+	}
+	return append(bc, packed...), nil
+}
+
+// Build creates the deployment transaction without signing or broadcasting.
+func (d *Deployment) Build(ctx context.Context) (*api.TransactionExtention, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	if d.from == "" {
+		return nil, fmt.Errorf("deployment: %w", ErrNoFromAddress)
+	}
+	if d.cfg.feeLimitMargin != nil {
+		return nil, errors.New("deployment: AutoFeeLimit is not supported")
+	}
+	bc, err := d.Bytecode()
+	if err != nil {
+		return nil, err
+	}
+	contractABI, err := JSONtoABI(d.abiJSON)
+	if err != nil {
+		return nil, fmt.Errorf("parse ABI: %w", err)
+	}
+
+	tx, err := d.client.DeployContractWithDataCtx(ctx, d.from, d.name, contractABI, bc,
+		d.cfg.feeLimit, d.cfg.callValue, d.consumeUserResourcePercent, d.originEnergyLimit)
+	if err != nil {
+		return nil, err
+	}
+	if d.cfg.permissionID != nil {
+		if err := tx.SetPermissionId(*d.cfg.permissionID); err != nil {
+			return nil, fmt.Errorf("set permission ID: %w", err)
+		}
+	}
+	return tx, nil
+}
+
+// Sign builds and signs the deployment transaction without broadcasting.
+func (d *Deployment) Sign(ctx context.Context, s signer.Signer) (*core.Transaction, error) {
+	tx, err := d.Build(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.Sign(tx.GetTransaction())
+}
+
+// Send builds, signs, and broadcasts the deployment. The receipt's
+// ContractAddress is only known once the transaction is confirmed; use
+// SendAndConfirm to obtain it.
+func (d *Deployment) Send(ctx context.Context, s signer.Signer) (*Receipt, error) {
+	tx, err := d.Build(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return txcore.Send(ctx, d.client, s, tx.GetTransaction())
+}
+
+// SendAndConfirm is like Send but additionally polls for confirmation. On
+// success the receipt's ContractAddress holds the deployed contract address
+// from the transaction info. If the constructor reverted, the receipt is
+// returned together with a *RevertError.
+func (d *Deployment) SendAndConfirm(ctx context.Context, s signer.Signer) (*Receipt, error) {
+	tx, err := d.Build(ctx)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := txcore.SendAndConfirm(ctx, d.client, s, tx.GetTransaction(), d.cfg.pollInterval)
+	if err != nil || !receipt.Confirmed || receipt.Error == "" {
+		return receipt, err
+	}
+	if len(receipt.Result) == 0 && !strings.Contains(receipt.Error, revertMarker) {
+		return receipt, nil
+	}
+	var parsed *eABI.ABI
+	if p, perr := abi.ParseABI(d.abiJSON); perr == nil {
+		parsed = &p
+	}
+	rerr := abi.DecodeRevert(parsed, receipt.Result)
+	if rerr.Reason == "" {
+		rerr.Reason = receipt.Error
+	}
+	return receipt, rerr
+}
+
+// PredictAddress returns the address of the contract that the
+// CreateSmartContract transaction tx will create. The address depends on the
+// transaction ID, so tx must be in its final form: changing the fee limit,
+// permission ID or expiration afterwards changes the address.
+func PredictAddress(tx *core.Transaction) (address.Address, error) {
+	contracts := tx.GetRawData().GetContract()
+	if len(contracts) != 1 || contracts[0].GetType() != core.Transaction_Contract_CreateSmartContract {
+		return nil, errors.New("not a CreateSmartContract transaction")
+	}
+	var create core.CreateSmartContract
+	if err := contracts[0].GetParameter().UnmarshalTo(&create); err != nil {
+		return nil, fmt.Errorf("decode CreateSmartContract: %w", err)
+	}
+	txID, err := txcore.TransactionID(tx)
+	if err != nil {
+		return nil, err
+	}
+	id, err := common.FromHex(txID)
+	if err != nil {
+		return nil, err
+	}
+	return address.ContractAddress(id, create.GetOwnerAddress())
+}
+
+// linkLibraries substitutes library addresses into hex bytecode and reports
+// any placeholder left unlinked. Libraries listed in refs are written at
+// their recorded offsets; all others are matched by placeholder. A plain
+// name matching several fully qualified names in refs is an error.
+func linkLibraries(code string, libraries map[string]string, refs map[string][]LinkReference) (string, error) {
+	for name, addr := range libraries {
+		a, err := address.Base58ToAddress(addr)
+		if err != nil {
+			return "", fmt.Errorf("invalid address for library %s: %w", name, err)
+		}
+		hexAddr := fmt.Sprintf("%x", a.Bytes()[1:])
+		var matches []string
+		for fq := range refs {
+			if fq == name || fq[strings.LastIndex(fq, ":")+1:] == name {
+				matches = append(matches, fq)
+			}
+		}
+		if len(matches) > 1 {
+			sort.Strings(matches)
+			return "", fmt.Errorf("library name %s is ambiguous, matching %s; link by fully qualified name", name, strings.Join(matches, ", "))
+		}
+		for _, fq := range matches {
+			for _, pos := range refs[fq] {
+				start, end := 2*pos.Start, 2*(pos.Start+pos.Length)
+				if pos.Length != len(hexAddr)/2 || start < 0 || end > len(code) {
+					return "", fmt.Errorf("invalid link reference for library %s at offset %d", fq, pos.Start)
+				}
+				code = code[:start] + hexAddr + code[end:]
+			}
+		}
+		for _, placeholder := range libraryPlaceholders(name) {
+			code = strings.ReplaceAll(code, placeholder, hexAddr)
+		}
+	}
+	// Hex bytecode never contains '_', so any remaining "__" starts a
+	// placeholder.
+	if i := strings.Index(code, "__"); i >= 0 {
+		end := min(i+placeholderLen, len(code))
+		return "", fmt.Errorf("bytecode references unlinked library %s", code[i:end])
+	}
+	return code, nil
+}
+
+// libraryPlaceholders returns the placeholders solc emits for a library:
+// the keccak-based form of solc >= 0.5 and the name-based legacy form.
+func libraryPlaceholders(name string) []string {
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write([]byte(name))
+	hash := fmt.Sprintf("%x", hasher.Sum(nil))
+
+	legacy := name
+	if len(legacy) > placeholderLen-4 {
+		legacy = legacy[:placeholderLen-4]
+	}
+	legacy = "__" + legacy + strings.Repeat("_", placeholderLen-2-len(legacy))
+	return []string{"__$" + hash[:34] + "$__", legacy}
+}
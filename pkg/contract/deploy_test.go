@@ -0,0 +1,204 @@
+package contract
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/fbsobreira/gotron-sdk/pkg/txcore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var _ Deployer = (*client.GrpcClient)(nil)
+
+const tokenDeployABI = `[
+ {"type":"constructor","stateMutability":"payable",
+  "inputs":[{"name":"name","type":"string"},{"name":"supply","type":"uint256"},{"name":"owner","type":"address"}]},
+ {"type":"error","name":"ZeroSupply","inputs":[]}
+]`
+
+// mockDeployer records the deployment request and serves broadcasts and
+// confirmations through the embedded mockClient.
+type mockDeployer struct {
+	mockClient
+	bytecode   []byte
+	callValue  int64
+	curPercent int64
+	oeLimit    int64
+}
+
+func (m *mockDeployer) DeployContractWithDataCtx(_ context.Context, _, _ string, _ *core.SmartContract_ABI, bytecode []byte, _, callValue, curPercent, oeLimit int64) (*api.TransactionExtention, error) {
+	m.bytecode, m.callValue, m.curPercent, m.oeLimit = bytecode, callValue, curPercent, oeLimit
+	ext := newTestTxExt()
+	ext.Transaction.RawData.Contract[0].Type = core.Transaction_Contract_CreateSmartContract
+	return ext, nil
+}
+
+func TestDeployBuild(t *testing.T) {
+	owner, err := address.Base58ToAddress("TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1")
+	require.NoError(t, err)
+
+	md := &mockDeployer{}
+	_, err = Deploy(md, tokenDeployABI, "0x6080604052").
+		From("TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1").
+		Name("Token").
+		Args("Token", big.NewInt(1_000_000), owner).
+		WithCallValue(5).
+		Build(context.Background())
+	require.NoError(t, err)
+
+	parsed, err := abi.ParseABI(tokenDeployABI)
+	require.NoError(t, err)
+	args, err := abi.PackArgs(parsed.Constructor.Inputs, "Token", 1_000_000, owner)
+	require.NoError(t, err)
+	assert.Equal(t, append([]byte{0x60, 0x80, 0x60, 0x40, 0x52}, args...), md.bytecode)
+	assert.Equal(t, int64(5), md.callValue)
+	assert.Equal(t, int64(DefaultConsumeUserResourcePercent), md.curPercent)
+	assert.Equal(t, int64(DefaultOriginEnergyLimit), md.oeLimit)
+
+	_, err = Deploy(md, tokenDeployABI, "6080").
+		From("TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1").
+		WithConsumeUserResourcePercent(30).
+		WithOriginEnergyLimit(5000).
+		Args("Token", 1, owner).
+		Build(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(30), md.curPercent)
+	assert.Equal(t, int64(5000), md.oeLimit)
+}
+
+func TestDeployErrors(t *testing.T) {
+	md := &mockDeployer{}
+	ctx := context.Background()
+
+	_, err := Deploy(md, tokenDeployABI, "6080").Build(ctx)
+	assert.ErrorIs(t, err, ErrNoFromAddress)
+
+	_, err = Deploy(md, tokenDeployABI, "6080").From("TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1").Args("Token").Build(ctx)
+	assert.ErrorContains(t, err, "constructor: expected 3 arguments")
+
+	_, err = Deploy(md, `[]`, "6080").From("TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1").Args(1).Build(ctx)
+	assert.ErrorContains(t, err, "constructor")
+
+	_, err = Deploy(md, `[]`, "zz").From("TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1").Build(ctx)
+	assert.ErrorContains(t, err, "invalid bytecode")
+
+	d := Deploy(md, `[]`, "6080").Link("Math", "bad")
+	assert.ErrorContains(t, d.Err(), "invalid address for library Math")
+}
+
+func TestDeployLinkLibraries(t *testing.T) {
+	lib := "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	libAddr, err := address.Base58ToAddress(lib)
+	require.NoError(t, err)
+	libHex := hex.EncodeToString(libAddr.Bytes()[1:])
+
+	placeholders := libraryPlaceholders("contracts/Math.sol:Math")
+	assert.Len(t, placeholders[0], placeholderLen)
+	assert.True(t, strings.HasPrefix(placeholders[0], "__$") && strings.HasSuffix(placeholders[0], "$__"))
+	legacy := libraryPlaceholders("Math")[1]
+	assert.Equal(t, "__Math"+strings.Repeat("_", 34), legacy)
+
+	code := "6080" + placeholders[0] + "00" + legacy + "00"
+	bc, err := Deploy(&mockDeployer{}, `[]`, code).
+		Link("contracts/Math.sol:Math", lib).
+		Link("Math", lib).
+		Bytecode()
+	require.NoError(t, err)
+	assert.Equal(t, "6080"+libHex+"00"+libHex+"00", hex.EncodeToString(bc))
+
+	_, err = Deploy(&mockDeployer{}, `[]`, code).Link("Math", lib).Bytecode()
+	assert.ErrorContains(t, err, "unlinked library "+placeholders[0])
+}
+
+func TestLinkLibrariesAmbiguousName(t *testing.T) {
+	lib := "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	code := "6080" + libraryPlaceholders("a/Math.sol:Math")[0] + libraryPlaceholders("b/Math.sol:Math")[0]
+	refs := map[string][]LinkReference{
+		"a/Math.sol:Math": {{Start: 2, Length: 20}},
+		"b/Math.sol:Math": {{Start: 22, Length: 20}},
+	}
+
+	_, err := linkLibraries(code, map[string]string{"Math": lib}, refs)
+	assert.ErrorContains(t, err, "library name Math is ambiguous, matching a/Math.sol:Math, b/Math.sol:Math")
+
+	_, err = linkLibraries(code, map[string]string{"a/Math.sol:Math": lib, "b/Math.sol:Math": lib}, refs)
+	assert.NoError(t, err)
+}
+
+func TestDeploySendAndConfirm(t *testing.T) {
+	md := &mockDeployer{}
+	md.broadcastCtxFunc = func(context.Context, *core.Transaction) (*api.Return, error) {
+		return &api.Return{Result: true}, nil
+	}
+	created, err := address.Base58ToAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	require.NoError(t, err)
+	md.getTransactionInfoByIDCtxFunc = func(context.Context, string) (*core.TransactionInfo, error) {
+		return &core.TransactionInfo{BlockNumber: 10, ContractAddress: created.Bytes()}, nil
+	}
+
+	receipt, err := Deploy(md, `[]`, "6080").
+		From("TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1").
+		Apply(WithPollInterval(time.Millisecond)).
+		SendAndConfirm(context.Background(), &mockSigner{})
+	require.NoError(t, err)
+	assert.Equal(t, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", receipt.ContractAddress)
+
+	// A reverting constructor surfaces as a decoded *RevertError.
+	md.getTransactionInfoByIDCtxFunc = func(context.Context, string) (*core.TransactionInfo, error) {
+		return &core.TransactionInfo{
+			BlockNumber:    10,
+			Result:         core.TransactionInfo_FAILED,
+			ResMessage:     []byte("REVERT opcode executed"),
+			ContractResult: [][]byte{abi.Signature("ZeroSupply()")},
+		}, nil
+	}
+	receipt, err = Deploy(md, tokenDeployABI, "6080").
+		From("TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1").
+		Args("Token", 0, "TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1").
+		Apply(WithPollInterval(time.Millisecond)).
+		SendAndConfirm(context.Background(), &mockSigner{})
+	require.NotNil(t, receipt)
+	var rerr *RevertError
+	require.True(t, errors.As(err, &rerr))
+	assert.Equal(t, "ZeroSupply", rerr.Name)
+}
+
+func TestPredictAddress(t *testing.T) {
+	owner, err := address.Base58ToAddress("TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1")
+	require.NoError(t, err)
+	param, err := anypb.New(&core.CreateSmartContract{OwnerAddress: owner})
+	require.NoError(t, err)
+	tx := &core.Transaction{RawData: &core.TransactionRaw{
+		Timestamp: 1700000000000,
+		Contract: []*core.Transaction_Contract{{
+			Type:      core.Transaction_Contract_CreateSmartContract,
+			Parameter: param,
+		}},
+	}}
+
+	predicted, err := PredictAddress(tx)
+	require.NoError(t, err)
+	txID, err := txcore.TransactionID(tx)
+	require.NoError(t, err)
+	id, err := common.FromHex(txID)
+	require.NoError(t, err)
+	want, err := address.ContractAddress(id, owner)
+	require.NoError(t, err)
+	assert.Equal(t, want, predicted)
+
+	_, err = PredictAddress(newTestTxExt().Transaction)
+	assert.ErrorContains(t, err, "not a CreateSmartContract transaction")
+}
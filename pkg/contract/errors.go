@@ -7,4 +7,6 @@ var (
 	ErrNoFromAddress = errors.New("from address required")
 	ErrNoContract    = errors.New("contract address required")
 	ErrNoMethod      = errors.New("method signature required")
+	ErrNoABI         = errors.New("contract ABI required")
+	ErrEmptyResult   = errors.New("empty call result")
 )
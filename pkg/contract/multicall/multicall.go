@@ -0,0 +1,279 @@
+// Package multicall batches many read-only contract calls into a single
+// constant call to a deployed Multicall3 contract, using its aggregate3
+// method. Each call reports its own success flag, so one failing call does
+// not fail the batch.
+//
+//	mc := multicall.New(conn, multicall.MainnetAddress)
+//	results, err := mc.Aggregate(ctx,
+//	    contract.New(conn, usdt).Method("balanceOf(address)").Params(holderJSON),
+//	    contract.New(conn, usdd).Method("balanceOf(address)").Params(holderJSON),
+//	)
+package multicall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/contract"
+)
+
+// MainnetAddress is the Multicall3 deployment on TRON mainnet.
+const MainnetAddress = "TEazPvZwDjDtFeJupyo7QunvnrnUjPH8ED"
+
+// Network names accepted by AddressFor and Register.
+const (
+	Mainnet = "mainnet"
+	Nile    = "nile"
+)
+
+var (
+	addressesMu sync.RWMutex
+	addresses   = map[string]string{
+		Mainnet: MainnetAddress,
+	}
+)
+
+// AddressFor returns the Multicall3 deployment known for network.
+func AddressFor(network string) (string, error) {
+	addressesMu.RLock()
+	defer addressesMu.RUnlock()
+	addr, ok := addresses[network]
+	if !ok {
+		return "", fmt.Errorf("multicall: no Multicall3 address known for network %q", network)
+	}
+	return addr, nil
+}
+
+// Register sets the Multicall3 deployment AddressFor returns for network,
+// for networks without a built-in deployment such as a private chain. It is
+// safe for concurrent use.
+func Register(network, contractAddress string) error {
+	if _, err := address.Base58ToAddress(contractAddress); err != nil {
+		return fmt.Errorf("multicall: invalid address for network %q: %w", network, err)
+	}
+	addressesMu.Lock()
+	defer addressesMu.Unlock()
+	addresses[network] = contractAddress
+	return nil
+}
+
+const (
+	// DefaultBatchSize is the default maximum number of calls per aggregate3
+	// request.
+	DefaultBatchSize = 100
+	// DefaultMaxCalldata is the default maximum size, in bytes, of the
+	// encoded call data of one aggregate3 request.
+	DefaultMaxCalldata = 64 * 1024
+)
+
+const aggregate3ABI = `[{"type":"function","name":"aggregate3","stateMutability":"payable",
+ "inputs":[{"name":"calls","type":"tuple[]","components":[
+  {"name":"target","type":"address"},{"name":"allowFailure","type":"bool"},{"name":"callData","type":"bytes"}]}],
+ "outputs":[{"name":"returnData","type":"tuple[]","components":[
+  {"name":"success","type":"bool"},{"name":"returnData","type":"bytes"}]}]}]`
+
+var aggregate3 eABI.Method
+
+func init() {
+	parsed, err := abi.ParseABI(aggregate3ABI)
+	if err != nil {
+		panic(err)
+	}
+	aggregate3 = parsed.Methods["aggregate3"]
+}
+
+type call3 struct {
+	Target       string
+	AllowFailure bool
+	CallData     []byte
+}
+
+type result3 struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// Result is the outcome of one call in a batch.
+type Result struct {
+	// Success reports whether the call succeeded.
+	Success bool
+	// ReturnData is the call's return data, or its revert data when Success
+	// is false.
+	ReturnData []byte
+}
+
+// Err returns nil for a successful call and the decoded revert otherwise.
+func (r Result) Err() error {
+	if r.Success {
+		return nil
+	}
+	return abi.DecodeRevert(nil, r.ReturnData)
+}
+
+// CallResult wraps the return data in a contract.CallResult so it can be
+// decoded with CallResult.Decode.
+func (r Result) CallResult() *contract.CallResult {
+	return &contract.CallResult{RawResults: [][]byte{r.ReturnData}}
+}
+
+// Multicall sends batched constant calls through a Multicall3 contract.
+type Multicall struct {
+	client  contract.Client
+	address string
+	cfg     config
+}
+
+type config struct {
+	batchSize   int
+	maxCalldata int
+	from        string
+}
+
+// Option configures a Multicall.
+type Option func(*config)
+
+// WithBatchSize sets the maximum number of calls sent in one aggregate3
+// request. Larger batches are split into several requests.
+func WithBatchSize(n int) Option {
+	return func(c *config) {
+		c.batchSize = n
+	}
+}
+
+// WithMaxCalldata sets the maximum encoded size, in bytes, of one aggregate3
+// request. Larger batches are split into several requests.
+func WithMaxCalldata(n int) Option {
+	return func(c *config) {
+		c.maxCalldata = n
+	}
+}
+
+// WithFrom sets the caller address used for the aggregate3 call.
+func WithFrom(addr string) Option {
+	return func(c *config) {
+		c.from = addr
+	}
+}
+
+// New returns a Multicall that sends calls through the Multicall3 contract
+// at multicallAddress.
+func New(client contract.Client, multicallAddress string, opts ...Option) *Multicall {
+	m := &Multicall{
+		client:  client,
+		address: multicallAddress,
+		cfg: config{
+			batchSize:   DefaultBatchSize,
+			maxCalldata: DefaultMaxCalldata,
+		},
+	}
+	for _, opt := range opts {
+		opt(&m.cfg)
+	}
+	return m
+}
+
+// Aggregate executes calls as constant calls through aggregate3 and returns
+// one Result per call, in order. Only each call's target and call data are
+// used; call values and caller addresses are ignored.
+//
+// Calls are split into requests of at most WithBatchSize calls and
+// WithMaxCalldata bytes. A request that runs out of energy or time on the
+// node is retried in halves, so the batch adapts to the node's limits. An
+// error is returned only when a call cannot be encoded, the node cannot be
+// reached, or a single call exhausts the limits on its own.
+func (m *Multicall) Aggregate(ctx context.Context, calls ...*contract.ContractCall) ([]Result, error) {
+	encoded := make([]call3, len(calls))
+	for i, c := range calls {
+		data, err := c.CallData(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %w", i, err)
+		}
+		encoded[i] = call3{Target: c.ContractAddress(), AllowFailure: true, CallData: data}
+	}
+
+	results := make([]Result, 0, len(calls))
+	for _, chunk := range m.chunks(encoded) {
+		res, err := m.execute(ctx, chunk)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, res...)
+	}
+	return results, nil
+}
+
+// chunks splits calls by the configured count and size limits. Each call
+// costs its padded data plus the tuple head and offsets (5 words).
+func (m *Multicall) chunks(calls []call3) [][]call3 {
+	var (
+		out   [][]call3
+		start int
+		size  int
+	)
+	for i, c := range calls {
+		cost := 5*32 + (len(c.CallData)+31)/32*32
+		if i > start && (i-start >= m.cfg.batchSize || (m.cfg.maxCalldata > 0 && size+cost > m.cfg.maxCalldata)) {
+			out = append(out, calls[start:i])
+			start, size = i, 0
+		}
+		size += cost
+	}
+	if start < len(calls) {
+		out = append(out, calls[start:])
+	}
+	return out
+}
+
+// execute runs one aggregate3 request, splitting it in halves when the node
+// rejects it for exceeding its execution limits.
+func (m *Multicall) execute(ctx context.Context, calls []call3) ([]Result, error) {
+	packed, err := abi.PackArgs(aggregate3.Inputs, calls)
+	if err != nil {
+		return nil, fmt.Errorf("encode aggregate3: %w", err)
+	}
+	data := append(append([]byte{}, aggregate3.ID...), packed...)
+
+	call := contract.New(m.client, m.address).WithData(data)
+	if m.cfg.from != "" {
+		call.From(m.cfg.from)
+	}
+	res, err := call.Call(ctx)
+	if err == nil && len(res.RawResults) == 0 {
+		err = contract.ErrEmptyResult
+	}
+
+	var rerr *contract.RevertError
+	if err != nil {
+		if len(calls) > 1 && (errors.As(err, &rerr) || errors.Is(err, contract.ErrEmptyResult)) {
+			half := len(calls) / 2
+			first, err := m.execute(ctx, calls[:half])
+			if err != nil {
+				return nil, err
+			}
+			second, err := m.execute(ctx, calls[half:])
+			if err != nil {
+				return nil, err
+			}
+			return append(first, second...), nil
+		}
+		return nil, fmt.Errorf("aggregate3: %w", err)
+	}
+
+	var decoded []result3
+	if err := abi.UnpackInto(aggregate3.Outputs, res.RawResults[0], &decoded); err != nil {
+		return nil, fmt.Errorf("decode aggregate3: %w", err)
+	}
+	if len(decoded) != len(calls) {
+		return nil, fmt.Errorf("aggregate3 returned %d results for %d calls", len(decoded), len(calls))
+	}
+	results := make([]Result, len(decoded))
+	for i, r := range decoded {
+		results[i] = Result(r)
+	}
+	return results, nil
+}
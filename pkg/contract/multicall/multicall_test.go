@@ -0,0 +1,229 @@
+package multicall
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/contract"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	tokenA = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	tokenB = "TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1"
+)
+
+// fakeMulticall emulates a Multicall3 contract: every call succeeds and
+// returns the last byte of its target address as a uint256, except calls
+// with data 0xdeadbeef, which revert. Requests with more than maxCalls calls
+// fail as if the node ran out of time.
+type fakeMulticall struct {
+	maxCalls int
+	requests []int // number of calls per request
+	err      error
+}
+
+type decodedCall struct {
+	Target       address.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+func (f *fakeMulticall) TriggerConstantContractWithDataCtx(_ context.Context, _, _ string, data []byte, _ ...client.ConstantCallOption) (*api.TransactionExtention, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	var in struct{ Calls []decodedCall }
+	if err := abi.UnpackInto(aggregate3.Inputs, data[4:], &in); err != nil {
+		return nil, err
+	}
+	f.requests = append(f.requests, len(in.Calls))
+	if f.maxCalls > 0 && len(in.Calls) > f.maxCalls {
+		return &api.TransactionExtention{
+			Result: &api.Return{Result: false, Message: []byte("CPU timeout for 'SLOAD' operation executing")},
+		}, nil
+	}
+
+	out := make([]result3, len(in.Calls))
+	for i, c := range in.Calls {
+		if string(c.CallData) == "\xde\xad\xbe\xef" {
+			out[i] = result3{ReturnData: append([]byte{0x08, 0xc3, 0x79, 0xa0}, mustPack(abi.PackArgs(stringArgs, "nope"))...)}
+			continue
+		}
+		ret, err := abi.PackArgs(uintArgs, big.NewInt(int64(c.Target[len(c.Target)-1])))
+		if err != nil {
+			return nil, err
+		}
+		out[i] = result3{Success: true, ReturnData: ret}
+	}
+	ret, err := abi.PackArgs(aggregate3.Outputs, out)
+	if err != nil {
+		return nil, err
+	}
+	return &api.TransactionExtention{ConstantResult: [][]byte{ret}, Result: &api.Return{Result: true}}, nil
+}
+
+func (f *fakeMulticall) TriggerConstantContractCtx(context.Context, string, string, string, string, ...client.ConstantCallOption) (*api.TransactionExtention, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeMulticall) TriggerContractCtx(context.Context, string, string, string, string, int64, int64, string, int64) (*api.TransactionExtention, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeMulticall) TriggerContractWithDataCtx(context.Context, string, string, []byte, int64, int64, string, int64) (*api.TransactionExtention, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeMulticall) EstimateEnergyCtx(context.Context, string, string, string, string, int64, string, int64) (*api.EstimateEnergyMessage, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeMulticall) EstimateEnergyWithDataCtx(context.Context, string, string, []byte, int64, string, int64) (*api.EstimateEnergyMessage, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeMulticall) BroadcastCtx(context.Context, *core.Transaction) (*api.Return, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeMulticall) GetTransactionInfoByIDCtx(context.Context, string) (*core.TransactionInfo, error) {
+	return nil, errors.New("not implemented")
+}
+
+var (
+	uintArgs   = mustArgs(`[{"type":"function","name":"f","inputs":[{"name":"","type":"uint256"}],"outputs":[]}]`)
+	stringArgs = mustArgs(`[{"type":"function","name":"f","inputs":[{"name":"","type":"string"}],"outputs":[]}]`)
+)
+
+func mustArgs(abiJSON string) eABI.Arguments {
+	parsed, err := abi.ParseABI(abiJSON)
+	if err != nil {
+		panic(err)
+	}
+	return parsed.Methods["f"].Inputs
+}
+
+func mustPack(b []byte, err error) []byte {
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func balanceCall(f *fakeMulticall, token string) *contract.ContractCall {
+	return contract.New(f, token).Method("balanceOf(address)").Params(`["` + tokenB + `"]`)
+}
+
+func lastByte(t *testing.T, addr string) int64 {
+	a, err := address.Base58ToAddress(addr)
+	require.NoError(t, err)
+	return int64(a[len(a)-1])
+}
+
+func TestAggregate(t *testing.T) {
+	f := &fakeMulticall{}
+	mc := New(f, MainnetAddress)
+
+	results, err := mc.Aggregate(context.Background(),
+		balanceCall(f, tokenA),
+		contract.New(f, tokenB).WithData([]byte{0xde, 0xad, 0xbe, 0xef}),
+		balanceCall(f, tokenB),
+	)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, []int{3}, f.requests)
+
+	assert.True(t, results[0].Success)
+	assert.NoError(t, results[0].Err())
+	var bal *big.Int
+	require.NoError(t, results[0].CallResult().Decode(
+		`[{"type":"function","name":"balanceOf","inputs":[{"name":"a","type":"address"}],"outputs":[{"name":"","type":"uint256"}]}]`,
+		"balanceOf", &bal))
+	assert.Equal(t, lastByte(t, tokenA), bal.Int64())
+
+	assert.False(t, results[1].Success)
+	var rerr *contract.RevertError
+	require.ErrorAs(t, results[1].Err(), &rerr)
+	assert.Equal(t, "nope", rerr.Reason)
+
+	assert.True(t, results[2].Success)
+}
+
+func TestAggregateChunks(t *testing.T) {
+	f := &fakeMulticall{}
+	calls := make([]*contract.ContractCall, 7)
+	for i := range calls {
+		calls[i] = balanceCall(f, tokenA)
+	}
+
+	results, err := New(f, MainnetAddress, WithBatchSize(3)).Aggregate(context.Background(), calls...)
+	require.NoError(t, err)
+	assert.Len(t, results, 7)
+	assert.Equal(t, []int{3, 3, 1}, f.requests)
+
+	// Each balanceOf call costs 5 words of overhead plus 2 words of data.
+	f.requests = nil
+	_, err = New(f, MainnetAddress, WithMaxCalldata(2*7*32)).Aggregate(context.Background(), calls...)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 2, 2, 1}, f.requests)
+}
+
+func TestAggregateSplitsOnNodeLimits(t *testing.T) {
+	f := &fakeMulticall{maxCalls: 2}
+	calls := make([]*contract.ContractCall, 5)
+	for i := range calls {
+		calls[i] = balanceCall(f, tokenB)
+	}
+
+	results, err := New(f, MainnetAddress).Aggregate(context.Background(), calls...)
+	require.NoError(t, err)
+	require.Len(t, results, 5)
+	for _, r := range results {
+		assert.True(t, r.Success)
+	}
+	assert.Equal(t, []int{5, 2, 3, 1, 2}, f.requests)
+
+	f = &fakeMulticall{err: errors.New("connection refused")}
+	_, err = New(f, MainnetAddress).Aggregate(context.Background(), balanceCall(f, tokenA), balanceCall(f, tokenA))
+	assert.ErrorContains(t, err, "connection refused")
+}
+
+func TestAggregateEncodeError(t *testing.T) {
+	f := &fakeMulticall{}
+	_, err := New(f, MainnetAddress).Aggregate(context.Background(),
+		balanceCall(f, tokenA),
+		contract.New(f, tokenA).SetError(errors.New("bad input")),
+	)
+	assert.ErrorContains(t, err, "call 1: bad input")
+	assert.Empty(t, f.requests)
+}
+
+func TestAddressFor(t *testing.T) {
+	addr, err := AddressFor(Mainnet)
+	require.NoError(t, err)
+	assert.Equal(t, MainnetAddress, addr)
+
+	_, err = AddressFor("private")
+	assert.ErrorContains(t, err, `network "private"`)
+
+	assert.ErrorContains(t, Register("private", "bad"), "invalid address")
+	require.NoError(t, Register("private", tokenA))
+	defer func() {
+		addressesMu.Lock()
+		delete(addresses, "private")
+		addressesMu.Unlock()
+	}()
+	addr, err = AddressFor("private")
+	require.NoError(t, err)
+	assert.Equal(t, tokenA, addr)
+}
@@ -12,6 +12,8 @@ type callConfig struct {
 	tokenAmount  int64
 	permissionID *int32
 	pollInterval time.Duration
+	// feeLimitMargin is set by AutoFeeLimit.
+	feeLimitMargin *float64
 }
 
 // WithFeeLimit sets the maximum TRX (in SUN) the caller is willing to spend
@@ -22,6 +24,17 @@ func WithFeeLimit(limit int64) Option {
 	}
 }
 
+// AutoFeeLimit makes Build estimate the fee limit instead of using a fixed
+// one: the call's energy is estimated, the contract's dynamic energy factor
+// applied, margin (e.g. 0.2 for 20%) added, and the result priced at the
+// current energy fee. A limit set with WithFeeLimit becomes the ceiling. The
+// client must implement FeeLimitEstimator.
+func AutoFeeLimit(margin float64) Option {
+	return func(c *callConfig) {
+		c.feeLimitMargin = &margin
+	}
+}
+
 // WithCallValue sets the TRX amount (in SUN) sent along with the call.
 func WithCallValue(value int64) Option {
 	return func(c *callConfig) {
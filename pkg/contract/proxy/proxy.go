@@ -0,0 +1,448 @@
+// Package proxy detects upgradeable proxy contracts and resolves the ABI of
+// the code they delegate to. It recognises EIP-1967 proxies (transparent and
+// UUPS), beacon proxies, EIP-1167 minimal proxies, EIP-2535 diamonds and
+// proxies exposing an implementation getter.
+//
+//	r := proxy.New(conn, proxy.WithStorage(proxy.NewJSONRPCStorage("https://api.trongrid.io/jsonrpc")))
+//	contractABI, info, err := r.ResolveABI(ctx, addr)
+//
+// The gRPC API cannot read contract storage, so proxies that keep their
+// implementation only in an EIP-1967 slot need a StorageReader to resolve.
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/contract"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// Kind is the proxy pattern of a contract.
+type Kind string
+
+// Proxy kinds reported by Detect.
+const (
+	// KindNone means no proxy pattern was recognised.
+	KindNone Kind = "none"
+	// KindEIP1967 is a proxy storing its implementation in the EIP-1967
+	// slot, such as a transparent upgradeable proxy.
+	KindEIP1967 Kind = "eip1967"
+	// KindUUPS is an EIP-1967 proxy whose implementation carries the
+	// upgrade logic (EIP-1822 proxiableUUID).
+	KindUUPS Kind = "uups"
+	// KindBeacon is a proxy reading its implementation from a beacon
+	// contract stored in the EIP-1967 beacon slot.
+	KindBeacon Kind = "beacon"
+	// KindMinimal is an EIP-1167 minimal proxy (clone) with the
+	// implementation embedded in its code.
+	KindMinimal Kind = "eip1167"
+	// KindDiamond is an EIP-2535 diamond routing selectors to facets.
+	KindDiamond Kind = "diamond"
+	// KindGetter is a proxy exposing its implementation through a getter
+	// such as implementation() or masterCopy().
+	KindGetter Kind = "getter"
+)
+
+// EIP-1967 storage slots.
+var (
+	ImplementationSlot = common.BytesToHash(mustHex("360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc"))
+	AdminSlot          = common.BytesToHash(mustHex("b53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103"))
+	BeaconSlot         = common.BytesToHash(mustHex("a3f0ad74e5423aebfd80d3ef4346578335a9a72aeaee59ff6cb3582b35133d50"))
+)
+
+var (
+	selectorFacets        = abi.Signature("facets()")
+	selectorProxiableUUID = abi.Signature("proxiableUUID()")
+	selectorBeaconImpl    = abi.Signature("implementation()")
+
+	// getterSelectors are tried in order on proxies that expose their
+	// implementation, as in client.GetContractABIResolved.
+	getterSelectors = [][]byte{
+		abi.Signature("implementation()"),
+		abi.Signature("comptrollerImplementation()"),
+		abi.Signature("getImplementation()"),
+		abi.Signature("masterCopy()"),
+	}
+
+	// EIP-1167 runtime code surrounding the 20-byte implementation address.
+	minimalPrefix = mustHex("363d3d373d3d3d363d73")
+	minimalSuffix = mustHex("5af43d82803e903d91602b57fd5bf3")
+)
+
+var facetsOutputs eABI.Arguments
+
+func init() {
+	parsed, err := abi.ParseABI(`[{"type":"function","name":"facets","inputs":[],
+	 "outputs":[{"name":"facets","type":"tuple[]","components":[
+	  {"name":"facetAddress","type":"address"},{"name":"functionSelectors","type":"bytes4[]"}]}]}]`)
+	if err != nil {
+		panic(err)
+	}
+	facetsOutputs = parsed.Methods["facets"].Outputs
+}
+
+// Client is the subset of GrpcClient that the resolver needs.
+type Client interface {
+	contract.Client
+	GetContractABICtx(ctx context.Context, contractAddress string) (*core.SmartContract_ABI, error)
+	GetContractInfoCtx(ctx context.Context, contractAddress string) (*core.SmartContractDataWrapper, error)
+}
+
+// Facet is a diamond facet and the selectors the diamond routes to it.
+type Facet struct {
+	Address   string
+	Selectors [][4]byte
+}
+
+// Info describes the proxy pattern of a contract. Addresses are base58.
+type Info struct {
+	Address string
+	Kind    Kind
+	// Implementation is the contract the proxy delegates to. It is empty
+	// for diamonds, non-proxies, and slot-based proxies detected from their
+	// code when no StorageReader is configured.
+	Implementation string
+	// Admin is the EIP-1967 admin, when storage can be read and it is set.
+	Admin string
+	// Beacon is the beacon contract of a beacon proxy.
+	Beacon string
+	// Facets lists the facets of a diamond.
+	Facets []Facet
+}
+
+// IsProxy reports whether a proxy pattern was recognised.
+func (i *Info) IsProxy() bool {
+	return i.Kind != KindNone
+}
+
+// Resolver detects proxy patterns. Create one with New.
+type Resolver struct {
+	client  Client
+	storage StorageReader
+}
+
+// Option configures a Resolver.
+type Option func(*Resolver)
+
+// WithStorage lets the resolver read EIP-1967 slots through s. Without it,
+// slot-based proxies are only resolved when they also expose a getter.
+func WithStorage(s StorageReader) Option {
+	return func(r *Resolver) {
+		r.storage = s
+	}
+}
+
+// New creates a Resolver using c for constant calls and contract metadata.
+func New(c Client, opts ...Option) *Resolver {
+	r := &Resolver{client: c}
+	for _, o := range opts {
+		o(r)
+	}
+	return r
+}
+
+// Detect tries each proxy pattern on contractAddress in turn: diamond
+// facets, the EIP-1967 implementation and beacon slots, EIP-1167 code and
+// implementation getters. Calls that revert simply rule out a pattern;
+// network and storage errors are returned.
+func (r *Resolver) Detect(ctx context.Context, contractAddress string) (*Info, error) {
+	if _, err := address.Base58ToAddress(contractAddress); err != nil {
+		return nil, fmt.Errorf("invalid address %s: %w", contractAddress, err)
+	}
+	info := &Info{Address: contractAddress, Kind: KindNone}
+
+	if facets, err := r.facets(ctx, contractAddress); err != nil {
+		return nil, err
+	} else if len(facets) > 0 {
+		info.Kind = KindDiamond
+		info.Facets = facets
+		return info, nil
+	}
+
+	if r.storage != nil {
+		found, err := r.detectFromSlots(ctx, info)
+		if err != nil || found {
+			return info, err
+		}
+	}
+
+	code, err := r.runtimeCode(ctx, contractAddress)
+	if err != nil {
+		return nil, err
+	}
+	if impl := minimalProxyTarget(code); impl != "" {
+		info.Kind = KindMinimal
+		info.Implementation = impl
+		return info, nil
+	}
+
+	for _, sel := range getterSelectors {
+		impl, err := r.callForAddress(ctx, contractAddress, sel)
+		if err != nil {
+			return nil, err
+		}
+		if impl != "" {
+			info.Implementation = impl
+			info.Kind = KindGetter
+			if bytes.Contains(code, ImplementationSlot[:]) {
+				if info.Kind, err = r.eip1967Kind(ctx, impl); err != nil {
+					return nil, err
+				}
+			}
+			return info, nil
+		}
+	}
+
+	// The slot constants in the code identify the pattern even when the
+	// implementation cannot be read without storage access.
+	switch {
+	case bytes.Contains(code, BeaconSlot[:]):
+		info.Kind = KindBeacon
+	case bytes.Contains(code, ImplementationSlot[:]):
+		info.Kind = KindEIP1967
+	}
+	return info, nil
+}
+
+// ResolveABI detects the proxy pattern of contractAddress and returns the ABI
+// to use with it: the implementation's ABI for single-implementation
+// proxies, the proxy's own entries merged with its facets' functions for
+// diamonds, and the contract's own ABI otherwise or when the implementation
+// has no ABI on chain.
+func (r *Resolver) ResolveABI(ctx context.Context, contractAddress string) (*core.SmartContract_ABI, *Info, error) {
+	info, err := r.Detect(ctx, contractAddress)
+	if err != nil {
+		return nil, nil, err
+	}
+	own, err := r.client.GetContractABICtx(ctx, contractAddress)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch {
+	case info.Kind == KindDiamond:
+		merged, err := r.mergeFacets(ctx, own, info.Facets)
+		if err != nil {
+			return nil, nil, err
+		}
+		return merged, info, nil
+	case info.Implementation != "":
+		impl, err := r.client.GetContractABICtx(ctx, info.Implementation)
+		if err == nil && len(impl.GetEntrys()) > 0 {
+			return impl, info, nil
+		}
+	}
+	return own, info, nil
+}
+
+// detectFromSlots reads the EIP-1967 implementation, beacon and admin slots.
+func (r *Resolver) detectFromSlots(ctx context.Context, info *Info) (bool, error) {
+	impl, err := r.slotAddress(ctx, info.Address, ImplementationSlot)
+	if err != nil {
+		return false, err
+	}
+	if impl != "" {
+		info.Implementation = impl
+		if info.Kind, err = r.eip1967Kind(ctx, impl); err != nil {
+			return false, err
+		}
+		if info.Admin, err = r.slotAddress(ctx, info.Address, AdminSlot); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	beacon, err := r.slotAddress(ctx, info.Address, BeaconSlot)
+	if err != nil || beacon == "" {
+		return false, err
+	}
+	info.Kind = KindBeacon
+	info.Beacon = beacon
+	info.Implementation, err = r.callForAddress(ctx, beacon, selectorBeaconImpl)
+	return true, err
+}
+
+// eip1967Kind tells UUPS implementations, which report the implementation
+// slot from proxiableUUID, from other EIP-1967 proxies.
+func (r *Resolver) eip1967Kind(ctx context.Context, impl string) (Kind, error) {
+	ret, err := r.call(ctx, impl, selectorProxiableUUID)
+	if err != nil {
+		return "", err
+	}
+	if len(ret) >= 32 && bytes.Equal(ret[:32], ImplementationSlot[:]) {
+		return KindUUPS, nil
+	}
+	return KindEIP1967, nil
+}
+
+func (r *Resolver) facets(ctx context.Context, contractAddress string) ([]Facet, error) {
+	ret, err := r.call(ctx, contractAddress, selectorFacets)
+	if err != nil || len(ret) == 0 {
+		return nil, err
+	}
+	var out struct {
+		Facets []struct {
+			FacetAddress      address.Address
+			FunctionSelectors [][4]byte
+		}
+	}
+	if abi.UnpackInto(facetsOutputs, ret, &out) != nil {
+		// Not a diamond; some fallback functions answer any selector.
+		return nil, nil
+	}
+	facets := make([]Facet, 0, len(out.Facets))
+	for _, f := range out.Facets {
+		if len(f.FunctionSelectors) == 0 {
+			continue
+		}
+		facets = append(facets, Facet{Address: f.FacetAddress.String(), Selectors: f.FunctionSelectors})
+	}
+	return facets, nil
+}
+
+// mergeFacets combines the diamond's own ABI with the functions each facet
+// serves. Events and errors of all facets are kept. Functions with tuple
+// parameters cannot be matched to a selector from the on-chain ABI and are
+// kept as well.
+func (r *Resolver) mergeFacets(ctx context.Context, own *core.SmartContract_ABI, facets []Facet) (*core.SmartContract_ABI, error) {
+	merged := &core.SmartContract_ABI{}
+	seen := make(map[string]bool)
+	add := func(e *core.SmartContract_ABI_Entry) {
+		key := entryKey(e)
+		if !seen[key] {
+			seen[key] = true
+			merged.Entrys = append(merged.Entrys, e)
+		}
+	}
+	for _, e := range own.GetEntrys() {
+		add(e)
+	}
+
+	for _, f := range facets {
+		facetABI, err := r.client.GetContractABICtx(ctx, f.Address)
+		if err != nil {
+			return nil, fmt.Errorf("facet %s: %w", f.Address, err)
+		}
+		routed := make(map[[4]byte]bool, len(f.Selectors))
+		for _, s := range f.Selectors {
+			routed[s] = true
+		}
+		for _, e := range facetABI.GetEntrys() {
+			switch e.GetType() {
+			case core.SmartContract_ABI_Entry_Function:
+				sel, ok := entrySelector(e)
+				if ok && !routed[sel] {
+					continue
+				}
+				add(e)
+			case core.SmartContract_ABI_Entry_Event, core.SmartContract_ABI_Entry_Error:
+				add(e)
+			}
+		}
+	}
+	return merged, nil
+}
+
+// call runs a constant call and returns its result, or nil if it reverted.
+func (r *Resolver) call(ctx context.Context, target string, data []byte) ([]byte, error) {
+	res, err := contract.New(r.client, target).WithData(data).Call(ctx)
+	var rerr *contract.RevertError
+	if errors.As(err, &rerr) {
+		// A revert rules the pattern out.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(res.RawResults) == 0 {
+		return nil, nil
+	}
+	return res.RawResults[0], nil
+}
+
+func (r *Resolver) callForAddress(ctx context.Context, target string, data []byte) (string, error) {
+	ret, err := r.call(ctx, target, data)
+	if err != nil || len(ret) < 32 {
+		return "", err
+	}
+	return wordToAddress(ret[:32]), nil
+}
+
+func (r *Resolver) slotAddress(ctx context.Context, contractAddress string, slot common.Hash) (string, error) {
+	word, err := r.storage.StorageAtCtx(ctx, contractAddress, slot)
+	if err != nil {
+		return "", fmt.Errorf("read storage slot %s: %w", slot.Hex(), err)
+	}
+	if len(word) < 32 {
+		word = append(make([]byte, 32-len(word)), word...)
+	}
+	return wordToAddress(word[len(word)-32:]), nil
+}
+
+func (r *Resolver) runtimeCode(ctx context.Context, contractAddress string) ([]byte, error) {
+	info, err := r.client.GetContractInfoCtx(ctx, contractAddress)
+	if err != nil {
+		return nil, fmt.Errorf("get contract info: %w", err)
+	}
+	return info.GetRuntimecode(), nil
+}
+
+// wordToAddress returns the base58 address in the low 20 bytes of a 32-byte
+// word, or "" if those bytes are zero.
+func wordToAddress(word []byte) string {
+	evmAddr := word[12:32]
+	if bytes.Equal(evmAddr, make([]byte, 20)) {
+		return ""
+	}
+	addr, _ := address.EthAddressToAddress(evmAddr)
+	return addr.String()
+}
+
+// minimalProxyTarget returns the implementation of EIP-1167 runtime code.
+func minimalProxyTarget(code []byte) string {
+	n := len(minimalPrefix) + 20 + len(minimalSuffix)
+	if len(code) != n || !bytes.HasPrefix(code, minimalPrefix) || !bytes.HasSuffix(code, minimalSuffix) {
+		return ""
+	}
+	addr, _ := address.EthAddressToAddress(code[len(minimalPrefix) : len(minimalPrefix)+20])
+	return addr.String()
+}
+
+// entrySelector computes the selector of a function entry. It fails for
+// tuple parameters, whose components the on-chain ABI does not record.
+func entrySelector(e *core.SmartContract_ABI_Entry) ([4]byte, bool) {
+	var sel [4]byte
+	types := make([]string, len(e.GetInputs()))
+	for i, in := range e.GetInputs() {
+		if strings.HasPrefix(in.GetType(), "tuple") {
+			return sel, false
+		}
+		types[i] = in.GetType()
+	}
+	copy(sel[:], abi.Signature(e.GetName()+"("+strings.Join(types, ",")+")"))
+	return sel, true
+}
+
+func entryKey(e *core.SmartContract_ABI_Entry) string {
+	types := make([]string, len(e.GetInputs()))
+	for i, in := range e.GetInputs() {
+		types[i] = in.GetType()
+	}
+	return e.GetType().String() + " " + e.GetName() + "(" + strings.Join(types, ",") + ")"
+}
+
+func mustHex(s string) []byte {
+	b, err := common.FromHex(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
@@ -0,0 +1,258 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ Client = (*client.GrpcClient)(nil)
+
+const (
+	proxyAddr  = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	implAddr   = "TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1"
+	beaconAddr = "TN3W4H6rK2ce4vX9YnFQHwKENnHjoxb3m9"
+	facetA     = "TPYmHEhy5n8TCEfYGqW2rPxsghSfzghPDn"
+	facetB     = "TEazPvZwDjDtFeJupyo7QunvnrnUjPH8ED"
+)
+
+// fakeChain serves constant calls, ABIs and runtime code per contract.
+// Calls without a registered return value revert.
+type fakeChain struct {
+	returns map[string]map[string][]byte // address -> selector hex -> return data
+	abis    map[string]*core.SmartContract_ABI
+	code    map[string][]byte
+	err     error
+}
+
+func newFakeChain() *fakeChain {
+	return &fakeChain{
+		returns: make(map[string]map[string][]byte),
+		abis:    make(map[string]*core.SmartContract_ABI),
+		code:    make(map[string][]byte),
+	}
+}
+
+func (f *fakeChain) onCall(addr, signature string, ret []byte) {
+	if f.returns[addr] == nil {
+		f.returns[addr] = make(map[string][]byte)
+	}
+	f.returns[addr][string(abi.Signature(signature))] = ret
+}
+
+func (f *fakeChain) TriggerConstantContractWithDataCtx(_ context.Context, _, contractAddress string, data []byte, _ ...client.ConstantCallOption) (*api.TransactionExtention, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	ret, ok := f.returns[contractAddress][string(data[:4])]
+	if !ok {
+		return &api.TransactionExtention{
+			Result:      &api.Return{Result: false, Message: []byte("REVERT opcode executed")},
+			Transaction: &core.Transaction{Ret: []*core.Transaction_Result{{ContractRet: core.Transaction_Result_REVERT}}},
+		}, nil
+	}
+	return &api.TransactionExtention{Result: &api.Return{Result: true}, ConstantResult: [][]byte{ret}}, nil
+}
+
+func (f *fakeChain) TriggerConstantContractCtx(context.Context, string, string, string, string, ...client.ConstantCallOption) (*api.TransactionExtention, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeChain) TriggerContractCtx(context.Context, string, string, string, string, int64, int64, string, int64) (*api.TransactionExtention, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeChain) TriggerContractWithDataCtx(context.Context, string, string, []byte, int64, int64, string, int64) (*api.TransactionExtention, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeChain) EstimateEnergyCtx(context.Context, string, string, string, string, int64, string, int64) (*api.EstimateEnergyMessage, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeChain) EstimateEnergyWithDataCtx(context.Context, string, string, []byte, int64, string, int64) (*api.EstimateEnergyMessage, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeChain) BroadcastCtx(context.Context, *core.Transaction) (*api.Return, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeChain) GetTransactionInfoByIDCtx(context.Context, string) (*core.TransactionInfo, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeChain) GetContractABICtx(_ context.Context, addr string) (*core.SmartContract_ABI, error) {
+	if a, ok := f.abis[addr]; ok {
+		return a, nil
+	}
+	return &core.SmartContract_ABI{}, nil
+}
+
+func (f *fakeChain) GetContractInfoCtx(_ context.Context, addr string) (*core.SmartContractDataWrapper, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &core.SmartContractDataWrapper{Runtimecode: f.code[addr]}, nil
+}
+
+type fakeStorage map[string]map[common.Hash][]byte
+
+func (s fakeStorage) StorageAtCtx(_ context.Context, addr string, slot common.Hash) ([]byte, error) {
+	if v, ok := s[addr][slot]; ok {
+		return v, nil
+	}
+	return make([]byte, 32), nil
+}
+
+func addressWord(t *testing.T, addr string) []byte {
+	a, err := address.Base58ToAddress(addr)
+	require.NoError(t, err)
+	return append(make([]byte, 12), a[1:]...)
+}
+
+func functionABI(names ...string) *core.SmartContract_ABI {
+	out := &core.SmartContract_ABI{}
+	for _, n := range names {
+		out.Entrys = append(out.Entrys, &core.SmartContract_ABI_Entry{Type: core.SmartContract_ABI_Entry_Function, Name: n})
+	}
+	return out
+}
+
+func TestDetectEIP1967FromStorage(t *testing.T) {
+	f := newFakeChain()
+	f.abis[implAddr] = functionABI("transfer")
+	storage := fakeStorage{proxyAddr: {
+		ImplementationSlot: addressWord(t, implAddr),
+		AdminSlot:          addressWord(t, beaconAddr),
+	}}
+	r := New(f, WithStorage(storage))
+
+	info, err := r.Detect(context.Background(), proxyAddr)
+	require.NoError(t, err)
+	assert.Equal(t, KindEIP1967, info.Kind)
+	assert.Equal(t, implAddr, info.Implementation)
+	assert.Equal(t, beaconAddr, info.Admin)
+
+	// A UUPS implementation reports the implementation slot.
+	f.onCall(implAddr, "proxiableUUID()", ImplementationSlot[:])
+	contractABI, info, err := r.ResolveABI(context.Background(), proxyAddr)
+	require.NoError(t, err)
+	assert.Equal(t, KindUUPS, info.Kind)
+	assert.Equal(t, "transfer", contractABI.Entrys[0].Name)
+}
+
+func TestDetectBeacon(t *testing.T) {
+	f := newFakeChain()
+	f.onCall(beaconAddr, "implementation()", addressWord(t, implAddr))
+	r := New(f, WithStorage(fakeStorage{proxyAddr: {BeaconSlot: addressWord(t, beaconAddr)}}))
+
+	info, err := r.Detect(context.Background(), proxyAddr)
+	require.NoError(t, err)
+	assert.Equal(t, KindBeacon, info.Kind)
+	assert.Equal(t, beaconAddr, info.Beacon)
+	assert.Equal(t, implAddr, info.Implementation)
+
+	// Without storage access the pattern is still recognised from the code.
+	f.code[proxyAddr] = append([]byte{0x7f}, BeaconSlot[:]...)
+	info, err = New(f).Detect(context.Background(), proxyAddr)
+	require.NoError(t, err)
+	assert.Equal(t, KindBeacon, info.Kind)
+	assert.Empty(t, info.Implementation)
+}
+
+func TestDetectMinimalProxy(t *testing.T) {
+	f := newFakeChain()
+	impl := addressWord(t, implAddr)[12:]
+	f.code[proxyAddr] = append(append(append([]byte{}, minimalPrefix...), impl...), minimalSuffix...)
+
+	info, err := New(f).Detect(context.Background(), proxyAddr)
+	require.NoError(t, err)
+	assert.Equal(t, KindMinimal, info.Kind)
+	assert.Equal(t, implAddr, info.Implementation)
+}
+
+func TestDetectGetter(t *testing.T) {
+	f := newFakeChain()
+	f.onCall(proxyAddr, "masterCopy()", addressWord(t, implAddr))
+
+	info, err := New(f).Detect(context.Background(), proxyAddr)
+	require.NoError(t, err)
+	assert.Equal(t, KindGetter, info.Kind)
+	assert.Equal(t, implAddr, info.Implementation)
+
+	// A getter on a proxy whose code uses the EIP-1967 slot.
+	f.code[proxyAddr] = append([]byte{0x7f}, ImplementationSlot[:]...)
+	info, err = New(f).Detect(context.Background(), proxyAddr)
+	require.NoError(t, err)
+	assert.Equal(t, KindEIP1967, info.Kind)
+}
+
+func TestDetectNone(t *testing.T) {
+	f := newFakeChain()
+	f.abis[proxyAddr] = functionABI("transfer")
+
+	contractABI, info, err := New(f).ResolveABI(context.Background(), proxyAddr)
+	require.NoError(t, err)
+	assert.False(t, info.IsProxy())
+	assert.Equal(t, f.abis[proxyAddr], contractABI)
+
+	_, err = New(f).Detect(context.Background(), "bad")
+	assert.ErrorContains(t, err, "invalid address")
+
+	f.err = errors.New("connection refused")
+	_, err = New(f).Detect(context.Background(), proxyAddr)
+	assert.ErrorContains(t, err, "connection refused")
+}
+
+func TestDiamondMergesFacets(t *testing.T) {
+	f := newFakeChain()
+	type facet struct {
+		FacetAddress      address.Address
+		FunctionSelectors [][4]byte
+	}
+	sel := func(sig string) [4]byte {
+		var s [4]byte
+		copy(s[:], abi.Signature(sig))
+		return s
+	}
+	a, err := address.Base58ToAddress(facetA)
+	require.NoError(t, err)
+	b, err := address.Base58ToAddress(facetB)
+	require.NoError(t, err)
+	ret, err := abi.PackArgs(facetsOutputs, []facet{
+		{FacetAddress: a, FunctionSelectors: [][4]byte{sel("transfer()"), sel("facets()")}},
+		{FacetAddress: b, FunctionSelectors: [][4]byte{sel("stake()")}},
+	})
+	require.NoError(t, err)
+	f.onCall(proxyAddr, "facets()", ret)
+
+	f.abis[proxyAddr] = &core.SmartContract_ABI{Entrys: []*core.SmartContract_ABI_Entry{
+		{Type: core.SmartContract_ABI_Entry_Fallback},
+	}}
+	f.abis[facetA] = functionABI("transfer", "notRouted", "facets")
+	f.abis[facetA].Entrys = append(f.abis[facetA].Entrys,
+		&core.SmartContract_ABI_Entry{Type: core.SmartContract_ABI_Entry_Event, Name: "Transfer"})
+	f.abis[facetB] = functionABI("stake", "facets")
+
+	contractABI, info, err := New(f).ResolveABI(context.Background(), proxyAddr)
+	require.NoError(t, err)
+	assert.Equal(t, KindDiamond, info.Kind)
+	require.Len(t, info.Facets, 2)
+	assert.Equal(t, facetA, info.Facets[0].Address)
+
+	var names []string
+	for _, e := range contractABI.Entrys {
+		names = append(names, e.Type.String()+":"+e.Name)
+	}
+	assert.Equal(t, []string{"Fallback:", "Function:transfer", "Function:facets", "Event:Transfer", "Function:stake"}, names)
+}
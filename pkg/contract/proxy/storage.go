@@ -0,0 +1,94 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+)
+
+// StorageReader reads a 32-byte storage slot of a contract.
+type StorageReader interface {
+	StorageAtCtx(ctx context.Context, contractAddress string, slot common.Hash) ([]byte, error)
+}
+
+// JSONRPCStorage reads storage through the eth_getStorageAt method of a
+// TRON JSON-RPC endpoint, such as https://api.trongrid.io/jsonrpc.
+type JSONRPCStorage struct {
+	URL string
+	// APIKey, if set, is sent as the TRON-PRO-API-KEY header.
+	APIKey string
+	// HTTPClient defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// NewJSONRPCStorage creates a StorageReader for the JSON-RPC endpoint url.
+func NewJSONRPCStorage(url string) *JSONRPCStorage {
+	return &JSONRPCStorage{URL: url}
+}
+
+type jsonRPCRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type jsonRPCResponse struct {
+	Result string `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// StorageAtCtx implements StorageReader.
+func (s *JSONRPCStorage) StorageAtCtx(ctx context.Context, contractAddress string, slot common.Hash) ([]byte, error) {
+	addr, err := address.Base58ToAddress(contractAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %s: %w", contractAddress, err)
+	}
+	body, err := json.Marshal(jsonRPCRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "eth_getStorageAt",
+		Params:  []interface{}{common.BytesToHexString(addr.Bytes()[1:]), slot.Hex(), "latest"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.APIKey != "" {
+		req.Header.Set("TRON-PRO-API-KEY", s.APIKey)
+	}
+	httpClient := s.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("eth_getStorageAt: HTTP %s", resp.Status)
+	}
+
+	var out jsonRPCResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("eth_getStorageAt: %w", err)
+	}
+	if out.Error != nil {
+		return nil, fmt.Errorf("eth_getStorageAt: %s (code %d)", out.Error.Message, out.Error.Code)
+	}
+	return common.FromHex(out.Result)
+}
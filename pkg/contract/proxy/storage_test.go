@@ -0,0 +1,42 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONRPCStorage(t *testing.T) {
+	var got jsonRPCRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "key", r.Header.Get("TRON-PRO-API-KEY"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		if got.Params[1] == AdminSlot.Hex() {
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"invalid slot"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x00000000000000000000000000000000000000000000000000000000000000ff"}`))
+	}))
+	defer srv.Close()
+
+	s := NewJSONRPCStorage(srv.URL)
+	s.APIKey = "key"
+	word, err := s.StorageAtCtx(context.Background(), proxyAddr, ImplementationSlot)
+	require.NoError(t, err)
+	assert.Len(t, word, 32)
+	assert.Equal(t, byte(0xff), word[31])
+	assert.Equal(t, "eth_getStorageAt", got.Method)
+	assert.Equal(t, []interface{}{"0xa614f803b6fd780986a42c78ec9c7f77e6ded13c", ImplementationSlot.Hex(), "latest"}, got.Params)
+
+	_, err = s.StorageAtCtx(context.Background(), proxyAddr, AdminSlot)
+	assert.ErrorContains(t, err, "invalid slot")
+
+	_, err = s.StorageAtCtx(context.Background(), "bad", common.Hash{})
+	assert.ErrorContains(t, err, "invalid address")
+}
@@ -1,5 +1,12 @@
 package contract
 
+import (
+	"fmt"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/abi"
+)
+
 // CallResult holds the decoded response from a constant (read-only) contract call.
 type CallResult struct {
 	// RawResults contains the raw byte slices returned by the contract call.
@@ -9,3 +16,33 @@ type CallResult struct {
 	// in the transaction's energy usage field.
 	EnergyUsed int64
 }
+
+// Decode unpacks the return data of method, as described by abiJSON, into
+// out. method is a name or a signature such as "balanceOf(address)".
+//
+// A single output is decoded into a pointer to a compatible Go value
+// (*big.Int, address.Address, string, slices, ...). Several outputs, and
+// tuples, are decoded into structs whose fields are matched by `abi:"name"`
+// tag or by name; see abi.CopyValues for the exact rules. Addresses are
+// returned as address.Address.
+func (r *CallResult) Decode(abiJSON, method string, out interface{}) error {
+	parsed, err := abi.ParseABI(abiJSON)
+	if err != nil {
+		return err
+	}
+	m, err := abi.FindMethod(parsed, method)
+	if err != nil {
+		return err
+	}
+	return r.decode(m, out)
+}
+
+func (r *CallResult) decode(m eABI.Method, out interface{}) error {
+	if len(r.RawResults) == 0 {
+		return fmt.Errorf("%s: %w", m.Sig, ErrEmptyResult)
+	}
+	if err := abi.UnpackInto(m.Outputs, r.RawResults[0], out); err != nil {
+		return fmt.Errorf("%s: %w", m.Sig, err)
+	}
+	return nil
+}
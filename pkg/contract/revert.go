@@ -0,0 +1,65 @@
+package contract
+
+import (
+	"context"
+	"strings"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// RevertError is returned by Call, CallInto, EstimateEnergy and
+// SendAndConfirm when the contract reverts. Custom errors are decoded against
+// the contract ABI (from WithABI, or fetched when the client implements
+// ABIFetcher); use errors.As to inspect the error name and arguments.
+type RevertError = abi.RevertError
+
+// revertMarker appears in node messages for calls that hit the REVERT
+// opcode (e.g. "REVERT opcode executed").
+const revertMarker = "REVERT"
+
+// constantRevert reports whether a constant call reverted, returning the
+// revert data and the node's message.
+func constantRevert(tx *api.TransactionExtention) ([]byte, string, bool) {
+	message := string(tx.GetResult().GetMessage())
+	ret := tx.GetTransaction().GetRet()
+	reverted := len(ret) > 0 && ret[0].GetContractRet() == core.Transaction_Result_REVERT
+	if !reverted && !strings.Contains(message, revertMarker) {
+		return nil, "", false
+	}
+	var data []byte
+	if results := tx.GetConstantResult(); len(results) > 0 {
+		data = results[0]
+	}
+	return data, message, true
+}
+
+// probeRevert replays the call as a constant call and returns the decoded
+// revert, or nil if the call does not revert.
+func (c *ContractCall) probeRevert(ctx context.Context, data []byte) *RevertError {
+	tx, err := c.triggerConstant(ctx, c.fromOrZero(), data)
+	if err != nil {
+		return nil
+	}
+	revertData, message, reverted := constantRevert(tx)
+	if !reverted {
+		return nil
+	}
+	return c.revertError(ctx, revertData, message)
+}
+
+// revertError decodes revert data, using the contract ABI on a best-effort
+// basis so built-in reasons are decoded even when no ABI is available.
+func (c *ContractCall) revertError(ctx context.Context, data []byte, message string) *RevertError {
+	var parsed *eABI.ABI
+	if len(data) >= 4 {
+		parsed, _ = c.resolveABI(ctx)
+	}
+	rerr := abi.DecodeRevert(parsed, data)
+	if rerr.Reason == "" {
+		rerr.Reason = message
+	}
+	return rerr
+}
@@ -27,6 +27,9 @@ import (
 	"github.com/btcsuite/btcd/btcec/v2"
 )
 
+// HardenedKeyStart is the first hardened child index.
+const HardenedKeyStart = 0x80000000
+
 // BIP44Params wraps BIP 44 params (5 level BIP 32 path).
 // To receive a canonical string representation ala
 // m / purpose' / coinType' / account' / change / addressIndex
@@ -172,12 +175,23 @@ func ComputeMastersFromSeed(seed []byte, masterSecret []byte) (secret [32]byte,
 // DerivePrivateKeyForPath derives the private key by following the BIP 32/44 path from privKeyBytes,
 // using the given chainCode.
 func DerivePrivateKeyForPath(curve elliptic.Curve, privKeyBytes [32]byte, chainCode [32]byte, path string) ([32]byte, error) {
-	data := privKeyBytes
+	indices, err := parsePath(path)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	derivedKey, _ := derivePath(curve, privKeyBytes, chainCode, indices)
+	return derivedKey, nil
+}
+
+// parsePath parses a BIP 32 path into child indices, with hardened indices
+// offset by HardenedKeyStart.
+func parsePath(path string) ([]uint32, error) {
 	path = strings.TrimPrefix(path, "m/")
 	parts := strings.Split(path, "/")
+	indices := make([]uint32, 0, len(parts))
 	for _, part := range parts {
 		if part == "" {
-			return [32]byte{}, errors.New("invalid BIP 32 path: empty segment")
+			return nil, errors.New("invalid BIP 32 path: empty segment")
 		}
 		// do we have an apostrophe?
 		harden := strings.HasSuffix(part, "'")
@@ -185,25 +199,30 @@ func DerivePrivateKeyForPath(curve elliptic.Curve, privKeyBytes [32]byte, chainC
 		if harden {
 			part = part[:len(part)-1]
 			if part == "" {
-				return [32]byte{}, errors.New("invalid BIP 32 path: empty segment")
+				return nil, errors.New("invalid BIP 32 path: empty segment")
 			}
 		}
 		idx, err := strconv.Atoi(part)
 		if err != nil {
-			return [32]byte{}, fmt.Errorf("invalid BIP 32 path: %s", err)
+			return nil, fmt.Errorf("invalid BIP 32 path: %s", err)
 		}
-		if idx < 0 {
-			return [32]byte{}, errors.New("invalid BIP 32 path: index negative or too large")
+		if idx < 0 || idx >= HardenedKeyStart {
+			return nil, errors.New("invalid BIP 32 path: index negative or too large")
 		}
-		data, chainCode = derivePrivateKey(curve, data, chainCode, uint32(idx), harden)
-	}
-	var derivedKey [32]byte
-	n := copy(derivedKey[:], data[:])
-	if n != 32 || len(data) != 32 {
-		return [32]byte{}, fmt.Errorf("expected a (secp256k1) key of length 32, got length: %v", len(data))
+		if harden {
+			idx += HardenedKeyStart
+		}
+		indices = append(indices, uint32(idx))
 	}
+	return indices, nil
+}
 
-	return derivedKey, nil
+// derivePath follows indices from the given key and chain code.
+func derivePath(curve elliptic.Curve, data [32]byte, chainCode [32]byte, indices []uint32) ([32]byte, [32]byte) {
+	for _, idx := range indices {
+		data, chainCode = derivePrivateKey(curve, data, chainCode, idx&^HardenedKeyStart, idx >= HardenedKeyStart)
+	}
+	return data, chainCode
 }
 
 // derivePrivateKey derives the private key with index and chainCode.
@@ -0,0 +1,192 @@
+package hd
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"golang.org/x/crypto/ripemd160" //nolint:staticcheck // BIP 32 fingerprints are defined with RIPEMD-160
+)
+
+// Serialization versions of BIP 32 extended keys (mainnet).
+var (
+	xpubVersion = [4]byte{0x04, 0x88, 0xb2, 0x1e}
+	xprvVersion = [4]byte{0x04, 0x88, 0xad, 0xe4}
+)
+
+// serializedKeyLength is the length of a serialized extended key before the
+// Base58Check checksum.
+const serializedKeyLength = 78
+
+var (
+	// ErrHardenedPublicDerivation is returned when a hardened child is
+	// requested from an extended public key.
+	ErrHardenedPublicDerivation = errors.New("cannot derive a hardened child from an extended public key")
+	// ErrInvalidChild is returned for the (astronomically unlikely) child
+	// indices that yield no valid key; BIP 32 says to skip to the next index.
+	ErrInvalidChild = errors.New("invalid child key, use the next index")
+)
+
+// ExtendedPublicKey is a BIP 32 extended public key. It derives the public
+// keys of its non-hardened descendants without any private key material,
+// which is what watch-only address generation needs.
+type ExtendedPublicKey struct {
+	Depth             uint8
+	ParentFingerprint [4]byte
+	ChildNumber       uint32
+	ChainCode         [32]byte
+	PublicKey         [33]byte // compressed SEC1 encoding
+}
+
+// NewExtendedPublicKey builds an extended public key from its parts.
+// pubKey may be compressed (33 bytes) or uncompressed (65 bytes).
+func NewExtendedPublicKey(pubKey []byte, chainCode [32]byte, depth uint8, parentFingerprint [4]byte, childNumber uint32) (*ExtendedPublicKey, error) {
+	pk, err := btcec.ParsePubKey(pubKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	k := &ExtendedPublicKey{
+		Depth:             depth,
+		ParentFingerprint: parentFingerprint,
+		ChildNumber:       childNumber,
+		ChainCode:         chainCode,
+	}
+	copy(k.PublicKey[:], pk.SerializeCompressed())
+	return k, nil
+}
+
+// ExtendedPublicKeyForPath derives the extended public key at the BIP 32
+// path from the master key and chain code, e.g. "44'/195'/0'" for a TRON
+// account.
+func ExtendedPublicKeyForPath(master [32]byte, chainCode [32]byte, path string) (*ExtendedPublicKey, error) {
+	indices, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(indices) > 255 {
+		return nil, errors.New("invalid BIP 32 path: deeper than 255 levels")
+	}
+	curve := btcec.S256()
+	parent, parentChain := derivePath(curve, master, chainCode, indices[:len(indices)-1])
+	defer common.ZeroBytes(parent[:])
+	last := indices[len(indices)-1]
+	key, childChain := derivePath(curve, parent, parentChain, indices[len(indices)-1:])
+	defer common.ZeroBytes(key[:])
+
+	_, parentPub := btcec.PrivKeyFromBytes(parent[:])
+	_, pub := btcec.PrivKeyFromBytes(key[:])
+	return NewExtendedPublicKey(pub.SerializeCompressed(), childChain, uint8(len(indices)), Fingerprint(parentPub.SerializeCompressed()), last)
+}
+
+// Fingerprint returns the BIP 32 fingerprint of a compressed public key:
+// the first 4 bytes of RIPEMD-160(SHA-256(pubKey)).
+func Fingerprint(pubKey []byte) [4]byte {
+	sha := sha256.Sum256(pubKey)
+	h := ripemd160.New()
+	_, _ = h.Write(sha[:])
+	var fp [4]byte
+	copy(fp[:], h.Sum(nil))
+	return fp
+}
+
+// Child derives the non-hardened child at index.
+func (k *ExtendedPublicKey) Child(index uint32) (*ExtendedPublicKey, error) {
+	if index >= HardenedKeyStart {
+		return nil, ErrHardenedPublicDerivation
+	}
+	if k.Depth == 255 {
+		return nil, errors.New("maximum derivation depth reached")
+	}
+	il, chainCode := i64(k.ChainCode[:], append(k.PublicKey[:], uint32ToBytes(index)...))
+	curve := btcec.S256()
+	ilNum := new(big.Int).SetBytes(il[:])
+	if ilNum.Cmp(curve.Params().N) >= 0 {
+		return nil, ErrInvalidChild
+	}
+	parent, err := btcec.ParsePubKey(k.PublicKey[:])
+	if err != nil {
+		return nil, err
+	}
+	ilX, ilY := curve.ScalarBaseMult(il[:])
+	x, y := curve.Add(ilX, ilY, parent.X(), parent.Y())
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return nil, ErrInvalidChild
+	}
+	// Marshal to the uncompressed form NewExtendedPublicKey parses.
+	uncompressed := elliptic.Marshal(curve, x, y) //nolint:staticcheck // secp256k1 is not a crypto/ecdh curve
+	return NewExtendedPublicKey(uncompressed, chainCode, k.Depth+1, Fingerprint(k.PublicKey[:]), index)
+}
+
+// DerivePath derives the descendant at a relative, non-hardened path such
+// as "0/5".
+func (k *ExtendedPublicKey) DerivePath(path string) (*ExtendedPublicKey, error) {
+	indices, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	child := k
+	for _, idx := range indices {
+		if child, err = child.Child(idx); err != nil {
+			return nil, err
+		}
+	}
+	return child, nil
+}
+
+// ToECDSA returns the public key for address derivation.
+func (k *ExtendedPublicKey) ToECDSA() *ecdsa.PublicKey {
+	// PublicKey is validated on construction.
+	pk, _ := btcec.ParsePubKey(k.PublicKey[:])
+	return pk.ToECDSA()
+}
+
+// String returns the Base58Check "xpub..." serialization.
+func (k *ExtendedPublicKey) String() string {
+	buf := make([]byte, 0, serializedKeyLength)
+	buf = append(buf, xpubVersion[:]...)
+	buf = append(buf, k.Depth)
+	buf = append(buf, k.ParentFingerprint[:]...)
+	buf = append(buf, uint32ToBytes(k.ChildNumber)...)
+	buf = append(buf, k.ChainCode[:]...)
+	buf = append(buf, k.PublicKey[:]...)
+	return common.EncodeCheck(buf)
+}
+
+// ParseExtendedPublicKey parses a Base58Check "xpub..." string.
+func ParseExtendedPublicKey(xpub string) (*ExtendedPublicKey, error) {
+	decoded, err := common.Decode(xpub)
+	if err != nil {
+		return nil, fmt.Errorf("invalid extended public key: %w", err)
+	}
+	if len(decoded) != serializedKeyLength+4 {
+		return nil, fmt.Errorf("invalid extended public key length %d, want %d", len(decoded), serializedKeyLength+4)
+	}
+	raw := decoded[:serializedKeyLength]
+	first := sha256.Sum256(raw)
+	second := sha256.Sum256(first[:])
+	if !bytes.Equal(second[:4], decoded[serializedKeyLength:]) {
+		return nil, errors.New("invalid extended public key: checksum mismatch")
+	}
+	switch {
+	case bytes.Equal(raw[:4], xprvVersion[:]):
+		return nil, errors.New("extended private key given, want an extended public key")
+	case !bytes.Equal(raw[:4], xpubVersion[:]):
+		return nil, fmt.Errorf("unsupported extended key version %x", raw[:4])
+	}
+	var parentFingerprint [4]byte
+	copy(parentFingerprint[:], raw[5:9])
+	var chainCode [32]byte
+	copy(chainCode[:], raw[13:45])
+	depth := raw[4]
+	if depth == 0 && parentFingerprint != ([4]byte{}) {
+		return nil, errors.New("invalid extended public key: zero depth with non-zero parent fingerprint")
+	}
+	return NewExtendedPublicKey(raw[45:], chainCode, depth, parentFingerprint, binary.BigEndian.Uint32(raw[9:13]))
+}
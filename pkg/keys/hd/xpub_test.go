@@ -0,0 +1,126 @@
+package hd
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// BIP 32 test vector 1 (seed 000102030405060708090a0b0c0d0e0f).
+const (
+	vector1Seed        = "000102030405060708090a0b0c0d0e0f"
+	vector1XpubH0      = "xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw"
+	vector1XpubH0_1    = "xpub6ASuArnXKPbfEwhqN6e3mwBcDTgzisQN1wXN9BJcM47sSikHjJf3UFHKkNAWbWMiGj7Wf5uMash7SyYq527Hqck2AxYysAA7xmALppuCkwQ"
+	vector1XpubH0_1_H2 = "xpub6D4BDPcP2GT577Vvch3R8wDkScZWzQzMMUm3PWbmWvVJrZwQY4VUNgqFJPMM3No2dFDFGTsxxpG5uJh7n7epu4trkrX7x7DogT5Uv6fcLW5"
+	vector1XpubLeaf    = "xpub6H1LXWLaKsWFhvm6RVpEL9P4KfRZSW7abD2ttkWP3SSQvnyA8FSVqNTEcYFgJS2UaFcxupHiYkro49S8yGasTvXEYBVPamhGW6cFJodrTHy"
+)
+
+func vector1Master(t *testing.T) ([32]byte, [32]byte) {
+	t.Helper()
+	seed, err := hex.DecodeString(vector1Seed)
+	require.NoError(t, err)
+	return ComputeMastersFromSeed(seed, masterSecret)
+}
+
+func TestExtendedPublicKeyForPath_BIP32Vector1(t *testing.T) {
+	master, ch := vector1Master(t)
+
+	xpub, err := ExtendedPublicKeyForPath(master, ch, "m/0'")
+	require.NoError(t, err)
+	assert.Equal(t, vector1XpubH0, xpub.String())
+
+	xpub, err = ExtendedPublicKeyForPath(master, ch, "0'/1/2'")
+	require.NoError(t, err)
+	assert.Equal(t, vector1XpubH0_1_H2, xpub.String())
+	assert.Equal(t, uint8(3), xpub.Depth)
+	assert.Equal(t, uint32(HardenedKeyStart+2), xpub.ChildNumber)
+}
+
+func TestExtendedPublicKey_PublicDerivationMatchesVector(t *testing.T) {
+	parent, err := ParseExtendedPublicKey(vector1XpubH0)
+	require.NoError(t, err)
+	child, err := parent.Child(1)
+	require.NoError(t, err)
+	assert.Equal(t, vector1XpubH0_1, child.String())
+
+	parent, err = ParseExtendedPublicKey(vector1XpubH0_1_H2)
+	require.NoError(t, err)
+	leaf, err := parent.DerivePath("2/1000000000")
+	require.NoError(t, err)
+	assert.Equal(t, vector1XpubLeaf, leaf.String())
+}
+
+func TestExtendedPublicKey_MatchesPrivateDerivation(t *testing.T) {
+	master, ch := ComputeMastersFromSeed(testSeed(t), masterSecret)
+
+	account, err := ExtendedPublicKeyForPath(master, ch, "44'/195'/0'")
+	require.NoError(t, err)
+
+	for _, path := range []string{"0/0", "0/7", "1/3"} {
+		child, err := account.DerivePath(path)
+		require.NoError(t, err)
+
+		priv, err := DerivePrivateKeyForPath(btcec.S256(), master, ch, "44'/195'/0'/"+path)
+		require.NoError(t, err)
+		_, pub := btcec.PrivKeyFromBytes(priv[:])
+		assert.Equal(t, pub.SerializeCompressed(), child.PublicKey[:], path)
+		assert.Equal(t, pub.ToECDSA().X, child.ToECDSA().X, path)
+	}
+}
+
+func TestExtendedPublicKey_RejectsHardenedChild(t *testing.T) {
+	xpub, err := ParseExtendedPublicKey(vector1XpubH0)
+	require.NoError(t, err)
+
+	_, err = xpub.Child(HardenedKeyStart)
+	assert.ErrorIs(t, err, ErrHardenedPublicDerivation)
+	_, err = xpub.DerivePath("0'")
+	assert.ErrorIs(t, err, ErrHardenedPublicDerivation)
+}
+
+func TestParseExtendedPublicKey_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{
+			name:    "bad checksum",
+			input:   vector1XpubH0[:len(vector1XpubH0)-1] + "x",
+			wantErr: "checksum",
+		},
+		{
+			name:    "extended private key",
+			input:   "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi",
+			wantErr: "extended private key",
+		},
+		{
+			name:    "too short",
+			input:   "xpub661MyMwAqRbc",
+			wantErr: "length",
+		},
+		{
+			name:    "not base58",
+			input:   "xpub0OIl",
+			wantErr: "invalid extended public key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseExtendedPublicKey(tt.input)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestExtendedPublicKey_RoundTrip(t *testing.T) {
+	xpub, err := ParseExtendedPublicKey(vector1XpubLeaf)
+	require.NoError(t, err)
+	assert.Equal(t, vector1XpubLeaf, xpub.String())
+	assert.Equal(t, uint8(5), xpub.Depth)
+	assert.Equal(t, uint32(1000000000), xpub.ChildNumber)
+}
@@ -92,12 +92,17 @@ func (fc *fileCache) scan(keyDir string) (mapset.Set, mapset.Set, mapset.Set, er
 	return creates, deletes, updates, nil
 }
 
-// nonKeyFile ignores editor backups, hidden files and folders/symlinks.
+// nonKeyFile ignores editor backups, hidden files, the HD wallet file and
+// folders/symlinks.
 func nonKeyFile(fi os.FileInfo) bool {
 	// Skip editor backups and UNIX-style hidden files.
 	if strings.HasSuffix(fi.Name(), "~") || strings.HasPrefix(fi.Name(), ".") {
 		return true
 	}
+	// The HD wallet holds an encrypted seed, not a key.
+	if fi.Name() == HDWalletFileName {
+		return true
+	}
 	// Skip misc special files, directories (yes, symlinks too).
 	if fi.IsDir() || fi.Mode()&os.ModeType != 0 {
 		return true
@@ -0,0 +1,482 @@
+package keystore
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/keys/hd"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/pborman/uuid"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// HDWalletScheme is the URL scheme of HD wallets and their derived accounts.
+	HDWalletScheme = "hdwallet"
+
+	// HDWalletFileName is the name of the HD wallet file inside a keystore
+	// directory. The account scanner skips it.
+	HDWalletFileName = "hdwallet.json"
+
+	// HardenedKeyStart is the first hardened child index (BIP-32).
+	HardenedKeyStart = hd.HardenedKeyStart
+
+	hdWalletVersion = 1
+)
+
+// ErrHDWalletExists is returned when creating an HD wallet in a directory
+// that already holds one.
+var ErrHDWalletExists = errors.New("hd wallet already exists")
+
+// DefaultBaseDerivationPath is the TRON BIP-44 base path m/44'/195'/0'/0;
+// the address index is appended to it.
+var DefaultBaseDerivationPath = DerivationPath{HardenedKeyStart + 44, HardenedKeyStart + 195, HardenedKeyStart + 0, 0}
+
+// TronDerivationPath returns the TRON BIP-44 path
+// m/44'/195'/account'/change/index.
+func TronDerivationPath(account, change, index uint32) DerivationPath {
+	return DerivationPath{HardenedKeyStart + 44, HardenedKeyStart + 195, HardenedKeyStart + account, change, index}
+}
+
+// ParseDerivationPath parses a BIP-32 path such as "m/44'/195'/0'/0/1".
+// Hardened components are suffixed with an apostrophe.
+func ParseDerivationPath(path string) (DerivationPath, error) {
+	path = strings.TrimPrefix(strings.TrimSpace(path), "m/")
+	if path == "" {
+		return nil, errors.New("empty derivation path")
+	}
+	var result DerivationPath
+	for _, component := range strings.Split(path, "/") {
+		hardened := strings.HasSuffix(component, "'")
+		component = strings.TrimSuffix(component, "'")
+		idx, err := strconv.ParseUint(component, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid derivation path component %q", component)
+		}
+		if idx >= HardenedKeyStart {
+			return nil, fmt.Errorf("derivation path component %d out of range", idx)
+		}
+		if hardened {
+			idx += HardenedKeyStart
+		}
+		result = append(result, uint32(idx))
+	}
+	return result, nil
+}
+
+// String returns the path in the "m/44'/195'/0'/0/0" notation.
+func (p DerivationPath) String() string {
+	var b strings.Builder
+	b.WriteString("m")
+	for _, component := range p {
+		if component >= HardenedKeyStart {
+			fmt.Fprintf(&b, "/%d'", component-HardenedKeyStart)
+		} else {
+			fmt.Fprintf(&b, "/%d", component)
+		}
+	}
+	return b.String()
+}
+
+type hdWalletJSON struct {
+	Version  int             `json:"version"`
+	ID       string          `json:"id"`
+	Crypto   CryptoJSON      `json:"crypto"`
+	Accounts []hdAccountJSON `json:"accounts"`
+}
+
+type hdAccountJSON struct {
+	Address string `json:"address"`
+	Path    string `json:"path"`
+}
+
+// HDWallet is a hierarchical deterministic wallet implementing Wallet. Its
+// BIP-39 seed is stored encrypted with the keystore's scrypt parameters and
+// decrypted into memory by Open; accounts pinned by Derive are persisted
+// with their paths so they are listed without the passphrase.
+type HDWallet struct {
+	file string
+
+	mu       sync.RWMutex
+	data     hdWalletJSON
+	accounts []Account
+	paths    map[string]DerivationPath // Base58 address -> path
+
+	master    *[32]byte // nil while the wallet is closed
+	chainCode *[32]byte
+}
+
+var _ Wallet = (*HDWallet)(nil)
+
+// NewHDWallet creates an HD wallet file at file holding seed encrypted with
+// passphrase. It fails with ErrHDWalletExists if file is already present.
+func NewHDWallet(file string, seed []byte, passphrase string, scryptN, scryptP int) (*HDWallet, error) {
+	if _, err := os.Stat(file); err == nil {
+		return nil, ErrHDWalletExists
+	}
+	cryptoJSON, err := EncryptDataV3(seed, []byte(passphrase), scryptN, scryptP)
+	if err != nil {
+		return nil, err
+	}
+	w := &HDWallet{
+		file: file,
+		data: hdWalletJSON{
+			Version: hdWalletVersion,
+			ID:      uuid.NewRandom().String(),
+			Crypto:  cryptoJSON,
+		},
+		paths: make(map[string]DerivationPath),
+	}
+	if err := w.save(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// LoadHDWallet reads the HD wallet stored at file. The wallet is returned
+// closed.
+func LoadHDWallet(file string) (*HDWallet, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	w := &HDWallet{file: file, paths: make(map[string]DerivationPath)}
+	if err := json.Unmarshal(raw, &w.data); err != nil {
+		return nil, fmt.Errorf("decode hd wallet %s: %w", file, err)
+	}
+	if w.data.Version != hdWalletVersion {
+		return nil, fmt.Errorf("unsupported hd wallet version %d", w.data.Version)
+	}
+	for _, a := range w.data.Accounts {
+		addr, err := address.Base58ToAddress(a.Address)
+		if err != nil {
+			return nil, fmt.Errorf("hd wallet account %s: %w", a.Address, err)
+		}
+		path, err := ParseDerivationPath(a.Path)
+		if err != nil {
+			return nil, fmt.Errorf("hd wallet account %s: %w", a.Address, err)
+		}
+		w.accounts = append(w.accounts, Account{Address: addr, URL: w.URL()})
+		w.paths[a.Address] = path
+	}
+	return w, nil
+}
+
+// save atomically writes the wallet file.
+func (w *HDWallet) save() error {
+	content, err := json.MarshalIndent(w.data, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmpName, err := writeTemporaryKeyFile(w.file, content)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmpName, w.file)
+}
+
+// URL implements Wallet, returning the location of the wallet file.
+func (w *HDWallet) URL() URL {
+	return URL{Scheme: HDWalletScheme, Path: w.file}
+}
+
+// Status implements Wallet, returning whether the seed is decrypted.
+func (w *HDWallet) Status() (string, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.master != nil {
+		return "Unlocked", nil
+	}
+	return "Locked", nil
+}
+
+// Open implements Wallet, decrypting the seed with passphrase and keeping
+// the master key in memory until Close.
+func (w *HDWallet) Open(passphrase string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.master != nil {
+		return ErrWalletAlreadyOpen
+	}
+	master, chainCode, err := w.decryptMaster(passphrase)
+	if err != nil {
+		return err
+	}
+	w.master, w.chainCode = &master, &chainCode
+	return nil
+}
+
+// Close implements Wallet, zeroing the master key.
+func (w *HDWallet) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.master != nil {
+		common.ZeroBytes(w.master[:])
+		common.ZeroBytes(w.chainCode[:])
+		w.master, w.chainCode = nil, nil
+	}
+	return nil
+}
+
+// Accounts implements Wallet, returning the pinned accounts.
+func (w *HDWallet) Accounts() []Account {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return append([]Account(nil), w.accounts...)
+}
+
+// Contains implements Wallet, reporting whether account is pinned in this
+// wallet.
+func (w *HDWallet) Contains(account Account) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	_, ok := w.paths[account.Address.String()]
+	return ok && (account.URL == (URL{}) || account.URL == w.URL())
+}
+
+// Path returns the derivation path of a pinned account.
+func (w *HDWallet) Path(account Account) (DerivationPath, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	path, ok := w.paths[account.Address.String()]
+	return path, ok
+}
+
+// Derive implements Wallet, deriving the account at path from the open
+// wallet. With pin set the account is added to the wallet file and signs
+// through the Sign* methods.
+func (w *HDWallet) Derive(path DerivationPath, pin bool) (Account, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.master == nil {
+		return Account{}, ErrWalletClosed
+	}
+	key, err := derivePrivateKey(*w.master, *w.chainCode, path)
+	if err != nil {
+		return Account{}, err
+	}
+	defer zeroKey(key)
+
+	account := Account{Address: address.PubkeyToAddress(key.PublicKey), URL: w.URL()}
+	if !pin {
+		return account, nil
+	}
+	addr := account.Address.String()
+	if _, ok := w.paths[addr]; ok {
+		return account, nil
+	}
+	w.data.Accounts = append(w.data.Accounts, hdAccountJSON{Address: addr, Path: path.String()})
+	if err := w.save(); err != nil {
+		w.data.Accounts = w.data.Accounts[:len(w.data.Accounts)-1]
+		return Account{}, err
+	}
+	w.accounts = append(w.accounts, account)
+	w.paths[addr] = path
+	return account, nil
+}
+
+// ExtendedPublicKey returns the BIP-32 extended public key at path, e.g.
+// the account level m/44'/195'/0', from which watch-only addresses can be
+// derived. The wallet must be open.
+func (w *HDWallet) ExtendedPublicKey(path DerivationPath) (*hd.ExtendedPublicKey, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.master == nil {
+		return nil, ErrWalletClosed
+	}
+	if len(path) == 0 {
+		return nil, errors.New("empty derivation path")
+	}
+	return hd.ExtendedPublicKeyForPath(*w.master, *w.chainCode, path.String())
+}
+
+// privateKey derives the key of a pinned account from the open wallet. The
+// caller must zero it.
+func (w *HDWallet) privateKey(account Account) (*ecdsa.PrivateKey, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	path, ok := w.paths[account.Address.String()]
+	if !ok {
+		return nil, ErrUnknownAccount
+	}
+	if w.master == nil {
+		return nil, ErrLocked
+	}
+	return derivePrivateKey(*w.master, *w.chainCode, path)
+}
+
+// privateKeyWithPassphrase derives the key of a pinned account, decrypting
+// the seed with passphrase. The caller must zero it.
+func (w *HDWallet) privateKeyWithPassphrase(account Account, passphrase string) (*ecdsa.PrivateKey, error) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	path, ok := w.paths[account.Address.String()]
+	if !ok {
+		return nil, ErrUnknownAccount
+	}
+	master, chainCode, err := w.decryptMaster(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer common.ZeroBytes(master[:])
+	defer common.ZeroBytes(chainCode[:])
+	return derivePrivateKey(master, chainCode, path)
+}
+
+// decryptMaster decrypts the seed and computes the BIP-32 master key.
+func (w *HDWallet) decryptMaster(passphrase string) (master, chainCode [32]byte, err error) {
+	seed, err := DecryptDataV3(w.data.Crypto, passphrase)
+	if err != nil {
+		return master, chainCode, err
+	}
+	defer common.ZeroBytes(seed)
+	master, chainCode = hd.ComputeMastersFromSeed(seed, []byte("Bitcoin seed"))
+	return master, chainCode, nil
+}
+
+// derivePrivateKey derives the secp256k1 key at path.
+func derivePrivateKey(master, chainCode [32]byte, path DerivationPath) (*ecdsa.PrivateKey, error) {
+	if len(path) == 0 {
+		return nil, errors.New("empty derivation path")
+	}
+	derived, err := hd.DerivePrivateKeyForPath(btcec.S256(), master, chainCode, path.String())
+	if err != nil {
+		return nil, err
+	}
+	defer common.ZeroBytes(derived[:])
+	return crypto.ToECDSA(derived[:])
+}
+
+// signHash signs hash with the key of account, using passphrase to decrypt
+// the seed when non-nil.
+func (w *HDWallet) signHash(account Account, passphrase *string, hash []byte) ([]byte, error) {
+	var (
+		key *ecdsa.PrivateKey
+		err error
+	)
+	if passphrase != nil {
+		key, err = w.privateKeyWithPassphrase(account, *passphrase)
+	} else {
+		key, err = w.privateKey(account)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer zeroKey(key)
+	return crypto.Sign(hash, key)
+}
+
+// signTx appends the signature of account to tx.
+func (w *HDWallet) signTx(account Account, passphrase *string, tx *core.Transaction) (*core.Transaction, error) {
+	rawData, err := proto.Marshal(tx.GetRawData())
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(rawData)
+	signature, err := w.signHash(account, passphrase, hash[:])
+	if err != nil {
+		return nil, err
+	}
+	tx.Signature = append(tx.Signature, signature)
+	return tx, nil
+}
+
+// SignData signs keccak256(data) with a pinned account of the open wallet.
+func (w *HDWallet) SignData(account Account, mimeType string, data []byte) ([]byte, error) {
+	return w.signHash(account, nil, crypto.Keccak256(data))
+}
+
+// SignDataWithPassphrase signs keccak256(data), decrypting the seed with
+// passphrase.
+func (w *HDWallet) SignDataWithPassphrase(account Account, passphrase, mimeType string, data []byte) ([]byte, error) {
+	return w.signHash(account, &passphrase, crypto.Keccak256(data))
+}
+
+// SignText signs the TRON message hash of text with a pinned account of the
+// open wallet.
+func (w *HDWallet) SignText(account Account, text []byte, useFixedLength ...bool) ([]byte, error) {
+	return w.signHash(account, nil, TextHash(text, useFixedLength...))
+}
+
+// SignTextWithPassphrase signs the TRON message hash of text, decrypting
+// the seed with passphrase.
+func (w *HDWallet) SignTextWithPassphrase(account Account, passphrase string, text []byte) ([]byte, error) {
+	return w.signHash(account, &passphrase, TextHash(text))
+}
+
+// SignTx signs tx with a pinned account of the open wallet.
+func (w *HDWallet) SignTx(account Account, tx *core.Transaction) (*core.Transaction, error) {
+	return w.signTx(account, nil, tx)
+}
+
+// SignTxWithPassphrase signs tx, decrypting the seed with passphrase.
+func (w *HDWallet) SignTxWithPassphrase(account Account, passphrase string, tx *core.Transaction) (*core.Transaction, error) {
+	return w.signTx(account, &passphrase, tx)
+}
+
+// NewHDWallet stores seed as the HD wallet of this keystore's directory,
+// encrypted with passphrase and the keystore's scrypt parameters.
+func (ks *KeyStore) NewHDWallet(seed []byte, passphrase string) (*HDWallet, error) {
+	N, P := StandardScryptN, StandardScryptP
+	if store, ok := ks.storage.(*keyStorePassphrase); ok {
+		N, P = store.scryptN, store.scryptP
+	}
+	return NewHDWallet(ks.storage.JoinPath(HDWalletFileName), seed, passphrase, N, P)
+}
+
+// HDWallet loads the HD wallet of this keystore's directory. The error
+// wraps os.ErrNotExist when the directory has none.
+func (ks *KeyStore) HDWallet() (*HDWallet, error) {
+	return LoadHDWallet(ks.storage.JoinPath(HDWalletFileName))
+}
+
+// UnlockDerived unlocks a pinned account of the open HD wallet w in the
+// keystore, so it signs through SignTx and SignHash like an account
+// unlocked with Unlock. It stays unlocked until Lock or Close.
+func (ks *KeyStore) UnlockDerived(w *HDWallet, a Account) error {
+	key, err := w.privateKey(a)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(address.PubkeyToAddress(key.PublicKey), a.Address) {
+		zeroKey(key)
+		return ErrUnknownAccount
+	}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	if u, found := ks.unlocked[a.Address.String()]; found {
+		if u.abort != nil {
+			close(u.abort)
+		}
+		zeroKey(u.PrivateKey)
+	}
+	ks.unlocked[a.Address.String()] = &unlocked{Key: &Key{
+		ID:         uuid.NewRandom(),
+		Address:    a.Address,
+		PrivateKey: key,
+	}}
+	return nil
+}
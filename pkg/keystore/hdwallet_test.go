@@ -0,0 +1,201 @@
+package keystore
+
+import (
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/mnemonic"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func newTestHDWallet(t *testing.T, passphrase string) (*HDWallet, *KeyStore) {
+	t.Helper()
+	ks := NewKeyStore(t.TempDir(), LightScryptN, LightScryptP)
+	t.Cleanup(ks.Close)
+	seed, err := mnemonic.Seed(testMnemonic, "")
+	require.NoError(t, err)
+	w, err := ks.NewHDWallet(seed, passphrase)
+	require.NoError(t, err)
+	return w, ks
+}
+
+func TestDerivationPath_ParseAndString(t *testing.T) {
+	path, err := ParseDerivationPath("m/44'/195'/1'/0/7")
+	require.NoError(t, err)
+	assert.Equal(t, TronDerivationPath(1, 0, 7), path)
+	assert.Equal(t, "m/44'/195'/1'/0/7", path.String())
+
+	withoutPrefix, err := ParseDerivationPath("44'/195'/0'/0")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultBaseDerivationPath, withoutPrefix)
+
+	for _, bad := range []string{"", "m/", "m/44'/x", "m/44'//0", "m/2147483648"} {
+		_, err := ParseDerivationPath(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestHDWallet_DeriveMatchesMnemonicDerivation(t *testing.T) {
+	w, _ := newTestHDWallet(t, "pass")
+	require.NoError(t, w.Open("pass"))
+	defer func() { _ = w.Close() }()
+
+	for _, index := range []int{0, 1, 5} {
+		sk, _ := mnemonic.FromSeedAndPassphrase(testMnemonic, "", index)
+		require.NotNil(t, sk)
+		want := address.PubkeyToAddress(sk.ToECDSA().PublicKey)
+
+		acct, err := w.Derive(TronDerivationPath(0, 0, uint32(index)), false)
+		require.NoError(t, err)
+		assert.Equal(t, want.String(), acct.Address.String())
+	}
+	assert.Empty(t, w.Accounts(), "unpinned derivations are not tracked")
+}
+
+func TestHDWallet_OpenAndClose(t *testing.T) {
+	w, _ := newTestHDWallet(t, "pass")
+
+	status, err := w.Status()
+	require.NoError(t, err)
+	assert.Equal(t, "Locked", status)
+
+	_, err = w.Derive(TronDerivationPath(0, 0, 0), false)
+	assert.ErrorIs(t, err, ErrWalletClosed)
+
+	assert.ErrorIs(t, w.Open("wrong"), ErrDecrypt)
+	require.NoError(t, w.Open("pass"))
+	assert.ErrorIs(t, w.Open("pass"), ErrWalletAlreadyOpen)
+
+	status, err = w.Status()
+	require.NoError(t, err)
+	assert.Equal(t, "Unlocked", status)
+
+	require.NoError(t, w.Close())
+	status, err = w.Status()
+	require.NoError(t, err)
+	assert.Equal(t, "Locked", status)
+}
+
+func TestHDWallet_PinnedAccountsPersist(t *testing.T) {
+	w, ks := newTestHDWallet(t, "pass")
+	require.NoError(t, w.Open("pass"))
+
+	first, err := w.Derive(TronDerivationPath(0, 0, 0), true)
+	require.NoError(t, err)
+	change, err := w.Derive(TronDerivationPath(2, 1, 3), true)
+	require.NoError(t, err)
+	_, err = w.Derive(TronDerivationPath(0, 0, 0), true)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	loaded, err := ks.HDWallet()
+	require.NoError(t, err)
+	accounts := loaded.Accounts()
+	require.Len(t, accounts, 2)
+	assert.Equal(t, first.Address, accounts[0].Address)
+	assert.Equal(t, change.Address, accounts[1].Address)
+	assert.True(t, loaded.Contains(Account{Address: change.Address}))
+
+	path, ok := loaded.Path(change)
+	require.True(t, ok)
+	assert.Equal(t, "m/44'/195'/2'/1/3", path.String())
+
+	assert.Empty(t, ks.Accounts(), "the wallet file is not a key file")
+}
+
+func TestHDWallet_NewFailsWhenExists(t *testing.T) {
+	_, ks := newTestHDWallet(t, "pass")
+	_, err := ks.NewHDWallet([]byte("seed"), "pass")
+	assert.ErrorIs(t, err, ErrHDWalletExists)
+}
+
+func TestLoadHDWallet_Missing(t *testing.T) {
+	_, err := LoadHDWallet(filepath.Join(t.TempDir(), HDWalletFileName))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestHDWallet_SignTx(t *testing.T) {
+	w, _ := newTestHDWallet(t, "pass")
+	require.NoError(t, w.Open("pass"))
+	acct, err := w.Derive(TronDerivationPath(0, 0, 0), true)
+	require.NoError(t, err)
+
+	tx := &core.Transaction{RawData: &core.TransactionRaw{RefBlockBytes: []byte{1, 2}}}
+	signed, err := w.SignTx(acct, tx)
+	require.NoError(t, err)
+	require.Len(t, signed.Signature, 1)
+
+	rawData, err := proto.Marshal(tx.GetRawData())
+	require.NoError(t, err)
+	hash := sha256.Sum256(rawData)
+	pub, err := crypto.SigToPub(hash[:], signed.Signature[0])
+	require.NoError(t, err)
+	assert.Equal(t, acct.Address.String(), address.PubkeyToAddress(*pub).String())
+
+	require.NoError(t, w.Close())
+	_, err = w.SignTx(acct, tx)
+	assert.ErrorIs(t, err, ErrLocked)
+
+	sig, err := w.SignTxWithPassphrase(acct, "pass", &core.Transaction{RawData: tx.RawData})
+	require.NoError(t, err)
+	assert.Equal(t, signed.Signature[0], sig.Signature[0])
+}
+
+func TestHDWallet_SignUnknownAccount(t *testing.T) {
+	w, _ := newTestHDWallet(t, "pass")
+	require.NoError(t, w.Open("pass"))
+	acct, err := w.Derive(TronDerivationPath(0, 0, 9), false)
+	require.NoError(t, err)
+
+	_, err = w.SignData(acct, "", []byte("data"))
+	assert.ErrorIs(t, err, ErrUnknownAccount)
+}
+
+func TestKeyStore_UnlockDerived(t *testing.T) {
+	w, ks := newTestHDWallet(t, "pass")
+	require.NoError(t, w.Open("pass"))
+	acct, err := w.Derive(TronDerivationPath(0, 0, 1), true)
+	require.NoError(t, err)
+
+	require.NoError(t, ks.UnlockDerived(w, acct))
+	require.NoError(t, w.Close())
+
+	hash := crypto.Keccak256([]byte("hello"))
+	sig, err := ks.SignHash(acct, hash)
+	require.NoError(t, err)
+	pub, err := crypto.SigToPub(hash, sig)
+	require.NoError(t, err)
+	assert.Equal(t, acct.Address.String(), address.PubkeyToAddress(*pub).String())
+
+	require.NoError(t, ks.Lock(acct.Address))
+	_, err = ks.SignHash(acct, hash)
+	assert.ErrorIs(t, err, ErrLocked)
+}
+
+func TestHDWallet_ExtendedPublicKey(t *testing.T) {
+	w, _ := newTestHDWallet(t, "pass")
+	path := DerivationPath{HardenedKeyStart + 44, HardenedKeyStart + 195, HardenedKeyStart + 0}
+
+	_, err := w.ExtendedPublicKey(path)
+	assert.ErrorIs(t, err, ErrWalletClosed)
+
+	require.NoError(t, w.Open("pass"))
+	defer func() { _ = w.Close() }()
+	xpub, err := w.ExtendedPublicKey(path)
+	require.NoError(t, err)
+
+	child, err := xpub.DerivePath("0/4")
+	require.NoError(t, err)
+	acct, err := w.Derive(TronDerivationPath(0, 0, 4), false)
+	require.NoError(t, err)
+	assert.Equal(t, acct.Address.String(), address.PubkeyToAddress(*child.ToECDSA()).String())
+}
@@ -7,7 +7,9 @@ import (
 
 	"golang.org/x/crypto/sha3"
 
+	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/keys/hd"
 )
 
 // Device abstracts a hardware wallet for testability and multi-device support.
@@ -16,6 +18,8 @@ type Device interface {
 	GetAddress() (string, error)
 	// SignTransaction signs a raw transaction and returns the signature.
 	SignTransaction(ctx context.Context, tx []byte) ([]byte, error)
+	// SignMessage signs a TIP-191 personal message and returns the signature.
+	SignMessage(ctx context.Context, msg []byte) ([]byte, error)
 	// Close releases the device connection.
 	Close() error
 }
@@ -23,6 +27,39 @@ type Device interface {
 // ensure NanoS implements Device at compile time.
 var _ Device = (*NanoS)(nil)
 
+// ExtendedKeyDevice is implemented by devices that export a public key
+// together with its BIP-32 chain code.
+type ExtendedKeyDevice interface {
+	// GetPublicKey returns the public key and chain code at path.
+	GetPublicKey(path []uint32) ([]byte, [32]byte, error)
+}
+
+var _ ExtendedKeyDevice = (*NanoS)(nil)
+
+// ExtendedPublicKey reads the extended public key of the TRON account
+// m/44'/195'/account' from dev. Watch-only addresses m/.../change/index
+// are derived from it without the device.
+func ExtendedPublicKey(dev ExtendedKeyDevice, account uint32) (*hd.ExtendedPublicKey, error) {
+	if account >= hd.HardenedKeyStart {
+		return nil, fmt.Errorf("account %d out of range", account)
+	}
+	parentPath := []uint32{hd.HardenedKeyStart + 44, hd.HardenedKeyStart + 195}
+	parentKey, _, err := dev.GetPublicKey(parentPath)
+	if err != nil {
+		return nil, fmt.Errorf("ledger public key: %w", err)
+	}
+	parent, err := btcec.ParsePubKey(parentKey)
+	if err != nil {
+		return nil, fmt.Errorf("ledger public key: %w", err)
+	}
+	childNumber := hd.HardenedKeyStart + account
+	key, chainCode, err := dev.GetPublicKey(append(parentPath, childNumber))
+	if err != nil {
+		return nil, fmt.Errorf("ledger public key: %w", err)
+	}
+	return hd.NewExtendedPublicKey(key, chainCode, uint8(len(parentPath)+1), hd.Fingerprint(parent.SerializeCompressed()), childNumber)
+}
+
 // OpenDevice opens a Ledger Nano S and returns it as a Device.
 // This is the recommended entry point for callers.
 func OpenDevice() (Device, error) {
@@ -65,6 +102,17 @@ func (n *NanoS) SignTransaction(_ context.Context, tx []byte) ([]byte, error) {
 	return sig[:], nil
 }
 
+// SignMessage signs msg as a TIP-191 personal message using the Ledger
+// device. The device hashes keccak256("\x19TRON Signed Message:\n" +
+// len(msg) + msg) and returns the 65-byte signature.
+func (n *NanoS) SignMessage(_ context.Context, msg []byte) ([]byte, error) {
+	sig, err := n.SignPersonalMessage(msg)
+	if err != nil {
+		return nil, fmt.Errorf("ledger sign message: %w", err)
+	}
+	return sig[:], nil
+}
+
 // GetAddress returns the TRON address from the connected Ledger device.
 // Deprecated: Use OpenDevice() and call GetAddress() on the Device instead.
 func GetAddress() string {
@@ -90,3 +138,14 @@ func SignTx(tx []byte) ([]byte, error) {
 	defer func() { _ = dev.Close() }()
 	return dev.SignTransaction(context.Background(), tx)
 }
+
+// SignMessage signs msg as a TIP-191 personal message using a newly opened
+// Ledger device.
+func SignMessage(msg []byte) ([]byte, error) {
+	dev, err := OpenNanoS()
+	if err != nil {
+		return nil, fmt.Errorf("open ledger: %w", err)
+	}
+	defer func() { _ = dev.Close() }()
+	return dev.SignMessage(context.Background(), msg)
+}
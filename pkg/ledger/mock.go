@@ -12,15 +12,21 @@ type MockDevice struct {
 	// SignTransactionFn is called by SignTransaction. When nil, the stored
 	// Signature and Err fields are returned instead.
 	SignTransactionFn func(ctx context.Context, tx []byte) ([]byte, error)
+	// SignMessageFn is called by SignMessage. When nil, the stored
+	// Signature and Err fields are returned instead.
+	SignMessageFn func(ctx context.Context, msg []byte) ([]byte, error)
+	// GetPublicKeyFn is called by GetPublicKey. When nil, Err is returned.
+	GetPublicKeyFn func(path []uint32) ([]byte, [32]byte, error)
 	// CloseFn is called by Close. When nil, nil is returned.
 	CloseFn func() error
 
 	// Address is returned by GetAddress when GetAddressFn is nil.
 	Address string
-	// Signature is returned by SignTransaction when SignTransactionFn is nil.
-	Signature []byte
-	// Err is returned by both GetAddress and SignTransaction when their
+	// Signature is returned by SignTransaction and SignMessage when their
 	// respective function fields are nil.
+	Signature []byte
+	// Err is returned by GetAddress, SignTransaction and SignMessage when
+	// their respective function fields are nil.
 	Err error
 }
 
@@ -40,6 +46,22 @@ func (m *MockDevice) SignTransaction(ctx context.Context, tx []byte) ([]byte, er
 	return m.Signature, m.Err
 }
 
+// SignMessage returns the configured signature or calls SignMessageFn.
+func (m *MockDevice) SignMessage(ctx context.Context, msg []byte) ([]byte, error) {
+	if m.SignMessageFn != nil {
+		return m.SignMessageFn(ctx, msg)
+	}
+	return m.Signature, m.Err
+}
+
+// GetPublicKey calls GetPublicKeyFn, or returns Err.
+func (m *MockDevice) GetPublicKey(path []uint32) ([]byte, [32]byte, error) {
+	if m.GetPublicKeyFn != nil {
+		return m.GetPublicKeyFn(path)
+	}
+	return nil, [32]byte{}, m.Err
+}
+
 // Close calls CloseFn if set, otherwise returns nil.
 func (m *MockDevice) Close() error {
 	if m.CloseFn != nil {
@@ -3,7 +3,11 @@ package ledger
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/keys/hd"
 )
 
 func TestMockDevice_GetAddress(t *testing.T) {
@@ -76,6 +80,26 @@ func TestMockDevice_SignTransaction(t *testing.T) {
 	})
 }
 
+func TestMockDevice_SignMessage(t *testing.T) {
+	dev := &MockDevice{
+		SignMessageFn: func(_ context.Context, msg []byte) ([]byte, error) {
+			return append([]byte{0xCC}, msg...), nil
+		},
+	}
+	got, err := dev.SignMessage(context.Background(), []byte("hi"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got[0] != 0xCC || string(got[1:]) != "hi" {
+		t.Fatalf("unexpected result: %x", got)
+	}
+
+	dev = &MockDevice{Signature: []byte{1}}
+	if got, _ := dev.SignMessage(context.Background(), nil); len(got) != 1 {
+		t.Fatalf("got %x, want configured signature", got)
+	}
+}
+
 func TestMockDevice_Close(t *testing.T) {
 	t.Run("returns nil by default", func(t *testing.T) {
 		dev := &MockDevice{}
@@ -97,3 +121,36 @@ func TestMockDevice_Close(t *testing.T) {
 
 // Verify MockDevice implements Device at compile time.
 var _ Device = (*MockDevice)(nil)
+
+func TestExtendedPublicKey(t *testing.T) {
+	master, chainCode := hd.ComputeMastersFromSeed([]byte("ledger extended key test seed"), []byte("Bitcoin seed"))
+	dev := &MockDevice{
+		GetPublicKeyFn: func(path []uint32) ([]byte, [32]byte, error) {
+			parts := make([]string, len(path))
+			for i, c := range path {
+				parts[i] = fmt.Sprintf("%d'", c-hd.HardenedKeyStart)
+			}
+			xpub, err := hd.ExtendedPublicKeyForPath(master, chainCode, strings.Join(parts, "/"))
+			if err != nil {
+				return nil, [32]byte{}, err
+			}
+			return xpub.PublicKey[:], xpub.ChainCode, nil
+		},
+	}
+
+	got, err := ExtendedPublicKey(dev, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want, err := hd.ExtendedPublicKeyForPath(master, chainCode, "44'/195'/2'")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.String() != want.String() {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	if _, err := ExtendedPublicKey(&MockDevice{Err: errors.New("device error")}, 0); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -14,6 +14,8 @@ import (
 const (
 	signatureSize int = 65
 	packetSize    int = 255
+	// maxPathDepth is the deepest BIP-32 path the TRON app accepts.
+	maxPathDepth = 10
 )
 
 // DEBUG enables verbose HID frame logging when true.
@@ -184,10 +186,15 @@ const (
 	cmdSignStaking  = 0x04
 	cmdSignTx       = 0x08
 
+	// cmdSignPersonalMessage is INS_SIGN_PERSONAL_MESSAGE of the TRON app,
+	// which hashes the message with the length-prefixed TIP-191 header.
+	cmdSignPersonalMessage = 0x08
+
 	p1First = 0x0
 	p1More  = 0x80
 
 	p2DisplayAddress = 0x00
+	p2ChainCode      = 0x01
 	p2DisplayHash    = 0x00
 	p2SignHash       = 0x01
 	p2Finish         = 0x02
@@ -218,6 +225,36 @@ func (n *NanoS) GetAddress() (addr string, err error) {
 	return string(pubkey[:]), nil
 }
 
+// GetPublicKey returns the uncompressed public key and BIP-32 chain code at
+// path without displaying the address. The response is the public key and
+// the address, each length-prefixed, followed by the chain code.
+func (n *NanoS) GetPublicKey(path []uint32) (pubkey []byte, chainCode [32]byte, err error) {
+	if len(path) == 0 || len(path) > maxPathDepth {
+		return nil, chainCode, fmt.Errorf("derivation path must have 1 to %d components", maxPathDepth)
+	}
+	payload := make([]byte, 1+4*len(path))
+	payload[0] = byte(len(path))
+	for i, component := range path {
+		binary.BigEndian.PutUint32(payload[1+4*i:], component)
+	}
+	resp, err := n.Exchange(cmdGetPublicKey, 0, p2ChainCode, payload)
+	if err != nil {
+		return nil, chainCode, err
+	}
+
+	if len(resp) < 1 || len(resp) < 2+int(resp[0]) {
+		return nil, chainCode, errors.New("public key response too short")
+	}
+	pubkey = resp[1 : 1+int(resp[0])]
+	rest := resp[1+int(resp[0]):]
+	addrLen := int(rest[0])
+	if len(rest) != 1+addrLen+len(chainCode) {
+		return nil, chainCode, errors.New("chain code missing from public key response")
+	}
+	copy(chainCode[:], rest[1+addrLen:])
+	return pubkey, chainCode, nil
+}
+
 // SignTxn signs a raw transaction using the Ledger device and returns the 65-byte signature.
 func (n *NanoS) SignTxn(txn []byte) (sig [signatureSize]byte, err error) {
 	var resp []byte
@@ -236,6 +273,32 @@ func (n *NanoS) SignTxn(txn []byte) (sig [signatureSize]byte, err error) {
 	return
 }
 
+// SignPersonalMessage signs msg as a TIP-191 personal message and returns the
+// 65-byte signature. The message is streamed in packet-sized chunks, the
+// first prefixed with its 4-byte big-endian length.
+func (n *NanoS) SignPersonalMessage(msg []byte) (sig [signatureSize]byte, err error) {
+	payload := make([]byte, 4+len(msg))
+	binary.BigEndian.PutUint32(payload, uint32(len(msg)))
+	copy(payload[4:], msg)
+
+	var resp []byte
+	var p1 byte = p1First
+	for len(payload) > 0 {
+		size := min(len(payload), packetSize)
+		resp, err = n.Exchange(cmdSignPersonalMessage, p1, 0, payload[:size])
+		if err != nil {
+			return [signatureSize]byte{}, err
+		}
+		payload = payload[size:]
+		p1 = p1More
+	}
+
+	if copy(sig[:], resp) != len(sig) {
+		return [signatureSize]byte{}, errors.New("signature has wrong length")
+	}
+	return
+}
+
 // OpenNanoS detects and opens a connection to a Ledger Nano S device.
 func OpenNanoS() (*NanoS, error) {
 	const (
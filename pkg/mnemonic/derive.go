@@ -36,3 +36,12 @@ func FromSeedAndPassphrase(mnemonic, passphrase string, index int) (*btcec.Priva
 	common.ZeroBytes(private[:])
 	return sk, pk
 }
+
+// Seed returns the BIP39 seed of mnemonic and passphrase, the input of HD
+// wallet derivation. The caller should zero the seed when done with it.
+func Seed(mnemonic, passphrase string) ([]byte, error) {
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, ErrInvalidMnemonic
+	}
+	return bip39.NewSeed(mnemonic, passphrase), nil
+}
@@ -24,6 +24,16 @@ func (s *keystoreSigner) Sign(tx *core.Transaction) (*core.Transaction, error) {
 	return s.ks.SignTx(s.acct, tx)
 }
 
+// SignHash signs a 32-byte digest using the keystore's unlocked key.
+func (s *keystoreSigner) SignHash(hash []byte) ([]byte, error) {
+	return s.ks.SignHash(s.acct, hash)
+}
+
+// SignMessage signs message in the TIP-191 format using the unlocked key.
+func (s *keystoreSigner) SignMessage(message []byte, format MessageFormat) ([]byte, error) {
+	return signMessageHash(s.SignHash, message, format)
+}
+
 // Address returns the TRON address of the keystore account.
 func (s *keystoreSigner) Address() address.Address {
 	return s.acct.Address
@@ -49,6 +59,17 @@ func (s *keystorePassphraseSigner) Sign(tx *core.Transaction) (*core.Transaction
 	return s.ks.SignTxWithPassphrase(s.acct, s.passphrase, tx)
 }
 
+// SignHash signs a 32-byte digest by decrypting the key with the passphrase.
+func (s *keystorePassphraseSigner) SignHash(hash []byte) ([]byte, error) {
+	return s.ks.SignHashWithPassphrase(s.acct, s.passphrase, hash)
+}
+
+// SignMessage signs message in the TIP-191 format by decrypting the key with
+// the passphrase.
+func (s *keystorePassphraseSigner) SignMessage(message []byte, format MessageFormat) ([]byte, error) {
+	return signMessageHash(s.SignHash, message, format)
+}
+
 // Address returns the TRON address of the keystore account.
 func (s *keystorePassphraseSigner) Address() address.Address {
 	return s.acct.Address
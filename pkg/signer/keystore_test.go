@@ -174,3 +174,25 @@ func TestKeystoreSigner_MultiSign(t *testing.T) {
 	assert.NotEqual(t, tx.Signature[0], tx.Signature[1],
 		"different keys should produce different signatures")
 }
+
+func TestKeystoreSigners_SignHash(t *testing.T) {
+	ks, acct := newTestKeystoreAndAccount(t, "pass")
+	hash := make([]byte, 32)
+	hash[0] = 1
+
+	passSigner, ok := NewKeystorePassphraseSigner(ks, acct, "pass").(HashSigner)
+	require.True(t, ok)
+	sig1, err := passSigner.SignHash(hash)
+	require.NoError(t, err)
+	assert.Len(t, sig1, 65)
+
+	unlockedSigner, ok := NewKeystoreSigner(ks, acct).(HashSigner)
+	require.True(t, ok)
+	_, err = unlockedSigner.SignHash(hash)
+	require.Error(t, err, "locked account")
+
+	require.NoError(t, ks.Unlock(acct, "pass"))
+	sig2, err := unlockedSigner.SignHash(hash)
+	require.NoError(t, err)
+	assert.Equal(t, sig1, sig2)
+}
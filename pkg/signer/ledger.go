@@ -1,6 +1,8 @@
 package signer
 
 import (
+	"fmt"
+
 	"github.com/fbsobreira/gotron-sdk/pkg/address"
 	"github.com/fbsobreira/gotron-sdk/pkg/ledger"
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
@@ -39,6 +41,26 @@ func (s *ledgerSigner) Sign(tx *core.Transaction) (*core.Transaction, error) {
 	return tx, nil
 }
 
+// SignMessage signs message on the Ledger device. The TRON app only signs the
+// length-prefixed MessageV2 format. The returned signature is checked
+// against the device address.
+func (s *ledgerSigner) SignMessage(message []byte, format MessageFormat) ([]byte, error) {
+	if format != MessageV2 {
+		return nil, fmt.Errorf("ledger: %w", ErrMessageFormatUnsupported)
+	}
+	sig, err := ledger.SignMessage(message)
+	if err != nil {
+		return nil, err
+	}
+	if sig, err = withWalletV(sig); err != nil {
+		return nil, err
+	}
+	if err := checkSigner(message, format, sig, s.addr); err != nil {
+		return nil, fmt.Errorf("ledger: %w", err)
+	}
+	return sig, nil
+}
+
 // Address returns the TRON address of the Ledger device.
 func (s *ledgerSigner) Address() address.Address {
 	return s.addr
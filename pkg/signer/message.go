@@ -0,0 +1,107 @@
+package signer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
+)
+
+// MessageFormat selects the TIP-191 header used to hash a message.
+type MessageFormat int
+
+const (
+	// MessageV2 hashes keccak256("\x19TRON Signed Message:\n" + len(msg) + msg),
+	// as TronWeb's signMessageV2 and the Ledger TRON app do.
+	MessageV2 MessageFormat = iota
+	// MessageV1 is the fixed-length format of TronWeb's trx.sign, whose
+	// header always declares a length of 32 ("\x19TRON Signed Message:\n32").
+	// It is meant for signing 32-byte hashes.
+	MessageV1
+)
+
+// ErrMessageFormatUnsupported is returned by a MessageSigner that cannot sign
+// the requested format.
+var ErrMessageFormatUnsupported = errors.New("message format not supported by signer")
+
+// MessageSigner is a Signer that can also sign off-chain messages, such as
+// login challenges, in the TIP-191 formats.
+type MessageSigner interface {
+	Signer
+
+	// SignMessage returns the 65-byte [R || S || V] signature of message
+	// hashed in format, with V set to 27 or 28 as wallets produce.
+	SignMessage(message []byte, format MessageFormat) ([]byte, error)
+}
+
+// Compile-time interface satisfaction checks.
+var (
+	_ MessageSigner = (*privateKeySigner)(nil)
+	_ MessageSigner = (*keystoreSigner)(nil)
+	_ MessageSigner = (*keystorePassphraseSigner)(nil)
+	_ MessageSigner = (*ledgerSigner)(nil)
+	_ MessageSigner = (*PKCS11)(nil)
+	_ HashSigner    = (*privateKeySigner)(nil)
+	_ HashSigner    = (*keystoreSigner)(nil)
+	_ HashSigner    = (*keystorePassphraseSigner)(nil)
+	_ HashSigner    = (*PKCS11)(nil)
+)
+
+// MessageHash returns the TIP-191 digest of message in format.
+func MessageHash(message []byte, format MessageFormat) []byte {
+	return keystore.TextHash(message, format == MessageV1)
+}
+
+// RecoverMessage returns the address that produced sig over message in
+// format. V may be given as 0/1 or 27/28.
+func RecoverMessage(message []byte, format MessageFormat, sig []byte) (address.Address, error) {
+	if len(sig) != 65 {
+		return nil, fmt.Errorf("invalid signature length: %d/65", len(sig))
+	}
+	normalized := append([]byte(nil), sig...)
+	if normalized[64] >= 27 {
+		normalized[64] -= 27
+	}
+	pub, err := crypto.SigToPub(MessageHash(message, format), normalized)
+	if err != nil {
+		return nil, err
+	}
+	return address.PubkeyToAddress(*pub), nil
+}
+
+// signMessageHash signs the TIP-191 digest of message with signHash and sets
+// V to 27/28.
+func signMessageHash(signHash func([]byte) ([]byte, error), message []byte, format MessageFormat) ([]byte, error) {
+	sig, err := signHash(MessageHash(message, format))
+	if err != nil {
+		return nil, err
+	}
+	return withWalletV(sig)
+}
+
+// withWalletV checks the signature length and moves V from a recovery id
+// (0/1) to 27/28.
+func withWalletV(sig []byte) ([]byte, error) {
+	if len(sig) != 65 {
+		return nil, fmt.Errorf("invalid signature length: %d/65", len(sig))
+	}
+	if sig[64] < 27 {
+		sig[64] += 27
+	}
+	return sig, nil
+}
+
+// checkSigner verifies that sig over message was produced by want.
+func checkSigner(message []byte, format MessageFormat, sig []byte, want address.Address) error {
+	got, err := RecoverMessage(message, format, sig)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(got, want) {
+		return fmt.Errorf("signature recovers to %s, expected %s", got, want)
+	}
+	return nil
+}
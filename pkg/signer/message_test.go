@@ -0,0 +1,70 @@
+package signer
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/keystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageHash(t *testing.T) {
+	msg := []byte("hello")
+	assert.Equal(t, crypto.Keccak256([]byte("\x19TRON Signed Message:\n5hello")), MessageHash(msg, MessageV2))
+	assert.Equal(t, crypto.Keccak256([]byte("\x19TRON Signed Message:\n32hello")), MessageHash(msg, MessageV1))
+}
+
+func TestPrivateKeySigner_SignMessage(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	s, err := NewPrivateKeySigner(key)
+	require.NoError(t, err)
+	ms, ok := s.(MessageSigner)
+	require.True(t, ok)
+
+	msg := []byte("login challenge 42")
+	for _, format := range []MessageFormat{MessageV1, MessageV2} {
+		sig, err := ms.SignMessage(msg, format)
+		require.NoError(t, err)
+		require.Len(t, sig, 65)
+		assert.Contains(t, []byte{27, 28}, sig[64])
+
+		addr, err := RecoverMessage(msg, format, sig)
+		require.NoError(t, err)
+		assert.Equal(t, s.Address(), addr)
+
+		// The other format recovers a different address.
+		other := MessageV2
+		if format == MessageV2 {
+			other = MessageV1
+		}
+		addr, err = RecoverMessage(msg, other, sig)
+		require.NoError(t, err)
+		assert.NotEqual(t, s.Address(), addr)
+	}
+
+	_, err = RecoverMessage(msg, MessageV2, []byte{1})
+	assert.ErrorContains(t, err, "invalid signature length")
+}
+
+func TestKeystoreSigner_SignMessageMatchesWallet(t *testing.T) {
+	ks, acct := newTestKeystoreAndAccount(t, "pass")
+	require.NoError(t, ks.Unlock(acct, "pass"))
+	msg := []byte("hello")
+
+	want, err := ks.Wallets()[0].SignText(acct, msg, true)
+	require.NoError(t, err)
+	want[64] += 27
+
+	sig, err := NewKeystoreSigner(ks, acct).(MessageSigner).SignMessage(msg, MessageV1)
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(want), hex.EncodeToString(sig))
+
+	sig, err = NewKeystorePassphraseSigner(ks, acct, "pass").(MessageSigner).SignMessage(msg, MessageV2)
+	require.NoError(t, err)
+	addr, err := keystore.RecoverPubkey(keystore.TextHash(msg), sig)
+	require.NoError(t, err)
+	assert.Equal(t, acct.Address, addr)
+}
@@ -0,0 +1,203 @@
+package signer
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	proto "google.golang.org/protobuf/proto"
+)
+
+var _ Signer = (*Multi)(nil)
+
+// AccountFetcher is the subset of GrpcClient that Multi needs to read an
+// account's permissions.
+type AccountFetcher interface {
+	GetAccountCtx(ctx context.Context, addr string) (*core.Account, error)
+}
+
+// MultiResult reports how a Multi signed a transaction.
+type MultiResult struct {
+	// Signers lists the keys that signed in this call, in signing order.
+	Signers []address.Address
+	// Weight is the permission weight of all signatures on the transaction,
+	// including any present before the call.
+	Weight int64
+	// Threshold is the weight the permission requires.
+	Threshold int64
+}
+
+// Multi combines several signers holding keys of one multi-signature
+// permission. Sign adds signatures in the given order until the permission
+// threshold is met, so a 2-of-3 permission is signed by the first two
+// signers only. Address returns the account that owns the permission.
+type Multi struct {
+	owner      address.Address
+	permission *core.Permission
+	weights    map[string]int64
+	signers    []Signer
+}
+
+// NewMulti reads permission permissionID of owner (0 owner, 1 witness, 2+
+// active) and returns a Multi signing with signers. Every signer must hold a
+// key of the permission, and together they must reach its threshold.
+func NewMulti(ctx context.Context, client AccountFetcher, owner address.Address, permissionID int32, signers ...Signer) (*Multi, error) {
+	acc, err := client.GetAccountCtx(ctx, owner.String())
+	if err != nil {
+		return nil, err
+	}
+	permission := findPermission(acc, owner, permissionID)
+	if permission == nil {
+		return nil, fmt.Errorf("account %s has no permission %d", owner, permissionID)
+	}
+	return newMulti(owner, permission, signers)
+}
+
+func newMulti(owner address.Address, permission *core.Permission, signers []Signer) (*Multi, error) {
+	if len(signers) == 0 {
+		return nil, errors.New("multi: no signers")
+	}
+	m := &Multi{
+		owner:      owner,
+		permission: permission,
+		weights:    make(map[string]int64, len(permission.GetKeys())),
+		signers:    signers,
+	}
+	for _, k := range permission.GetKeys() {
+		m.weights[address.Address(k.GetAddress()).String()] = k.GetWeight()
+	}
+
+	seen := make(map[string]bool, len(signers))
+	var total int64
+	for _, s := range signers {
+		addr := s.Address().String()
+		weight, ok := m.weights[addr]
+		if !ok {
+			return nil, fmt.Errorf("multi: %s is not a key of permission %d (%s)", addr, permission.GetId(), permission.GetPermissionName())
+		}
+		if seen[addr] {
+			return nil, fmt.Errorf("multi: %s given twice", addr)
+		}
+		seen[addr] = true
+		total += weight
+	}
+	if total < permission.GetThreshold() {
+		return nil, fmt.Errorf("multi: signers hold weight %d of threshold %d", total, permission.GetThreshold())
+	}
+	return m, nil
+}
+
+// findPermission returns permission id of acc. Accounts that never updated
+// their permissions are owned by their own key alone.
+func findPermission(acc *core.Account, owner address.Address, id int32) *core.Permission {
+	switch id {
+	case 0:
+		if p := acc.GetOwnerPermission(); p != nil {
+			return p
+		}
+		return &core.Permission{
+			Type:           core.Permission_Owner,
+			PermissionName: "owner",
+			Threshold:      1,
+			Keys:           []*core.Key{{Address: owner, Weight: 1}},
+		}
+	case 1:
+		return acc.GetWitnessPermission()
+	}
+	for _, p := range acc.GetActivePermission() {
+		if p.GetId() == id {
+			return p
+		}
+	}
+	return nil
+}
+
+// Permission returns the permission Multi signs for.
+func (m *Multi) Permission() *core.Permission {
+	return m.permission
+}
+
+// Address returns the account that owns the permission.
+func (m *Multi) Address() address.Address {
+	return append(address.Address(nil), m.owner...)
+}
+
+// Sign signs tx until the permission threshold is met.
+func (m *Multi) Sign(tx *core.Transaction) (*core.Transaction, error) {
+	signed, _, err := m.SignWithResult(tx)
+	return signed, err
+}
+
+// SignWithResult signs tx until the permission threshold is met and reports
+// which keys contributed. Signatures already on tx from keys of the
+// permission count towards the threshold, and their signers are skipped.
+func (m *Multi) SignWithResult(tx *core.Transaction) (*core.Transaction, *MultiResult, error) {
+	contracts := tx.GetRawData().GetContract()
+	if len(contracts) == 0 {
+		return nil, nil, errors.New("multi: transaction has no contract")
+	}
+	for _, c := range contracts {
+		if c.GetPermissionId() != m.permission.GetId() {
+			return nil, nil, fmt.Errorf("multi: transaction uses permission %d, want %d", c.GetPermissionId(), m.permission.GetId())
+		}
+		if !m.allows(c.GetType()) {
+			return nil, nil, fmt.Errorf("multi: permission %d does not allow %s", m.permission.GetId(), c.GetType())
+		}
+	}
+
+	rawData, err := proto.Marshal(tx.GetRawData())
+	if err != nil {
+		return nil, nil, err
+	}
+	hash := sha256.Sum256(rawData)
+
+	result := &MultiResult{Threshold: m.permission.GetThreshold()}
+	signedBy := make(map[string]bool)
+	for _, sig := range tx.GetSignature() {
+		pub, err := crypto.SigToPub(hash[:], sig)
+		if err != nil {
+			continue
+		}
+		addr := address.PubkeyToAddress(*pub).String()
+		if weight, ok := m.weights[addr]; ok && !signedBy[addr] {
+			signedBy[addr] = true
+			result.Weight += weight
+		}
+	}
+
+	for _, s := range m.signers {
+		if result.Weight >= result.Threshold {
+			break
+		}
+		addr := s.Address()
+		if signedBy[addr.String()] {
+			continue
+		}
+		n := len(tx.GetSignature())
+		if tx, err = s.Sign(tx); err != nil {
+			return nil, nil, fmt.Errorf("multi: %s: %w", addr, err)
+		}
+		if len(tx.GetSignature()) != n+1 {
+			return nil, nil, fmt.Errorf("multi: %s added %d signatures, want 1", addr, len(tx.GetSignature())-n)
+		}
+		signedBy[addr.String()] = true
+		result.Weight += m.weights[addr.String()]
+		result.Signers = append(result.Signers, addr)
+	}
+	return tx, result, nil
+}
+
+// allows reports whether the permission may sign contracts of type t. Only
+// active permissions restrict operations.
+func (m *Multi) allows(t core.Transaction_Contract_ContractType) bool {
+	if m.permission.GetType() != core.Permission_Active {
+		return true
+	}
+	ops := m.permission.GetOperations()
+	i := int(t)
+	return i/8 < len(ops) && ops[i/8]&(1<<(i%8)) != 0
+}
@@ -0,0 +1,140 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ AccountFetcher = (*client.GrpcClient)(nil)
+
+type fakeAccounts map[string]*core.Account
+
+func (f fakeAccounts) GetAccountCtx(_ context.Context, addr string) (*core.Account, error) {
+	acc, ok := f[addr]
+	if !ok {
+		return nil, errors.New("account not found")
+	}
+	return acc, nil
+}
+
+func newKeySigners(t *testing.T, n int) []Signer {
+	t.Helper()
+	out := make([]Signer, n)
+	for i := range out {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		out[i], err = NewPrivateKeySigner(key)
+		require.NoError(t, err)
+	}
+	return out
+}
+
+// transferOperations allows only TransferContract in an active permission.
+func transferOperations() []byte {
+	ops := make([]byte, 32)
+	ops[core.Transaction_Contract_TransferContract/8] |= 1 << (core.Transaction_Contract_TransferContract % 8)
+	return ops
+}
+
+// multisigAccount returns an account with a 2-of-3 active permission 2.
+func multisigAccount(owner address.Address, keys []Signer) fakeAccounts {
+	permission := &core.Permission{
+		Type:           core.Permission_Active,
+		Id:             2,
+		PermissionName: "treasury",
+		Threshold:      2,
+		Operations:     transferOperations(),
+	}
+	for _, k := range keys {
+		permission.Keys = append(permission.Keys, &core.Key{Address: k.Address(), Weight: 1})
+	}
+	return fakeAccounts{owner.String(): {
+		Address:          owner,
+		ActivePermission: []*core.Permission{permission},
+	}}
+}
+
+func multisigTx(t *testing.T, permissionID int32) *core.Transaction {
+	tx := trxTransfer(t, policyAddr(0x10), 1)
+	tx.RawData.Contract[0].PermissionId = permissionID
+	return tx
+}
+
+func TestMulti_SignsUntilThreshold(t *testing.T) {
+	owner := policyAddr(0x01)
+	keys := newKeySigners(t, 3)
+	m, err := NewMulti(context.Background(), multisigAccount(owner, keys), owner, 2, keys...)
+	require.NoError(t, err)
+	assert.Equal(t, owner, m.Address())
+	assert.Equal(t, "treasury", m.Permission().GetPermissionName())
+
+	tx, result, err := m.SignWithResult(multisigTx(t, 2))
+	require.NoError(t, err)
+	assert.Len(t, tx.Signature, 2)
+	assert.Equal(t, []address.Address{keys[0].Address(), keys[1].Address()}, result.Signers)
+	assert.Equal(t, int64(2), result.Weight)
+	assert.Equal(t, int64(2), result.Threshold)
+}
+
+func TestMulti_CountsExistingSignatures(t *testing.T) {
+	owner := policyAddr(0x01)
+	keys := newKeySigners(t, 3)
+	m, err := NewMulti(context.Background(), multisigAccount(owner, keys), owner, 2, keys[0], keys[2])
+	require.NoError(t, err)
+
+	tx, err := keys[0].Sign(multisigTx(t, 2))
+	require.NoError(t, err)
+	tx, result, err := m.SignWithResult(tx)
+	require.NoError(t, err)
+	assert.Len(t, tx.Signature, 2)
+	assert.Equal(t, []address.Address{keys[2].Address()}, result.Signers, "the key that already signed is skipped")
+	assert.Equal(t, int64(2), result.Weight)
+}
+
+func TestMulti_Errors(t *testing.T) {
+	owner := policyAddr(0x01)
+	keys := newKeySigners(t, 4)
+	accounts := multisigAccount(owner, keys[:3])
+	ctx := context.Background()
+
+	_, err := NewMulti(ctx, accounts, owner, 2, keys[0], keys[3])
+	assert.ErrorContains(t, err, "is not a key of permission 2")
+	_, err = NewMulti(ctx, accounts, owner, 2, keys[0])
+	assert.ErrorContains(t, err, "weight 1 of threshold 2")
+	_, err = NewMulti(ctx, accounts, owner, 2, keys[0], keys[0])
+	assert.ErrorContains(t, err, "given twice")
+	_, err = NewMulti(ctx, accounts, owner, 3, keys[0], keys[1])
+	assert.ErrorContains(t, err, "no permission 3")
+	_, err = NewMulti(ctx, accounts, policyAddr(0x02), 2, keys[0], keys[1])
+	assert.Error(t, err)
+
+	m, err := NewMulti(ctx, accounts, owner, 2, keys[0], keys[1])
+	require.NoError(t, err)
+	_, err = m.Sign(multisigTx(t, 0))
+	assert.ErrorContains(t, err, "uses permission 0")
+
+	tx := policyTx(t, core.Transaction_Contract_AccountPermissionUpdateContract, &core.AccountPermissionUpdateContract{OwnerAddress: owner})
+	tx.RawData.Contract[0].PermissionId = 2
+	_, err = m.Sign(tx)
+	assert.ErrorContains(t, err, "does not allow AccountPermissionUpdateContract")
+}
+
+func TestMulti_DefaultOwnerPermission(t *testing.T) {
+	keys := newKeySigners(t, 1)
+	owner := keys[0].Address()
+	accounts := fakeAccounts{owner.String(): {Address: owner}}
+
+	m, err := NewMulti(context.Background(), accounts, owner, 0, keys...)
+	require.NoError(t, err)
+	_, result, err := m.SignWithResult(multisigTx(t, 0))
+	require.NoError(t, err)
+	assert.Equal(t, []address.Address{owner}, result.Signers)
+}
@@ -0,0 +1,195 @@
+package signer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	proto "google.golang.org/protobuf/proto"
+)
+
+// PKCS11Config locates a secp256k1 key in a PKCS#11 token.
+type PKCS11Config struct {
+	// Module is the path of the PKCS#11 library, e.g.
+	// /usr/lib/softhsm/libsofthsm2.so.
+	Module string
+	// TokenLabel selects the token holding the key.
+	TokenLabel string
+	// PIN is the user PIN of the token.
+	PIN string
+	// KeyLabel is the CKA_LABEL shared by the private and public key objects.
+	KeyLabel string
+}
+
+// pkcs11Token is an open session on the key of a PKCS#11 token.
+type pkcs11Token interface {
+	// sign returns the CKM_ECDSA signature of digest, raw r||s or DER.
+	sign(digest []byte) ([]byte, error)
+	close() error
+}
+
+// PKCS11 is a Signer backed by a secp256k1 key held in an HSM through
+// PKCS#11. The HSM signs the SHA-256 digest of the raw transaction data; the
+// signature is normalized to TRON's 65-byte [R || S || V] form, with V found
+// by recovering the public key. PKCS#11 support requires cgo.
+type PKCS11 struct {
+	mu    sync.Mutex // PKCS#11 sessions are not safe for concurrent use
+	token pkcs11Token
+	pub   []byte // uncompressed public key
+	addr  address.Address
+}
+
+// NewPKCS11 logs in to the token and looks up the key. Close releases the
+// session.
+func NewPKCS11(cfg PKCS11Config) (*PKCS11, error) {
+	if cfg.Module == "" || cfg.KeyLabel == "" {
+		return nil, errors.New("pkcs11: module and key label are required")
+	}
+	token, ecPoint, err := openPKCS11(cfg)
+	if err != nil {
+		return nil, err
+	}
+	s, err := newPKCS11(token, ecPoint)
+	if err != nil {
+		_ = token.close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func newPKCS11(token pkcs11Token, ecPoint []byte) (*PKCS11, error) {
+	pub, err := parseECPoint(ecPoint)
+	if err != nil {
+		return nil, fmt.Errorf("pkcs11: %w", err)
+	}
+	pk, err := crypto.UnmarshalPubkey(pub)
+	if err != nil {
+		return nil, fmt.Errorf("pkcs11: %w", err)
+	}
+	return &PKCS11{token: token, pub: pub, addr: address.PubkeyToAddress(*pk)}, nil
+}
+
+// Sign appends the HSM signature of the transaction.
+func (s *PKCS11) Sign(tx *core.Transaction) (*core.Transaction, error) {
+	rawData, err := proto.Marshal(tx.GetRawData())
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.Sum256(rawData)
+	sig, err := s.SignHash(h[:])
+	if err != nil {
+		return nil, err
+	}
+	tx.Signature = append(tx.Signature, sig)
+	return tx, nil
+}
+
+// SignHash signs a 32-byte digest in the HSM.
+func (s *PKCS11) SignHash(hash []byte) ([]byte, error) {
+	if len(hash) != 32 {
+		return nil, fmt.Errorf("pkcs11: hash must be 32 bytes, got %d", len(hash))
+	}
+	s.mu.Lock()
+	sig, err := s.token.sign(hash)
+	s.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("pkcs11: %w", err)
+	}
+	return normalizeSignature(sig, hash, s.pub)
+}
+
+// SignMessage signs message in the TIP-191 format in the HSM.
+func (s *PKCS11) SignMessage(message []byte, format MessageFormat) ([]byte, error) {
+	return signMessageHash(s.SignHash, message, format)
+}
+
+// Address returns the TRON address of the HSM key.
+func (s *PKCS11) Address() address.Address {
+	return append(address.Address(nil), s.addr...)
+}
+
+// Close closes the PKCS#11 session. The module is finalized once every
+// PKCS11 signer opened through it is closed.
+func (s *PKCS11) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.token.close()
+}
+
+// secp256k1OID is the DER encoding of the secp256k1 curve OID 1.3.132.0.10,
+// as found in CKA_EC_PARAMS.
+var secp256k1OID = []byte{0x06, 0x05, 0x2b, 0x81, 0x04, 0x00, 0x0a}
+
+var (
+	secp256k1N     = crypto.S256().Params().N
+	secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+)
+
+// parseECPoint returns the uncompressed public key of a CKA_EC_POINT value,
+// which is a DER OCTET STRING holding the point, though some tokens return
+// the bare point.
+func parseECPoint(ecPoint []byte) ([]byte, error) {
+	point := ecPoint
+	var inner []byte
+	if rest, err := asn1.Unmarshal(ecPoint, &inner); err == nil && len(rest) == 0 && (len(inner) == 33 || len(inner) == 65) {
+		point = inner
+	}
+	switch len(point) {
+	case 65:
+		pk, err := crypto.UnmarshalPubkey(point)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC point: %w", err)
+		}
+		return crypto.FromECDSAPub(pk), nil
+	case 33:
+		pk, err := crypto.DecompressPubkey(point)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC point: %w", err)
+		}
+		return crypto.FromECDSAPub(pk), nil
+	}
+	return nil, fmt.Errorf("invalid EC point length %d", len(point))
+}
+
+// normalizeSignature converts an ECDSA signature given as raw r||s or DER
+// into the 65-byte [R || S || V] form with a low S, choosing the recovery
+// id V that yields pub.
+func normalizeSignature(sig, hash, pub []byte) ([]byte, error) {
+	var r, s *big.Int
+	if len(sig) == 64 {
+		r, s = new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:])
+	} else {
+		var der struct{ R, S *big.Int }
+		rest, err := asn1.Unmarshal(sig, &der)
+		if err != nil || len(rest) != 0 {
+			return nil, fmt.Errorf("pkcs11: invalid signature encoding (%d bytes)", len(sig))
+		}
+		r, s = der.R, der.S
+	}
+	if r.Sign() <= 0 || r.Cmp(secp256k1N) >= 0 || s.Sign() <= 0 || s.Cmp(secp256k1N) >= 0 {
+		return nil, errors.New("pkcs11: signature values out of range")
+	}
+	// Transactions require the canonical low-S form.
+	if s.Cmp(secp256k1HalfN) > 0 {
+		s = new(big.Int).Sub(secp256k1N, s)
+	}
+
+	out := make([]byte, 65)
+	r.FillBytes(out[:32])
+	s.FillBytes(out[32:64])
+	for v := byte(0); v < 2; v++ {
+		out[64] = v
+		recovered, err := crypto.Ecrecover(hash, out)
+		if err == nil && bytes.Equal(recovered, pub) {
+			return out, nil
+		}
+	}
+	return nil, errors.New("pkcs11: signature does not match the token public key")
+}
@@ -0,0 +1,198 @@
+//go:build cgo
+
+package signer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+)
+
+// hsmToken is a logged-in PKCS#11 session on a private key.
+type hsmToken struct {
+	module  string
+	ctx     *pkcs11.Ctx
+	session pkcs11.SessionHandle
+	key     pkcs11.ObjectHandle
+}
+
+// pkcs11Module is a loaded module shared by every token opened through it.
+// C_Initialize and C_Finalize act on the whole module, so it is finalized
+// only when its last token closes, and only if this package initialized it.
+type pkcs11Module struct {
+	ctx         *pkcs11.Ctx
+	refs        int
+	initialized bool
+}
+
+var (
+	pkcs11ModulesMu sync.Mutex
+	pkcs11Modules   = make(map[string]*pkcs11Module)
+)
+
+// acquireModule returns the loaded module at path, loading and initializing
+// it on first use.
+func acquireModule(path string) (*pkcs11.Ctx, error) {
+	pkcs11ModulesMu.Lock()
+	defer pkcs11ModulesMu.Unlock()
+	if m, ok := pkcs11Modules[path]; ok {
+		m.refs++
+		return m.ctx, nil
+	}
+	ctx := pkcs11.New(path)
+	if ctx == nil {
+		return nil, fmt.Errorf("pkcs11: cannot load module %s", path)
+	}
+	err := ctx.Initialize()
+	if err != nil && !isPKCS11Error(err, pkcs11.CKR_CRYPTOKI_ALREADY_INITIALIZED) {
+		ctx.Destroy()
+		return nil, fmt.Errorf("pkcs11: initialize: %w", err)
+	}
+	pkcs11Modules[path] = &pkcs11Module{ctx: ctx, refs: 1, initialized: err == nil}
+	return ctx, nil
+}
+
+// releaseModule drops a reference to the module at path, finalizing and
+// unloading it with the last one.
+func releaseModule(path string) error {
+	pkcs11ModulesMu.Lock()
+	defer pkcs11ModulesMu.Unlock()
+	m, ok := pkcs11Modules[path]
+	if !ok {
+		return nil
+	}
+	if m.refs--; m.refs > 0 {
+		return nil
+	}
+	delete(pkcs11Modules, path)
+	var err error
+	if m.initialized {
+		err = m.ctx.Finalize()
+	}
+	m.ctx.Destroy()
+	return err
+}
+
+// openPKCS11 loads the module, logs in to the token and finds the key pair
+// labelled cfg.KeyLabel. It returns the CKA_EC_POINT of the public key.
+func openPKCS11(cfg PKCS11Config) (pkcs11Token, []byte, error) {
+	ctx, err := acquireModule(cfg.Module)
+	if err != nil {
+		return nil, nil, err
+	}
+	t := &hsmToken{module: cfg.Module, ctx: ctx}
+	ecPoint, err := t.open(cfg)
+	if err != nil {
+		_ = t.close()
+		return nil, nil, err
+	}
+	return t, ecPoint, nil
+}
+
+func (t *hsmToken) open(cfg PKCS11Config) ([]byte, error) {
+	slot, err := t.findSlot(cfg.TokenLabel)
+	if err != nil {
+		return nil, err
+	}
+	if t.session, err = t.ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION); err != nil {
+		return nil, fmt.Errorf("pkcs11: open session: %w", err)
+	}
+	if err := t.ctx.Login(t.session, pkcs11.CKU_USER, cfg.PIN); err != nil && !isPKCS11Error(err, pkcs11.CKR_USER_ALREADY_LOGGED_IN) {
+		return nil, fmt.Errorf("pkcs11: login: %w", err)
+	}
+
+	if t.key, err = t.findKey(pkcs11.CKO_PRIVATE_KEY, cfg.KeyLabel); err != nil {
+		return nil, err
+	}
+	pubKey, err := t.findKey(pkcs11.CKO_PUBLIC_KEY, cfg.KeyLabel)
+	if err != nil {
+		return nil, err
+	}
+	attrs, err := t.ctx.GetAttributeValue(t.session, pubKey, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, nil),
+		pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("pkcs11: read public key: %w", err)
+	}
+	if !bytes.Equal(attrs[0].Value, secp256k1OID) {
+		return nil, fmt.Errorf("pkcs11: key %q is not a secp256k1 key", cfg.KeyLabel)
+	}
+	return attrs[1].Value, nil
+}
+
+func (t *hsmToken) findSlot(label string) (uint, error) {
+	slots, err := t.ctx.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("pkcs11: list slots: %w", err)
+	}
+	for _, slot := range slots {
+		info, err := t.ctx.GetTokenInfo(slot)
+		if err != nil {
+			continue
+		}
+		if strings.TrimRight(info.Label, " \x00") == label {
+			return slot, nil
+		}
+	}
+	return 0, fmt.Errorf("pkcs11: no token labelled %q", label)
+}
+
+func (t *hsmToken) findKey(class uint, label string) (pkcs11.ObjectHandle, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	}
+	if err := t.ctx.FindObjectsInit(t.session, template); err != nil {
+		return 0, fmt.Errorf("pkcs11: find key: %w", err)
+	}
+	objects, _, err := t.ctx.FindObjects(t.session, 2)
+	if finalErr := t.ctx.FindObjectsFinal(t.session); err == nil {
+		err = finalErr
+	}
+	if err != nil {
+		return 0, fmt.Errorf("pkcs11: find key: %w", err)
+	}
+	kind := "private"
+	if class == pkcs11.CKO_PUBLIC_KEY {
+		kind = "public"
+	}
+	switch len(objects) {
+	case 0:
+		return 0, fmt.Errorf("pkcs11: no EC %s key labelled %q", kind, label)
+	case 1:
+		return objects[0], nil
+	}
+	return 0, fmt.Errorf("pkcs11: several EC %s keys labelled %q", kind, label)
+}
+
+func (t *hsmToken) sign(digest []byte) ([]byte, error) {
+	if err := t.ctx.SignInit(t.session, []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil)}, t.key); err != nil {
+		return nil, err
+	}
+	return t.ctx.Sign(t.session, digest)
+}
+
+// close closes the session and releases the module. It does not call
+// C_Logout, which would log out every other session on the token; the token
+// logs out by itself when its last session closes.
+func (t *hsmToken) close() error {
+	if t.ctx == nil {
+		return nil
+	}
+	if t.session != 0 {
+		_ = t.ctx.CloseSession(t.session)
+	}
+	t.ctx = nil
+	return releaseModule(t.module)
+}
+
+func isPKCS11Error(err error, code uint) bool {
+	var e pkcs11.Error
+	return errors.As(err, &e) && uint(e) == code
+}
@@ -0,0 +1,151 @@
+//go:build cgo
+
+package signer
+
+import (
+	"crypto/sha256"
+	"os"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/miekg/pkcs11"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	proto "google.golang.org/protobuf/proto"
+)
+
+// TestPKCS11_SoftHSM runs against a real token. Initialize one with
+//
+//	softhsm2-util --init-token --free --label gotron-test --pin 1234 --so-pin 0000
+//
+// and set PKCS11_MODULE to the SoftHSM library, e.g.
+// /usr/lib/softhsm/libsofthsm2.so.
+func TestPKCS11_SoftHSM(t *testing.T) {
+	module := os.Getenv("PKCS11_MODULE")
+	if module == "" {
+		t.Skip("PKCS11_MODULE not set")
+	}
+	cfg := PKCS11Config{
+		Module:     module,
+		TokenLabel: envOr("PKCS11_TOKEN", "gotron-test"),
+		PIN:        envOr("PKCS11_PIN", "1234"),
+		KeyLabel:   "gotron-test-key",
+	}
+	generateSoftHSMKey(t, cfg)
+
+	s, err := NewPKCS11(cfg)
+	require.NoError(t, err)
+	defer s.Close()
+
+	tx := &core.Transaction{RawData: &core.TransactionRaw{RefBlockNum: 1, Timestamp: 1700000000000}}
+	_, err = s.Sign(tx)
+	require.NoError(t, err)
+	require.Len(t, tx.Signature, 1)
+
+	rawData, err := proto.Marshal(tx.GetRawData())
+	require.NoError(t, err)
+	h := sha256.Sum256(rawData)
+	again, err := normalizeSignature(tx.Signature[0][:64], h[:], s.pub)
+	require.NoError(t, err)
+	assert.Equal(t, tx.Signature[0], again)
+
+	msgSig, err := s.SignMessage([]byte("hello"), MessageV2)
+	require.NoError(t, err)
+	addr, err := RecoverMessage([]byte("hello"), MessageV2, msgSig)
+	require.NoError(t, err)
+	assert.Equal(t, s.Address(), addr)
+}
+
+// TestPKCS11_SoftHSMSharedModule checks that closing one of two signers on
+// the same module leaves the other usable.
+func TestPKCS11_SoftHSMSharedModule(t *testing.T) {
+	module := os.Getenv("PKCS11_MODULE")
+	if module == "" {
+		t.Skip("PKCS11_MODULE not set")
+	}
+	cfg := PKCS11Config{
+		Module:     module,
+		TokenLabel: envOr("PKCS11_TOKEN", "gotron-test"),
+		PIN:        envOr("PKCS11_PIN", "1234"),
+		KeyLabel:   "gotron-test-key",
+	}
+	cfg2 := cfg
+	cfg2.KeyLabel = "gotron-test-key-2"
+	generateSoftHSMKey(t, cfg)
+	generateSoftHSMKey(t, cfg2)
+
+	first, err := NewPKCS11(cfg)
+	require.NoError(t, err)
+	defer first.Close()
+	second, err := NewPKCS11(cfg2)
+	require.NoError(t, err)
+	defer second.Close()
+
+	require.NoError(t, first.Close())
+	tx := &core.Transaction{RawData: &core.TransactionRaw{RefBlockNum: 1, Timestamp: 1700000000000}}
+	_, err = second.Sign(tx)
+	require.NoError(t, err, "closing one signer must not finalize the shared module")
+	require.Len(t, tx.Signature, 1)
+
+	require.NoError(t, second.Close())
+	pkcs11ModulesMu.Lock()
+	assert.Empty(t, pkcs11Modules)
+	pkcs11ModulesMu.Unlock()
+}
+
+// generateSoftHSMKey creates a secp256k1 key pair labelled
+// cfg.KeyLabel, replacing any earlier one.
+func generateSoftHSMKey(t *testing.T, cfg PKCS11Config) {
+	t.Helper()
+	ctx := pkcs11.New(cfg.Module)
+	require.NotNil(t, ctx)
+	require.NoError(t, ctx.Initialize())
+	defer ctx.Destroy()
+	defer ctx.Finalize()
+
+	h := &hsmToken{ctx: ctx}
+	slot, err := h.findSlot(cfg.TokenLabel)
+	require.NoError(t, err)
+	session, err := ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+	require.NoError(t, err)
+	defer ctx.CloseSession(session)
+	require.NoError(t, ctx.Login(session, pkcs11.CKU_USER, cfg.PIN))
+	defer ctx.Logout(session)
+
+	for _, class := range []uint{pkcs11.CKO_PRIVATE_KEY, pkcs11.CKO_PUBLIC_KEY} {
+		require.NoError(t, ctx.FindObjectsInit(session, []*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+			pkcs11.NewAttribute(pkcs11.CKA_LABEL, cfg.KeyLabel),
+		}))
+		objects, _, err := ctx.FindObjects(session, 16)
+		require.NoError(t, err)
+		require.NoError(t, ctx.FindObjectsFinal(session))
+		for _, o := range objects {
+			require.NoError(t, ctx.DestroyObject(session, o))
+		}
+	}
+
+	_, _, err = ctx.GenerateKeyPair(session,
+		[]*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_EC_KEY_PAIR_GEN, nil)},
+		[]*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_VERIFY, true),
+			pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, secp256k1OID),
+			pkcs11.NewAttribute(pkcs11.CKA_LABEL, cfg.KeyLabel),
+		},
+		[]*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+			pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+			pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+			pkcs11.NewAttribute(pkcs11.CKA_LABEL, cfg.KeyLabel),
+		})
+	require.NoError(t, err)
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
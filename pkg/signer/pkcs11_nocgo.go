@@ -0,0 +1,9 @@
+//go:build !cgo
+
+package signer
+
+import "errors"
+
+func openPKCS11(PKCS11Config) (pkcs11Token, []byte, error) {
+	return nil, nil, errors.New("pkcs11: built without cgo, PKCS#11 is unavailable")
+}
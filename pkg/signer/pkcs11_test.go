@@ -0,0 +1,164 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	proto "google.golang.org/protobuf/proto"
+)
+
+// softToken emulates an HSM that returns DER signatures, optionally with a
+// high S as PKCS#11 does not enforce low-S.
+type softToken struct {
+	key    *ecdsa.PrivateKey
+	highS  bool
+	err    error
+	closed bool
+}
+
+func (t *softToken) sign(digest []byte) ([]byte, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	sig, err := crypto.Sign(digest, t.key)
+	if err != nil {
+		return nil, err
+	}
+	r, s := new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:64])
+	if t.highS {
+		s.Sub(secp256k1N, s)
+	}
+	return asn1.Marshal(struct{ R, S *big.Int }{r, s})
+}
+
+func (t *softToken) close() error {
+	t.closed = true
+	return nil
+}
+
+func newTestPKCS11(t *testing.T, token *softToken) *PKCS11 {
+	t.Helper()
+	ecPoint, err := asn1.Marshal(crypto.FromECDSAPub(&token.key.PublicKey))
+	require.NoError(t, err)
+	s, err := newPKCS11(token, ecPoint)
+	require.NoError(t, err)
+	return s
+}
+
+func TestPKCS11_Sign(t *testing.T) {
+	for _, highS := range []bool{false, true} {
+		key, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		s := newTestPKCS11(t, &softToken{key: key, highS: highS})
+		assert.Equal(t, address.PubkeyToAddress(key.PublicKey), s.Address())
+
+		tx := &core.Transaction{RawData: &core.TransactionRaw{RefBlockNum: 1, Timestamp: 1700000000000}}
+		signed, err := s.Sign(tx)
+		require.NoError(t, err)
+		require.Len(t, signed.Signature, 1)
+		sig := signed.Signature[0]
+		require.Len(t, sig, 65)
+		assert.LessOrEqual(t, new(big.Int).SetBytes(sig[32:64]).Cmp(secp256k1HalfN), 0, "S must be low")
+
+		rawData, err := proto.Marshal(tx.GetRawData())
+		require.NoError(t, err)
+		h := sha256.Sum256(rawData)
+		pub, err := crypto.SigToPub(h[:], sig)
+		require.NoError(t, err)
+		assert.Equal(t, address.PubkeyToAddress(key.PublicKey), address.PubkeyToAddress(*pub))
+	}
+}
+
+func TestPKCS11_SignMessage(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	s := newTestPKCS11(t, &softToken{key: key})
+
+	msg := []byte("hello")
+	sig, err := s.SignMessage(msg, MessageV2)
+	require.NoError(t, err)
+	assert.Contains(t, []byte{27, 28}, sig[64])
+	addr, err := RecoverMessage(msg, MessageV2, sig)
+	require.NoError(t, err)
+	assert.Equal(t, s.Address(), addr)
+}
+
+func TestPKCS11_Errors(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	token := &softToken{key: key}
+	s := newTestPKCS11(t, token)
+
+	_, err = s.SignHash([]byte{1, 2, 3})
+	assert.Error(t, err)
+
+	token.err = errors.New("CKR_DEVICE_REMOVED")
+	_, err = s.Sign(&core.Transaction{RawData: &core.TransactionRaw{}})
+	assert.ErrorContains(t, err, "CKR_DEVICE_REMOVED")
+
+	require.NoError(t, s.Close())
+	assert.True(t, token.closed)
+
+	_, err = NewPKCS11(PKCS11Config{})
+	assert.Error(t, err)
+}
+
+func TestNormalizeSignature(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	pub := crypto.FromECDSAPub(&key.PublicKey)
+	hash := sha256.Sum256([]byte("digest"))
+	want, err := crypto.Sign(hash[:], key)
+	require.NoError(t, err)
+
+	// Raw r||s as returned by most tokens.
+	got, err := normalizeSignature(want[:64], hash[:], pub)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	// DER with a high S.
+	r, s := new(big.Int).SetBytes(want[:32]), new(big.Int).SetBytes(want[32:64])
+	der, err := asn1.Marshal(struct{ R, S *big.Int }{r, new(big.Int).Sub(secp256k1N, s)})
+	require.NoError(t, err)
+	got, err = normalizeSignature(der, hash[:], pub)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	// Trailing data, out of range values and a foreign key are rejected.
+	_, err = normalizeSignature(append(der, 0), hash[:], pub)
+	assert.Error(t, err)
+	zero, err := asn1.Marshal(struct{ R, S *big.Int }{big.NewInt(0), s})
+	require.NoError(t, err)
+	_, err = normalizeSignature(zero, hash[:], pub)
+	assert.Error(t, err)
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	_, err = normalizeSignature(want[:64], hash[:], crypto.FromECDSAPub(&other.PublicKey))
+	assert.Error(t, err)
+}
+
+func TestParseECPoint(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	uncompressed := crypto.FromECDSAPub(&key.PublicKey)
+	wrapped, err := asn1.Marshal(uncompressed)
+	require.NoError(t, err)
+
+	for _, point := range [][]byte{uncompressed, wrapped, crypto.CompressPubkey(&key.PublicKey)} {
+		got, err := parseECPoint(point)
+		require.NoError(t, err)
+		assert.Equal(t, uncompressed, got)
+	}
+
+	_, err = parseECPoint(uncompressed[:40])
+	assert.Error(t, err)
+}
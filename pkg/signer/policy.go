@@ -0,0 +1,534 @@
+package signer
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/client/transaction"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// AssetTRX names TRX in spending limits. TRC-10 tokens are named by their
+// asset ID and TRC-20 tokens by their contract address.
+const AssetTRX = "TRX"
+
+// PolicyViolation is returned when a transaction is refused by a policy
+// rule.
+type PolicyViolation struct {
+	Rule   string // rule that refused the transaction, e.g. "destination"
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *PolicyViolation) Error() string {
+	return fmt.Sprintf("policy violation (%s): %s", e.Rule, e.Reason)
+}
+
+// Rule is a signing policy rule. Check is given the transaction and its
+// first contract decoded with transaction.DecodeContractData; contract types
+// the decoder does not support come with their Type and no Fields.
+type Rule interface {
+	// Check returns a *PolicyViolation if tx must not be signed.
+	Check(tx *core.Transaction, data *transaction.ContractData) error
+}
+
+// Recorder is implemented by rules that keep state, such as spending
+// limits. Record is called once a transaction passed every rule and was
+// signed.
+type Recorder interface {
+	Record(tx *core.Transaction, data *transaction.ContractData)
+}
+
+// checkPolicy decodes tx and checks it against rules. It returns the decoded
+// contract for recordPolicy.
+func checkPolicy(rules []Rule, tx *core.Transaction) (*transaction.ContractData, error) {
+	if n := len(tx.GetRawData().GetContract()); n != 1 {
+		return nil, &PolicyViolation{Rule: "contract", Reason: fmt.Sprintf("transaction has %d contracts, want 1", n)}
+	}
+	data, err := transaction.DecodeContractData(tx)
+	if errors.Is(err, transaction.ErrUnsupportedContract) {
+		data = &transaction.ContractData{
+			Type:   tx.GetRawData().GetContract()[0].GetType().String(),
+			Fields: map[string]any{},
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	// Fail closed on value-moving calls the rules cannot read.
+	if _, err := parseTokenCall(data); err != nil && len(rules) > 0 {
+		return nil, &PolicyViolation{Rule: "calldata", Reason: err.Error()}
+	}
+	for _, rule := range rules {
+		if err := rule.Check(tx, data); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// recordPolicy lets stateful rules account for a signed transaction.
+func recordPolicy(rules []Rule, tx *core.Transaction, data *transaction.ContractData) {
+	for _, rule := range rules {
+		if r, ok := rule.(Recorder); ok {
+			r.Record(tx, data)
+		}
+	}
+}
+
+// policySigner checks transactions against rules before delegating to the
+// inner signer.
+type policySigner struct {
+	inner Signer
+	rules []Rule
+
+	// mu serializes check, sign and record so concurrent calls cannot
+	// overrun a spending limit together.
+	mu sync.Mutex
+}
+
+// WithPolicy returns a Signer that signs with inner only the transactions
+// every rule accepts, and returns a *PolicyViolation for the others. Like
+// Server, it signs transactions only: it does not implement HashSigner or
+// MessageSigner, as those would get around the rules.
+func WithPolicy(inner Signer, rules ...Rule) Signer {
+	return &policySigner{inner: inner, rules: rules}
+}
+
+// Sign checks tx against the rules and signs it with the inner signer.
+func (s *policySigner) Sign(tx *core.Transaction) (*core.Transaction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := checkPolicy(s.rules, tx)
+	if err != nil {
+		return nil, err
+	}
+	signed, err := s.inner.Sign(tx)
+	if err != nil {
+		return nil, err
+	}
+	recordPolicy(s.rules, signed, data)
+	return signed, nil
+}
+
+// Address returns the address of the inner signer.
+func (s *policySigner) Address() address.Address {
+	return s.inner.Address()
+}
+
+// tokenMethod locates the arguments of a token method that moves or
+// approves value.
+type tokenMethod struct {
+	words     int // head words the call needs
+	recipient int // word holding the recipient
+	amount    int // word holding the amount counted against limits, or -1
+}
+
+// tokenMethods maps the selectors of value-moving token methods to their
+// arguments. The arguments are read from the fixed head words of the
+// calldata rather than from the ABI decoder, which rejects calldata with
+// trailing bytes that contracts still execute.
+var tokenMethods = map[string]tokenMethod{
+	"a9059cbb": {2, 0, 1},  // transfer(address,uint256)
+	"23b872dd": {3, 1, 2},  // transferFrom(address,address,uint256)
+	"095ea7b3": {2, 0, -1}, // approve(address,uint256)
+	"39509351": {2, 0, -1}, // increaseAllowance(address,uint256)
+	"a22cb465": {2, 0, -1}, // setApprovalForAll(address,bool)
+	"42842e0e": {3, 1, -1}, // safeTransferFrom(address,address,uint256)
+	"b88d4fde": {4, 1, -1}, // safeTransferFrom(address,address,uint256,bytes)
+	"f242432a": {5, 1, -1}, // safeTransferFrom(address,address,uint256,uint256,bytes)
+	"2eb2c2d6": {5, 1, -1}, // safeBatchTransferFrom(address,address,uint256[],uint256[],bytes)
+}
+
+// tokenCall is a call to one of tokenMethods.
+type tokenCall struct {
+	recipient string
+	amount    *big.Int // nil if the method moves no fungible amount
+}
+
+// parseTokenCall reads the recipient and amount of a call to a
+// value-moving token method. It returns nil for other calls and an error
+// if the calldata is too short for the method's arguments.
+func parseTokenCall(data *transaction.ContractData) (*tokenCall, error) {
+	if data.Type != "TriggerSmartContract" {
+		return nil, nil
+	}
+	calldata, err := hex.DecodeString(fieldString(data, "data"))
+	if err != nil || len(calldata) < 4 {
+		return nil, nil
+	}
+	selector := hex.EncodeToString(calldata[:4])
+	m, ok := tokenMethods[selector]
+	if !ok {
+		return nil, nil
+	}
+	args := calldata[4:]
+	if len(args) < 32*m.words {
+		return nil, fmt.Errorf("calldata of %s call is %d bytes, too short for its arguments", selector, len(calldata))
+	}
+	word := func(i int) []byte { return args[32*i : 32*(i+1)] }
+
+	to := make(address.Address, address.AddressLength)
+	to[0] = address.TronBytePrefix
+	copy(to[1:], word(m.recipient)[12:])
+	call := &tokenCall{recipient: to.String()}
+	if m.amount >= 0 {
+		call.amount = new(big.Int).SetBytes(word(m.amount))
+	}
+	return call, nil
+}
+
+// recipients returns the addresses value is sent to or delegated to.
+func recipients(data *transaction.ContractData) []string {
+	var out []string
+	switch data.Type {
+	case "TransferContract", "TransferAssetContract":
+		out = append(out, fieldString(data, "to_address"))
+	case "DelegateResourceContract":
+		out = append(out, fieldString(data, "receiver_address"))
+	case "TriggerSmartContract":
+		if sun, ok := parseTRX(fieldString(data, "call_value")); ok && sun.Sign() > 0 {
+			out = append(out, fieldString(data, "contract_address"))
+		}
+		if call, _ := parseTokenCall(data); call != nil {
+			out = append(out, call.recipient)
+		}
+	}
+	return out
+}
+
+// spend is an amount of an asset, in its base unit, moved by a transaction.
+type spend struct {
+	asset  string
+	amount *big.Int
+}
+
+// spends returns the assets a transaction moves out of the owner account.
+func spends(data *transaction.ContractData) []spend {
+	var out []spend
+	switch data.Type {
+	case "TransferContract":
+		if sun, ok := parseTRX(fieldString(data, "amount")); ok {
+			out = append(out, spend{AssetTRX, sun})
+		}
+	case "TransferAssetContract":
+		if amount, ok := data.Fields["amount"].(int64); ok {
+			out = append(out, spend{fieldString(data, "asset_name"), big.NewInt(amount)})
+		}
+	case "TriggerSmartContract":
+		if sun, ok := parseTRX(fieldString(data, "call_value")); ok && sun.Sign() > 0 {
+			out = append(out, spend{AssetTRX, sun})
+		}
+		if call, _ := parseTokenCall(data); call != nil && call.amount != nil {
+			out = append(out, spend{fieldString(data, "contract_address"), call.amount})
+		}
+	}
+	return out
+}
+
+func fieldString(data *transaction.ContractData, key string) string {
+	s, _ := data.Fields[key].(string)
+	return s
+}
+
+// parseTRX converts a decoded "1.500000" TRX amount back to SUN.
+func parseTRX(s string) (*big.Int, bool) {
+	whole, frac, _ := strings.Cut(s, ".")
+	if len(frac) > 6 {
+		return nil, false
+	}
+	sun, ok := new(big.Int).SetString(whole+frac+strings.Repeat("0", 6-len(frac)), 10)
+	return sun, ok
+}
+
+// --- destination allowlist ---
+
+type destinationRule struct {
+	allowed map[string]bool
+}
+
+// AllowDestinations refuses transactions that send or delegate value to any
+// address but addrs: TRX and TRC-10 transfers, token transfers and approvals
+// (TRC-20, TRC-721 and TRC-1155), contract calls with a call value, and
+// resource delegations.
+func AllowDestinations(addrs ...address.Address) Rule {
+	r := &destinationRule{allowed: make(map[string]bool, len(addrs))}
+	for _, a := range addrs {
+		r.allowed[a.String()] = true
+	}
+	return r
+}
+
+// Check implements Rule.
+func (r *destinationRule) Check(_ *core.Transaction, data *transaction.ContractData) error {
+	for _, to := range recipients(data) {
+		if !r.allowed[to] {
+			return &PolicyViolation{Rule: "destination", Reason: fmt.Sprintf("destination %s is not allowed", to)}
+		}
+	}
+	return nil
+}
+
+// --- spending limits ---
+
+type spendRecord struct {
+	at     time.Time
+	amount *big.Int
+}
+
+type spendingLimit struct {
+	asset  string
+	max    *big.Int
+	window time.Duration
+	now    func() time.Time
+
+	mu    sync.Mutex
+	spent []spendRecord
+}
+
+// WindowLimit caps the amount of asset, in its base unit (SUN for TRX),
+// signed away within any rolling window. asset is AssetTRX, a TRC-10 asset
+// ID or a TRC-20 contract address; TRC-20 amounts count transfer and
+// transferFrom calls. Spending is kept in memory and starts over when the
+// process restarts.
+func WindowLimit(asset string, limit *big.Int, window time.Duration) Rule {
+	return &spendingLimit{asset: asset, max: limit, window: window, now: time.Now}
+}
+
+// DailyLimit is WindowLimit over 24 hours.
+func DailyLimit(asset string, limit *big.Int) Rule {
+	return WindowLimit(asset, limit, 24*time.Hour)
+}
+
+// total returns the amount tx would add and the amount already spent in
+// the window. Callers hold r.mu.
+func (r *spendingLimit) total(data *transaction.ContractData) (*big.Int, *big.Int) {
+	amount := new(big.Int)
+	for _, s := range spends(data) {
+		if s.asset == r.asset {
+			amount.Add(amount, s.amount)
+		}
+	}
+	cutoff := r.now().Add(-r.window)
+	kept := r.spent[:0]
+	spent := new(big.Int)
+	for _, rec := range r.spent {
+		if rec.at.After(cutoff) {
+			kept = append(kept, rec)
+			spent.Add(spent, rec.amount)
+		}
+	}
+	r.spent = kept
+	return amount, spent
+}
+
+// Check implements Rule.
+func (r *spendingLimit) Check(_ *core.Transaction, data *transaction.ContractData) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	amount, spent := r.total(data)
+	if amount.Sign() == 0 {
+		return nil
+	}
+	if new(big.Int).Add(spent, amount).Cmp(r.max) > 0 {
+		return &PolicyViolation{
+			Rule:   "limit",
+			Reason: fmt.Sprintf("%s amount %s exceeds the remaining limit %s of %s per %s", r.asset, amount, new(big.Int).Sub(r.max, spent), r.max, r.window),
+		}
+	}
+	return nil
+}
+
+// Record implements Recorder.
+func (r *spendingLimit) Record(_ *core.Transaction, data *transaction.ContractData) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	amount, _ := r.total(data)
+	if amount.Sign() > 0 {
+		r.spent = append(r.spent, spendRecord{at: r.now(), amount: amount})
+	}
+}
+
+// --- per-transaction amount ---
+
+type amountRule struct {
+	asset string
+	max   *big.Int
+}
+
+// MaxPerTransaction caps the amount of asset, in its base unit, a single
+// transaction may move. Assets are named as in WindowLimit.
+func MaxPerTransaction(asset string, limit *big.Int) Rule {
+	return &amountRule{asset: asset, max: limit}
+}
+
+// Check implements Rule.
+func (r *amountRule) Check(_ *core.Transaction, data *transaction.ContractData) error {
+	amount := new(big.Int)
+	for _, s := range spends(data) {
+		if s.asset == r.asset {
+			amount.Add(amount, s.amount)
+		}
+	}
+	if amount.Cmp(r.max) > 0 {
+		return &PolicyViolation{Rule: "amount", Reason: fmt.Sprintf("%s amount %s exceeds the limit %s per transaction", r.asset, amount, r.max)}
+	}
+	return nil
+}
+
+// --- contract method allowlist ---
+
+type methodRule struct {
+	allowed map[string]map[string]bool
+}
+
+// AllowContractMethods refuses smart contract calls except to the listed
+// methods. methods is keyed by base58 contract address; a method is given
+// by its signature, such as "transfer(address,uint256)", or its 4-byte
+// selector in hex, such as "a9059cbb".
+func AllowContractMethods(methods map[string][]string) Rule {
+	r := &methodRule{allowed: make(map[string]map[string]bool, len(methods))}
+	for contract, list := range methods {
+		set := make(map[string]bool, len(list))
+		for _, m := range list {
+			if !strings.Contains(m, "(") {
+				m = strings.ToLower(strings.TrimPrefix(m, "0x"))
+			}
+			set[m] = true
+		}
+		r.allowed[contract] = set
+	}
+	return r
+}
+
+// Check implements Rule.
+func (r *methodRule) Check(_ *core.Transaction, data *transaction.ContractData) error {
+	if data.Type != "TriggerSmartContract" {
+		return nil
+	}
+	contract := fieldString(data, "contract_address")
+	allowed, ok := r.allowed[contract]
+	if !ok {
+		return &PolicyViolation{Rule: "method", Reason: fmt.Sprintf("calls to contract %s are not allowed", contract)}
+	}
+	method := fieldString(data, "method")
+	selector := fieldString(data, "data")
+	if len(selector) > 8 {
+		selector = selector[:8]
+	}
+	if (method != "" && allowed[method]) || allowed[selector] {
+		return nil
+	}
+	if method == "" {
+		method = selector
+	}
+	return &PolicyViolation{Rule: "method", Reason: fmt.Sprintf("method %s of contract %s is not allowed", method, contract)}
+}
+
+// --- memo pattern ---
+
+type memoRule struct {
+	pattern *regexp.Regexp
+}
+
+// RequireMemo refuses transactions whose memo (the raw data's data field)
+// does not match pattern.
+func RequireMemo(pattern *regexp.Regexp) Rule {
+	return &memoRule{pattern: pattern}
+}
+
+// Check implements Rule.
+func (r *memoRule) Check(tx *core.Transaction, _ *transaction.ContractData) error {
+	memo := tx.GetRawData().GetData()
+	if !r.pattern.Match(memo) {
+		return &PolicyViolation{Rule: "memo", Reason: fmt.Sprintf("memo %q does not match %s", memo, r.pattern)}
+	}
+	return nil
+}
+
+// --- forbidden contract types ---
+
+type contractTypeRule struct {
+	forbidden map[core.Transaction_Contract_ContractType]bool
+}
+
+// ForbidContractTypes refuses transactions of the given contract types,
+// such as core.Transaction_Contract_AccountPermissionUpdateContract.
+func ForbidContractTypes(types ...core.Transaction_Contract_ContractType) Rule {
+	r := &contractTypeRule{forbidden: make(map[core.Transaction_Contract_ContractType]bool, len(types))}
+	for _, t := range types {
+		r.forbidden[t] = true
+	}
+	return r
+}
+
+// Check implements Rule.
+func (r *contractTypeRule) Check(tx *core.Transaction, _ *transaction.ContractData) error {
+	for _, c := range tx.GetRawData().GetContract() {
+		if r.forbidden[c.GetType()] {
+			return &PolicyViolation{Rule: "contract_type", Reason: fmt.Sprintf("%s transactions are not allowed", c.GetType())}
+		}
+	}
+	return nil
+}
+
+// --- expiration window ---
+
+type expirationRule struct {
+	max time.Duration
+	now func() time.Time
+}
+
+// ExpirationWindow refuses transactions that have already expired or that
+// expire more than window from now, so a signed transaction cannot be held
+// back and broadcast much later. The network itself accepts at most 24
+// hours.
+func ExpirationWindow(window time.Duration) Rule {
+	return &expirationRule{max: window, now: time.Now}
+}
+
+// Check implements Rule.
+func (r *expirationRule) Check(tx *core.Transaction, _ *transaction.ContractData) error {
+	expiration := time.UnixMilli(tx.GetRawData().GetExpiration())
+	now := r.now()
+	switch {
+	case !expiration.After(now):
+		return &PolicyViolation{Rule: "expiration", Reason: fmt.Sprintf("transaction expired at %s", expiration.UTC().Format(time.RFC3339))}
+	case expiration.After(now.Add(r.max)):
+		return &PolicyViolation{Rule: "expiration", Reason: fmt.Sprintf("expiration %s is more than %s away", expiration.UTC().Format(time.RFC3339), r.max)}
+	}
+	return nil
+}
+
+// --- permission IDs ---
+
+type permissionRule struct {
+	allowed map[int32]bool
+}
+
+// AllowPermissionIDs refuses transactions signed under any permission but
+// ids. The owner permission is 0, the witness permission 1 and active
+// permissions start at 2.
+func AllowPermissionIDs(ids ...int32) Rule {
+	r := &permissionRule{allowed: make(map[int32]bool, len(ids))}
+	for _, id := range ids {
+		r.allowed[id] = true
+	}
+	return r
+}
+
+// Check implements Rule.
+func (r *permissionRule) Check(tx *core.Transaction, _ *transaction.ContractData) error {
+	for _, c := range tx.GetRawData().GetContract() {
+		if !r.allowed[c.GetPermissionId()] {
+			return &PolicyViolation{Rule: "permission", Reason: fmt.Sprintf("permission ID %d is not allowed", c.GetPermissionId())}
+		}
+	}
+	return nil
+}
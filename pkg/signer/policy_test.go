@@ -0,0 +1,359 @@
+package signer
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	proto "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+func policyAddr(suffix byte) address.Address {
+	addr := make(address.Address, address.AddressLength)
+	addr[0] = address.TronBytePrefix
+	addr[address.AddressLength-1] = suffix
+	return addr
+}
+
+func policyTx(t *testing.T, contractType core.Transaction_Contract_ContractType, msg proto.Message) *core.Transaction {
+	t.Helper()
+	param, err := proto.Marshal(msg)
+	require.NoError(t, err)
+	return &core.Transaction{
+		RawData: &core.TransactionRaw{
+			Contract: []*core.Transaction_Contract{{
+				Type:      contractType,
+				Parameter: &anypb.Any{Value: param},
+			}},
+		},
+	}
+}
+
+func trxTransfer(t *testing.T, to address.Address, sun int64) *core.Transaction {
+	return policyTx(t, core.Transaction_Contract_TransferContract, &core.TransferContract{
+		OwnerAddress: policyAddr(0x01),
+		ToAddress:    to,
+		Amount:       sun,
+	})
+}
+
+func trc20Transfer(t *testing.T, token, to address.Address, amount int64) *core.Transaction {
+	t.Helper()
+	data, err := hex.DecodeString("a9059cbb" +
+		"000000000000000000000000" + hex.EncodeToString(to[1:]) +
+		hex.EncodeToString(uint256Word(amount)))
+	require.NoError(t, err)
+	return policyTx(t, core.Transaction_Contract_TriggerSmartContract, &core.TriggerSmartContract{
+		OwnerAddress:    policyAddr(0x01),
+		ContractAddress: token,
+		Data:            data,
+	})
+}
+
+func uint256Word(v int64) []byte {
+	return new(big.Int).SetInt64(v).FillBytes(make([]byte, 32))
+}
+
+func assertViolation(t *testing.T, err error, rule string) {
+	t.Helper()
+	var violation *PolicyViolation
+	require.True(t, errors.As(err, &violation), "want a PolicyViolation, got %v", err)
+	assert.Equal(t, rule, violation.Rule)
+}
+
+func TestAllowDestinations(t *testing.T) {
+	allowed, other, token := policyAddr(0x10), policyAddr(0x11), policyAddr(0x20)
+	rules := []Rule{AllowDestinations(allowed)}
+
+	_, err := checkPolicy(rules, trxTransfer(t, allowed, 1))
+	assert.NoError(t, err)
+	_, err = checkPolicy(rules, trxTransfer(t, other, 1))
+	assertViolation(t, err, "destination")
+
+	_, err = checkPolicy(rules, trc20Transfer(t, token, allowed, 5))
+	assert.NoError(t, err, "the token contract itself receives no value")
+	_, err = checkPolicy(rules, trc20Transfer(t, token, other, 5))
+	assertViolation(t, err, "destination")
+
+	freeze := policyTx(t, core.Transaction_Contract_FreezeBalanceV2Contract, &core.FreezeBalanceV2Contract{
+		OwnerAddress: policyAddr(0x01), FrozenBalance: 1,
+	})
+	_, err = checkPolicy(rules, freeze)
+	assert.NoError(t, err)
+}
+
+func TestDailyLimit(t *testing.T) {
+	token := policyAddr(0x20)
+	trxLimit := DailyLimit(AssetTRX, big.NewInt(10_000_000)).(*spendingLimit)
+	tokenLimit := DailyLimit(token.String(), big.NewInt(100)).(*spendingLimit)
+	now := time.Unix(1_700_000_000, 0)
+	trxLimit.now = func() time.Time { return now }
+	rules := []Rule{trxLimit, tokenLimit}
+
+	sign := func(tx *core.Transaction) error {
+		data, err := checkPolicy(rules, tx)
+		if err == nil {
+			recordPolicy(rules, tx, data)
+		}
+		return err
+	}
+
+	require.NoError(t, sign(trxTransfer(t, policyAddr(0x10), 6_000_000)))
+	assertViolation(t, sign(trxTransfer(t, policyAddr(0x10), 5_000_000)), "limit")
+	require.NoError(t, sign(trxTransfer(t, policyAddr(0x10), 4_000_000)))
+
+	require.NoError(t, sign(trc20Transfer(t, token, policyAddr(0x10), 100)))
+	assertViolation(t, sign(trc20Transfer(t, token, policyAddr(0x10), 1)), "limit")
+	require.NoError(t, sign(trc20Transfer(t, policyAddr(0x21), policyAddr(0x10), 1000)), "other tokens are not limited")
+
+	now = now.Add(24*time.Hour + time.Second)
+	require.NoError(t, sign(trxTransfer(t, policyAddr(0x10), 10_000_000)), "the window rolls over")
+}
+
+// paddedCall appends trailing bytes to the calldata of a contract call.
+// The ABI decoder rejects such calldata, but contracts ignore the extra
+// bytes and execute the call.
+func paddedCall(t *testing.T, tx *core.Transaction, extra ...byte) *core.Transaction {
+	t.Helper()
+	var call core.TriggerSmartContract
+	require.NoError(t, proto.Unmarshal(tx.RawData.Contract[0].Parameter.Value, &call))
+	call.Data = append(call.Data, extra...)
+	return policyTx(t, core.Transaction_Contract_TriggerSmartContract, &call)
+}
+
+func TestPolicy_PaddedCalldata(t *testing.T) {
+	allowed, other, token := policyAddr(0x10), policyAddr(0x11), policyAddr(0x20)
+	limit := DailyLimit(token.String(), big.NewInt(100))
+	rules := []Rule{AllowDestinations(allowed), limit}
+
+	_, err := checkPolicy(rules, paddedCall(t, trc20Transfer(t, token, other, 1), 0x00))
+	assertViolation(t, err, "destination")
+
+	data, err := checkPolicy(rules, paddedCall(t, trc20Transfer(t, token, allowed, 100), 0x00))
+	require.NoError(t, err)
+	recordPolicy(rules, nil, data)
+	_, err = checkPolicy(rules, paddedCall(t, trc20Transfer(t, token, allowed, 1), 0xff, 0xff))
+	assertViolation(t, err, "limit")
+}
+
+func TestPolicy_ShortCalldata(t *testing.T) {
+	token := policyAddr(0x20)
+	tx := trc20Transfer(t, token, policyAddr(0x10), 1)
+	var call core.TriggerSmartContract
+	require.NoError(t, proto.Unmarshal(tx.RawData.Contract[0].Parameter.Value, &call))
+	call.Data = call.Data[:4+32+16]
+	short := policyTx(t, core.Transaction_Contract_TriggerSmartContract, &call)
+
+	_, err := checkPolicy([]Rule{AllowDestinations(policyAddr(0x10))}, short)
+	assertViolation(t, err, "calldata")
+	_, err = checkPolicy(nil, short)
+	assert.NoError(t, err, "without rules every transaction is signed")
+}
+
+func TestAllowContractMethods(t *testing.T) {
+	token, other := policyAddr(0x20), policyAddr(0x21)
+	rules := []Rule{AllowContractMethods(map[string][]string{
+		token.String(): {"transfer(address,uint256)"},
+		other.String(): {"0xDEADBEEF"},
+	})}
+
+	_, err := checkPolicy(rules, trc20Transfer(t, token, policyAddr(0x10), 1))
+	assert.NoError(t, err)
+
+	approve := trc20Transfer(t, token, policyAddr(0x10), 1)
+	var call core.TriggerSmartContract
+	require.NoError(t, proto.Unmarshal(approve.RawData.Contract[0].Parameter.Value, &call))
+	call.Data = append([]byte{0x09, 0x5e, 0xa7, 0xb3}, call.Data[4:]...)
+	approve = policyTx(t, core.Transaction_Contract_TriggerSmartContract, &call)
+	_, err = checkPolicy(rules, approve)
+	assertViolation(t, err, "method")
+
+	unknown := policyTx(t, core.Transaction_Contract_TriggerSmartContract, &core.TriggerSmartContract{
+		OwnerAddress: policyAddr(0x01), ContractAddress: other, Data: []byte{0xde, 0xad, 0xbe, 0xef, 0x01},
+	})
+	_, err = checkPolicy(rules, unknown)
+	assert.NoError(t, err, "selectors match undecodable calls")
+
+	_, err = checkPolicy(rules, trc20Transfer(t, policyAddr(0x22), policyAddr(0x10), 1))
+	assertViolation(t, err, "method")
+
+	_, err = checkPolicy(rules, trxTransfer(t, policyAddr(0x10), 1))
+	assert.NoError(t, err, "only contract calls are restricted")
+}
+
+func TestRequireMemo(t *testing.T) {
+	rules := []Rule{RequireMemo(regexp.MustCompile(`^INV-[0-9]+$`))}
+
+	tx := trxTransfer(t, policyAddr(0x10), 1)
+	_, err := checkPolicy(rules, tx)
+	assertViolation(t, err, "memo")
+
+	tx.RawData.Data = []byte("INV-42")
+	_, err = checkPolicy(rules, tx)
+	assert.NoError(t, err)
+}
+
+func TestCheckPolicy_ContractCount(t *testing.T) {
+	_, err := checkPolicy(nil, &core.Transaction{RawData: &core.TransactionRaw{}})
+	assertViolation(t, err, "contract")
+}
+
+func TestCheckPolicy_UnsupportedContractType(t *testing.T) {
+	tx := policyTx(t, core.Transaction_Contract_AccountPermissionUpdateContract, &core.AccountPermissionUpdateContract{
+		OwnerAddress: policyAddr(0x01),
+	})
+	data, err := checkPolicy([]Rule{AllowDestinations()}, tx)
+	require.NoError(t, err)
+	assert.Equal(t, "AccountPermissionUpdateContract", data.Type)
+}
+
+func TestWindowLimit(t *testing.T) {
+	limit := WindowLimit(AssetTRX, big.NewInt(10), time.Hour).(*spendingLimit)
+	now := time.Unix(1_700_000_000, 0)
+	limit.now = func() time.Time { return now }
+	rules := []Rule{limit}
+
+	data, err := checkPolicy(rules, trxTransfer(t, policyAddr(0x10), 10))
+	require.NoError(t, err)
+	recordPolicy(rules, nil, data)
+	_, err = checkPolicy(rules, trxTransfer(t, policyAddr(0x10), 1))
+	assertViolation(t, err, "limit")
+
+	now = now.Add(time.Hour + time.Second)
+	_, err = checkPolicy(rules, trxTransfer(t, policyAddr(0x10), 10))
+	assert.NoError(t, err)
+}
+
+func TestMaxPerTransaction(t *testing.T) {
+	token := policyAddr(0x20)
+	rules := []Rule{
+		MaxPerTransaction(AssetTRX, big.NewInt(5_000_000)),
+		MaxPerTransaction(token.String(), big.NewInt(100)),
+	}
+
+	_, err := checkPolicy(rules, trxTransfer(t, policyAddr(0x10), 5_000_000))
+	assert.NoError(t, err)
+	_, err = checkPolicy(rules, trxTransfer(t, policyAddr(0x10), 5_000_001))
+	assertViolation(t, err, "amount")
+	_, err = checkPolicy(rules, trc20Transfer(t, token, policyAddr(0x10), 101))
+	assertViolation(t, err, "amount")
+	_, err = checkPolicy(rules, trc20Transfer(t, policyAddr(0x21), policyAddr(0x10), 101))
+	assert.NoError(t, err, "other tokens are not limited")
+}
+
+func TestAmountLimits_NonCanonicalCalldata(t *testing.T) {
+	token := policyAddr(0x20)
+	to := policyAddr(0x10)
+
+	perTx := []Rule{MaxPerTransaction(token.String(), big.NewInt(100))}
+	_, err := checkPolicy(perTx, paddedCall(t, trc20Transfer(t, token, to, 101), 0x00))
+	assertViolation(t, err, "amount")
+	_, err = checkPolicy(perTx, paddedCall(t, trc20Transfer(t, token, to, 100), 0x00))
+	assert.NoError(t, err)
+
+	window := []Rule{WindowLimit(token.String(), big.NewInt(100), time.Hour)}
+	data, err := checkPolicy(window, paddedCall(t, trc20Transfer(t, token, to, 60), 0x00, 0x00))
+	require.NoError(t, err)
+	recordPolicy(window, nil, data)
+	_, err = checkPolicy(window, paddedCall(t, trc20Transfer(t, token, to, 41), 0x01))
+	assertViolation(t, err, "limit")
+
+	// Calldata too short to read the amount is refused outright.
+	short := paddedCall(t, trc20Transfer(t, token, to, 1))
+	var call core.TriggerSmartContract
+	require.NoError(t, proto.Unmarshal(short.RawData.Contract[0].Parameter.Value, &call))
+	call.Data = call.Data[:4+32]
+	short = policyTx(t, core.Transaction_Contract_TriggerSmartContract, &call)
+	for _, rules := range [][]Rule{perTx, window} {
+		_, err = checkPolicy(rules, short)
+		assertViolation(t, err, "calldata")
+	}
+}
+
+func TestForbidContractTypes(t *testing.T) {
+	rules := []Rule{ForbidContractTypes(core.Transaction_Contract_AccountPermissionUpdateContract)}
+
+	tx := policyTx(t, core.Transaction_Contract_AccountPermissionUpdateContract, &core.AccountPermissionUpdateContract{
+		OwnerAddress: policyAddr(0x01),
+	})
+	_, err := checkPolicy(rules, tx)
+	assertViolation(t, err, "contract_type")
+	_, err = checkPolicy(rules, trxTransfer(t, policyAddr(0x10), 1))
+	assert.NoError(t, err)
+}
+
+func TestExpirationWindow(t *testing.T) {
+	rule := ExpirationWindow(time.Hour).(*expirationRule)
+	now := time.Unix(1_700_000_000, 0)
+	rule.now = func() time.Time { return now }
+	rules := []Rule{rule}
+
+	for _, tc := range []struct {
+		expiration time.Time
+		ok         bool
+	}{
+		{now.Add(time.Minute), true},
+		{now.Add(time.Hour), true},
+		{now, false},
+		{now.Add(-time.Minute), false},
+		{now.Add(time.Hour + time.Second), false},
+	} {
+		tx := trxTransfer(t, policyAddr(0x10), 1)
+		tx.RawData.Expiration = tc.expiration.UnixMilli()
+		_, err := checkPolicy(rules, tx)
+		if tc.ok {
+			assert.NoError(t, err, tc.expiration)
+		} else {
+			assertViolation(t, err, "expiration")
+		}
+	}
+}
+
+func TestAllowPermissionIDs(t *testing.T) {
+	rules := []Rule{AllowPermissionIDs(2)}
+
+	tx := trxTransfer(t, policyAddr(0x10), 1)
+	_, err := checkPolicy(rules, tx)
+	assertViolation(t, err, "permission")
+
+	tx.RawData.Contract[0].PermissionId = 2
+	_, err = checkPolicy(rules, tx)
+	assert.NoError(t, err)
+}
+
+func TestWithPolicy(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	inner, err := NewPrivateKeySigner(key)
+	require.NoError(t, err)
+	s := WithPolicy(inner,
+		AllowDestinations(policyAddr(0x10)),
+		DailyLimit(AssetTRX, big.NewInt(10)),
+	)
+	assert.Equal(t, inner.Address(), s.Address())
+	_, isHashSigner := s.(HashSigner)
+	assert.False(t, isHashSigner, "raw hashes would get around the rules")
+
+	signed, err := s.Sign(trxTransfer(t, policyAddr(0x10), 6))
+	require.NoError(t, err)
+	assert.Len(t, signed.Signature, 1)
+
+	tx := trxTransfer(t, policyAddr(0x10), 6)
+	_, err = s.Sign(tx)
+	assertViolation(t, err, "limit")
+	assert.Empty(t, tx.Signature)
+
+	_, err = s.Sign(trxTransfer(t, policyAddr(0x11), 1))
+	assertViolation(t, err, "destination")
+	_, err = s.Sign(trxTransfer(t, policyAddr(0x10), 4))
+	assert.NoError(t, err, "refused transactions do not count against the limit")
+}
@@ -67,6 +67,16 @@ func (s *privateKeySigner) Sign(tx *core.Transaction) (*core.Transaction, error)
 	return tx, nil
 }
 
+// SignHash signs a 32-byte digest with the private key.
+func (s *privateKeySigner) SignHash(hash []byte) ([]byte, error) {
+	return crypto.Sign(hash, s.key)
+}
+
+// SignMessage signs message in the TIP-191 format.
+func (s *privateKeySigner) SignMessage(message []byte, format MessageFormat) ([]byte, error) {
+	return signMessageHash(s.SignHash, message, format)
+}
+
 // Address returns the TRON address derived from the signing key.
 // Returns a copy to prevent callers from mutating the cached address.
 func (s *privateKeySigner) Address() address.Address {
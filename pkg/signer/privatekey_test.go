@@ -254,3 +254,22 @@ func TestNewPrivateKeySignerFromBTCEC_SignAndVerifyAddress(t *testing.T) {
 	assert.Len(t, signed.Signature, 1)
 	assert.Len(t, signed.Signature[0], 65)
 }
+
+func TestPrivateKeySigner_SignHash(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	s, err := NewPrivateKeySigner(key)
+	require.NoError(t, err)
+	hs, ok := s.(HashSigner)
+	require.True(t, ok)
+
+	hash := crypto.Keccak256([]byte("typed data"))
+	sig, err := hs.SignHash(hash)
+	require.NoError(t, err)
+	require.Len(t, sig, 65)
+
+	pub, err := crypto.SigToPub(hash, sig)
+	require.NoError(t, err)
+	assert.Equal(t, key.PublicKey, *pub)
+}
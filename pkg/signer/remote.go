@@ -0,0 +1,205 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	proto "google.golang.org/protobuf/proto"
+)
+
+var _ Signer = (*Remote)(nil)
+
+// defaultRemoteTimeout bounds a signing round trip.
+const defaultRemoteTimeout = 30 * time.Second
+
+// Remote is a Signer that delegates signing to a Server, such as one
+// started with tronctl signer serve, so no private key lives in the
+// application process. Transactions refused by the server's policy fail
+// with a *PolicyViolation.
+type Remote struct {
+	baseURL string
+	client  *http.Client
+	addr    address.Address
+}
+
+type remoteConfig struct {
+	tls     *tls.Config
+	client  *http.Client
+	timeout time.Duration
+}
+
+// RemoteOption configures NewRemote.
+type RemoteOption func(*remoteConfig)
+
+// WithRemoteTLS sets the TLS configuration of an https endpoint, including
+// the client certificate for mutual TLS.
+func WithRemoteTLS(cfg *tls.Config) RemoteOption {
+	return func(c *remoteConfig) {
+		c.tls = cfg
+	}
+}
+
+// WithRemoteHTTPClient replaces the HTTP client; the endpoint transport and
+// TLS options are then ignored.
+func WithRemoteHTTPClient(client *http.Client) RemoteOption {
+	return func(c *remoteConfig) {
+		c.client = client
+	}
+}
+
+// WithRemoteTimeout sets the timeout of a signing request (default 30s).
+func WithRemoteTimeout(d time.Duration) RemoteOption {
+	return func(c *remoteConfig) {
+		c.timeout = d
+	}
+}
+
+// NewRemote returns a Signer for the account addr of the signer server at
+// endpoint, either "unix:///path/to/socket" or "https://host:port".
+func NewRemote(endpoint string, addr address.Address, opts ...RemoteOption) (*Remote, error) {
+	cfg := remoteConfig{timeout: defaultRemoteTimeout}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signer endpoint: %w", err)
+	}
+
+	r := &Remote{addr: append(address.Address(nil), addr...)}
+	transport := &http.Transport{TLSClientConfig: cfg.tls}
+	switch u.Scheme {
+	case "unix":
+		socket := u.Path
+		if socket == "" {
+			return nil, errors.New("invalid signer endpoint: missing socket path")
+		}
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", socket)
+		}
+		r.baseURL = "http://unix"
+	case "https", "http":
+		r.baseURL = strings.TrimSuffix(u.String(), "/")
+	default:
+		return nil, fmt.Errorf("unsupported signer endpoint scheme %q", u.Scheme)
+	}
+	r.client = cfg.client
+	if r.client == nil {
+		r.client = &http.Client{Transport: transport, Timeout: cfg.timeout}
+	}
+	return r, nil
+}
+
+// Address returns the TRON address of the remote account.
+func (r *Remote) Address() address.Address {
+	return append(address.Address(nil), r.addr...)
+}
+
+// Accounts returns the addresses the server signs for.
+func (r *Remote) Accounts(ctx context.Context) ([]address.Address, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.baseURL+"/v1/accounts", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("remote signer: unexpected status %s", resp.Status)
+	}
+	var out remoteAccountsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("remote signer: %w", err)
+	}
+	addrs := make([]address.Address, 0, len(out.Accounts))
+	for _, a := range out.Accounts {
+		addr, err := address.Base58ToAddress(a)
+		if err != nil {
+			return nil, fmt.Errorf("remote signer: %w", err)
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
+// Sign sends the transaction to the server and appends the returned
+// signature after checking it was made by the account's key over the same
+// raw data.
+func (r *Remote) Sign(tx *core.Transaction) (*core.Transaction, error) {
+	encoded, err := proto.Marshal(tx)
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(remoteSignRequest{Address: r.addr.String(), Transaction: hex.EncodeToString(encoded)})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.client.Post(r.baseURL+"/v1/sign", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var out remoteSignResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("remote signer: unexpected response (%s): %w", resp.Status, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if out.Rule != "" {
+			return nil, &PolicyViolation{Rule: out.Rule, Reason: out.Error}
+		}
+		return nil, fmt.Errorf("remote signer: %s", out.Error)
+	}
+
+	raw, err := hex.DecodeString(out.Transaction)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: %w", err)
+	}
+	signed := &core.Transaction{}
+	if err := proto.Unmarshal(raw, signed); err != nil {
+		return nil, fmt.Errorf("remote signer: %w", err)
+	}
+	if !proto.Equal(signed.GetRawData(), tx.GetRawData()) || len(signed.GetSignature()) != len(tx.GetSignature())+1 {
+		return nil, errors.New("remote signer: returned transaction does not match the request")
+	}
+	sig := signed.GetSignature()[len(signed.GetSignature())-1]
+	if err := r.checkSignature(tx, sig); err != nil {
+		return nil, err
+	}
+	tx.Signature = append(tx.Signature, sig)
+	return tx, nil
+}
+
+// checkSignature verifies that sig over tx recovers to the account.
+func (r *Remote) checkSignature(tx *core.Transaction, sig []byte) error {
+	rawData, err := proto.Marshal(tx.GetRawData())
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256(rawData)
+	pub, err := crypto.SigToPub(hash[:], sig)
+	if err != nil {
+		return fmt.Errorf("remote signer: invalid signature: %w", err)
+	}
+	if got := address.PubkeyToAddress(*pub); !bytes.Equal(got, r.addr) {
+		return fmt.Errorf("remote signer: signature is from %s, want %s", got, r.addr)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSigner(t *testing.T) Signer {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	s, err := NewPrivateKeySigner(key)
+	require.NoError(t, err)
+	return s
+}
+
+// serveUnix serves handler on a unix socket and returns its endpoint.
+func serveUnix(t *testing.T, handler http.Handler) string {
+	t.Helper()
+	socket := filepath.Join(t.TempDir(), "signer.sock")
+	l, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	srv := &http.Server{Handler: handler}
+	go func() { _ = srv.Serve(l) }()
+	t.Cleanup(func() { _ = srv.Close() })
+	return "unix://" + socket
+}
+
+func TestRemote_SignOverUnixSocket(t *testing.T) {
+	local := newTestSigner(t)
+	endpoint := serveUnix(t, NewServer([]Signer{local}, DailyLimit(AssetTRX, big.NewInt(5))))
+
+	remote, err := NewRemote(endpoint, local.Address())
+	require.NoError(t, err)
+
+	accounts, err := remote.Accounts(context.Background())
+	require.NoError(t, err)
+	require.Len(t, accounts, 1)
+	assert.Equal(t, local.Address().String(), accounts[0].String())
+
+	tx := trxTransfer(t, policyAddr(0x10), 3)
+	signed, err := remote.Sign(tx)
+	require.NoError(t, err)
+	require.Len(t, signed.Signature, 1)
+
+	want, err := local.Sign(trxTransfer(t, policyAddr(0x10), 3))
+	require.NoError(t, err)
+	assert.Equal(t, want.Signature[0], signed.Signature[0], "signatures are deterministic")
+
+	_, err = remote.Sign(trxTransfer(t, policyAddr(0x10), 3))
+	var violation *PolicyViolation
+	require.True(t, errors.As(err, &violation), "got %v", err)
+	assert.Equal(t, "limit", violation.Rule)
+}
+
+func TestRemote_UnknownAccount(t *testing.T) {
+	endpoint := serveUnix(t, NewServer([]Signer{newTestSigner(t)}))
+	remote, err := NewRemote(endpoint, newTestSigner(t).Address())
+	require.NoError(t, err)
+
+	_, err = remote.Sign(trxTransfer(t, policyAddr(0x10), 1))
+	assert.ErrorContains(t, err, "unknown account")
+}
+
+// lyingSigner signs with a different key than the address it reports.
+type lyingSigner struct {
+	Signer
+	addr address.Address
+}
+
+func (s lyingSigner) Address() address.Address { return s.addr }
+
+func TestRemote_RejectsForeignSignature(t *testing.T) {
+	claimed := newTestSigner(t).Address()
+	srv := httptest.NewServer(NewServer([]Signer{lyingSigner{Signer: newTestSigner(t), addr: claimed}}))
+	defer srv.Close()
+
+	remote, err := NewRemote(srv.URL, claimed)
+	require.NoError(t, err)
+	tx := trxTransfer(t, policyAddr(0x10), 1)
+	_, err = remote.Sign(tx)
+	assert.ErrorContains(t, err, "signature is from")
+	assert.Empty(t, tx.Signature)
+}
+
+func TestServer_InvalidRequests(t *testing.T) {
+	srv := httptest.NewServer(NewServer([]Signer{newTestSigner(t)}))
+	defer srv.Close()
+
+	resp, err := http.Post(srv.URL+"/v1/sign", "application/json", http.NoBody)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = http.Get(srv.URL + "/v1/sign")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestNewRemote_InvalidEndpoint(t *testing.T) {
+	for _, endpoint := range []string{"ftp://host", "unix://", "::"} {
+		_, err := NewRemote(endpoint, policyAddr(0x01))
+		assert.Error(t, err, endpoint)
+	}
+}
@@ -0,0 +1,141 @@
+package signer
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	proto "google.golang.org/protobuf/proto"
+)
+
+// maxRequestBytes bounds the size of a signing request.
+const maxRequestBytes = 1 << 20
+
+// remoteSignRequest asks the server to sign a protobuf-encoded transaction
+// with the key of Address.
+type remoteSignRequest struct {
+	Address     string `json:"address"`
+	Transaction string `json:"transaction"` // hex
+}
+
+// remoteSignResponse carries the signed transaction, or an error and, for
+// policy violations, the rule that refused it.
+type remoteSignResponse struct {
+	Transaction string `json:"transaction,omitempty"` // hex
+	Error       string `json:"error,omitempty"`
+	Rule        string `json:"rule,omitempty"`
+}
+
+type remoteAccountsResponse struct {
+	Accounts []string `json:"accounts"`
+}
+
+// Server serves signing requests of Remote clients over HTTP. Every
+// transaction is checked against the server's rules before it is signed.
+// Only transactions are signed, never raw hashes or messages, so a client
+// cannot get around the rules.
+//
+// Spending limits are shared by all accounts of the server.
+type Server struct {
+	signers map[string]Signer
+	rules   []Rule
+	mux     *http.ServeMux
+
+	// mu serializes check, sign and record so concurrent requests cannot
+	// overrun a spending limit together.
+	mu sync.Mutex
+}
+
+// NewServer returns a Server signing with signers under rules.
+func NewServer(signers []Signer, rules ...Rule) *Server {
+	s := &Server{
+		signers: make(map[string]Signer, len(signers)),
+		rules:   rules,
+		mux:     http.NewServeMux(),
+	}
+	for _, sg := range signers {
+		s.signers[sg.Address().String()] = sg
+	}
+	s.mux.HandleFunc("GET /v1/accounts", s.handleAccounts)
+	s.mux.HandleFunc("POST /v1/sign", s.handleSign)
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleAccounts(w http.ResponseWriter, _ *http.Request) {
+	resp := remoteAccountsResponse{Accounts: make([]string, 0, len(s.signers))}
+	for addr := range s.signers {
+		resp.Accounts = append(resp.Accounts, addr)
+	}
+	sort.Strings(resp.Accounts)
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleSign(w http.ResponseWriter, r *http.Request) {
+	var req remoteSignRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes)).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, remoteSignResponse{Error: "invalid request: " + err.Error()})
+		return
+	}
+	sg, ok := s.signers[req.Address]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, remoteSignResponse{Error: "unknown account " + req.Address})
+		return
+	}
+	raw, err := hex.DecodeString(req.Transaction)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, remoteSignResponse{Error: "invalid transaction: " + err.Error()})
+		return
+	}
+	tx := &core.Transaction{}
+	if err := proto.Unmarshal(raw, tx); err != nil {
+		writeJSON(w, http.StatusBadRequest, remoteSignResponse{Error: "invalid transaction: " + err.Error()})
+		return
+	}
+
+	signed, err := s.sign(sg, tx)
+	var violation *PolicyViolation
+	switch {
+	case errors.As(err, &violation):
+		writeJSON(w, http.StatusForbidden, remoteSignResponse{Error: violation.Reason, Rule: violation.Rule})
+		return
+	case err != nil:
+		writeJSON(w, http.StatusUnprocessableEntity, remoteSignResponse{Error: err.Error()})
+		return
+	}
+	out, err := proto.Marshal(signed)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, remoteSignResponse{Error: err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, remoteSignResponse{Transaction: hex.EncodeToString(out)})
+}
+
+func (s *Server) sign(sg Signer, tx *core.Transaction) (*core.Transaction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := checkPolicy(s.rules, tx)
+	if err != nil {
+		return nil, err
+	}
+	signed, err := sg.Sign(tx)
+	if err != nil {
+		return nil, err
+	}
+	recordPolicy(s.rules, signed, data)
+	return signed, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
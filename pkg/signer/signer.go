@@ -17,3 +17,14 @@ type Signer interface {
 	// Address returns the TRON address of the signing key.
 	Address() address.Address
 }
+
+// HashSigner is a Signer that can also sign an arbitrary 32-byte digest, as
+// needed for off-chain messages such as TIP-712 typed data. Hardware wallets
+// that only sign transactions do not implement it.
+type HashSigner interface {
+	Signer
+
+	// SignHash returns the 65-byte [R || S || V] signature of hash, where V
+	// is the recovery id (0 or 1).
+	SignHash(hash []byte) ([]byte, error)
+}
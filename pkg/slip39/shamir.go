@@ -0,0 +1,163 @@
+package slip39
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+const (
+	// secretIndex and digestIndex are the x coordinates of the shared
+	// secret and of its digest share.
+	secretIndex = 255
+	digestIndex = 254
+
+	digestLength = 4
+
+	// MaxShareCount is the largest number of shares in a group and of
+	// groups.
+	MaxShareCount = 16
+)
+
+// expTable and logTable implement GF(256) with the Rijndael polynomial
+// x^8 + x^4 + x^3 + x + 1 and generator 3.
+var expTable, logTable = gfTables()
+
+func gfTables() (exp [255]byte, log [256]byte) {
+	poly := 1
+	for i := 0; i < 255; i++ {
+		exp[i] = byte(poly)
+		log[poly] = byte(i)
+		// Multiply by the generator x + 1.
+		poly = (poly << 1) ^ poly
+		if poly&0x100 != 0 {
+			poly ^= 0x11b
+		}
+	}
+	return exp, log
+}
+
+// point is a share (x, f(x)) of a byte-wise polynomial.
+type point struct {
+	x     byte
+	value []byte
+}
+
+// interpolate evaluates at x the polynomial through points with Lagrange
+// interpolation in GF(256).
+func interpolate(points []point, x byte) ([]byte, error) {
+	seen := make(map[byte]bool, len(points))
+	for _, p := range points {
+		if seen[p.x] {
+			return nil, errors.New("share indices must be unique")
+		}
+		seen[p.x] = true
+		if len(p.value) != len(points[0].value) {
+			return nil, errors.New("all share values must have the same length")
+		}
+	}
+	for _, p := range points {
+		if p.x == x {
+			return append([]byte(nil), p.value...), nil
+		}
+	}
+
+	logProd := 0
+	for _, p := range points {
+		logProd += int(logTable[p.x^x])
+	}
+	result := make([]byte, len(points[0].value))
+	for _, p := range points {
+		logBasis := logProd - int(logTable[p.x^x])
+		for _, other := range points {
+			logBasis -= int(logTable[p.x^other.x])
+		}
+		logBasis = ((logBasis % 255) + 255) % 255
+		for i, v := range p.value {
+			if v != 0 {
+				result[i] ^= expTable[(int(logTable[v])+logBasis)%255]
+			}
+		}
+	}
+	return result, nil
+}
+
+// createDigest returns the first bytes of HMAC-SHA256(randomPart, secret).
+func createDigest(randomPart, secret []byte) []byte {
+	mac := hmac.New(sha256.New, randomPart)
+	mac.Write(secret)
+	return mac.Sum(nil)[:digestLength]
+}
+
+// splitSecret splits secret into count shares, threshold of which recover
+// it. With threshold > 1 a digest share lets recoverSecret detect wrong
+// combinations.
+func splitSecret(threshold, count int, secret []byte) ([]point, error) {
+	if threshold < 1 {
+		return nil, errors.New("threshold must be positive")
+	}
+	if threshold > count {
+		return nil, fmt.Errorf("threshold %d exceeds share count %d", threshold, count)
+	}
+	if count > MaxShareCount {
+		return nil, fmt.Errorf("share count %d exceeds %d", count, MaxShareCount)
+	}
+
+	if threshold == 1 {
+		shares := make([]point, count)
+		for i := range shares {
+			shares[i] = point{x: byte(i), value: append([]byte(nil), secret...)}
+		}
+		return shares, nil
+	}
+
+	randomCount := threshold - 2
+	shares := make([]point, 0, count)
+	for i := 0; i < randomCount; i++ {
+		value := make([]byte, len(secret))
+		if _, err := rand.Read(value); err != nil {
+			return nil, err
+		}
+		shares = append(shares, point{x: byte(i), value: value})
+	}
+	randomPart := make([]byte, len(secret)-digestLength)
+	if _, err := rand.Read(randomPart); err != nil {
+		return nil, err
+	}
+	digest := createDigest(randomPart, secret)
+
+	base := append(append([]point(nil), shares...),
+		point{x: digestIndex, value: append(digest, randomPart...)},
+		point{x: secretIndex, value: secret},
+	)
+	for i := randomCount; i < count; i++ {
+		value, err := interpolate(base, byte(i))
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, point{x: byte(i), value: value})
+	}
+	return shares, nil
+}
+
+// recoverSecret recovers the secret from threshold shares and checks the
+// digest.
+func recoverSecret(threshold int, shares []point) ([]byte, error) {
+	if threshold == 1 {
+		return shares[0].value, nil
+	}
+	secret, err := interpolate(shares, secretIndex)
+	if err != nil {
+		return nil, err
+	}
+	digestShare, err := interpolate(shares, digestIndex)
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal(digestShare[:digestLength], createDigest(digestShare[digestLength:], secret)) {
+		return nil, ErrInvalidDigest
+	}
+	return secret, nil
+}
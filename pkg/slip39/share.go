@@ -0,0 +1,185 @@
+package slip39
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	radixBits = 10
+	radix     = 1 << radixBits
+
+	idLengthBits   = 15
+	idExpWords     = 2 // identifier, extendable flag and iteration exponent
+	checksumWords  = 3
+	metadataWords  = idExpWords + 2 + checksumWords
+	minStrengthBit = 128
+	minShareWords  = metadataWords + (minStrengthBit+radixBits-1)/radixBits
+)
+
+var (
+	customizationOriginal   = []byte("shamir")
+	customizationExtendable = []byte("shamir_extendable")
+)
+
+// wordIndex maps every word of the wordlist to its index.
+var wordIndex = func() map[string]int {
+	m := make(map[string]int, radix)
+	for i, w := range wordlist {
+		m[w] = i
+	}
+	return m
+}()
+
+// share is a decoded SLIP-0039 mnemonic.
+type share struct {
+	identifier        uint16
+	extendable        bool
+	iterationExponent uint8
+	groupIndex        uint8
+	groupThreshold    uint8
+	groupCount        uint8
+	memberIndex       uint8
+	memberThreshold   uint8
+	value             []byte
+}
+
+// rs1024Polymod is the Reed-Solomon checksum of SLIP-0039 over GF(1024).
+func rs1024Polymod(values []int) int {
+	gen := [10]int{
+		0xe0e040, 0x1c1c080, 0x3838100, 0x7070200, 0xe0e0009,
+		0x1c0c2412, 0x38086c24, 0x3090fc48, 0x21b1f890, 0x3f3f120,
+	}
+	chk := 1
+	for _, v := range values {
+		b := chk >> 20
+		chk = (chk&0xfffff)<<10 ^ v
+		for i := 0; i < 10; i++ {
+			if (b>>i)&1 != 0 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+func customization(extendable bool) []int {
+	s := customizationOriginal
+	if extendable {
+		s = customizationExtendable
+	}
+	values := make([]int, len(s))
+	for i, c := range s {
+		values[i] = int(c)
+	}
+	return values
+}
+
+func createChecksum(data []int, extendable bool) []int {
+	values := append(customization(extendable), data...)
+	values = append(values, make([]int, checksumWords)...)
+	polymod := rs1024Polymod(values) ^ 1
+	checksum := make([]int, checksumWords)
+	for i := range checksum {
+		checksum[i] = (polymod >> (radixBits * (checksumWords - 1 - i))) & (radix - 1)
+	}
+	return checksum
+}
+
+func verifyChecksum(data []int, extendable bool) bool {
+	return rs1024Polymod(append(customization(extendable), data...)) == 1
+}
+
+// intToIndices splits value into count big-endian words of bits bits.
+func intToIndices(value *big.Int, count, bits int) []int {
+	mask := big.NewInt(int64(1)<<bits - 1)
+	out := make([]int, count)
+	v := new(big.Int).Set(value)
+	for i := count - 1; i >= 0; i-- {
+		out[i] = int(new(big.Int).And(v, mask).Int64())
+		v.Rsh(v, uint(bits))
+	}
+	return out
+}
+
+func indicesToInt(indices []int) *big.Int {
+	v := new(big.Int)
+	for _, idx := range indices {
+		v.Lsh(v, radixBits)
+		v.Or(v, big.NewInt(int64(idx)))
+	}
+	return v
+}
+
+// words returns the share as a mnemonic.
+func (s *share) words() string {
+	idExp := int(s.identifier)<<5 | int(s.iterationExponent)
+	if s.extendable {
+		idExp |= 1 << 4
+	}
+	data := intToIndices(big.NewInt(int64(idExp)), idExpWords, radixBits)
+	data = append(data,
+		int(s.groupIndex)<<6|int(s.groupThreshold-1)<<2|int(s.groupCount-1)>>2,
+		int(s.groupCount-1)&3<<8|int(s.memberIndex)<<4|int(s.memberThreshold-1),
+	)
+	valueWords := (len(s.value)*8 + radixBits - 1) / radixBits
+	data = append(data, intToIndices(new(big.Int).SetBytes(s.value), valueWords, radixBits)...)
+	data = append(data, createChecksum(data, s.extendable)...)
+
+	out := make([]string, len(data))
+	for i, idx := range data {
+		out[i] = wordlist[idx]
+	}
+	return strings.Join(out, " ")
+}
+
+// parseShare decodes and validates a mnemonic.
+func parseShare(mnemonic string) (*share, error) {
+	fields := strings.Fields(strings.ToLower(mnemonic))
+	if len(fields) < minShareWords {
+		return nil, fmt.Errorf("invalid mnemonic length: %d words, want at least %d", len(fields), minShareWords)
+	}
+	paddingBits := (radixBits * (len(fields) - metadataWords)) % 16
+	if paddingBits > 8 {
+		return nil, fmt.Errorf("invalid mnemonic length: %d words", len(fields))
+	}
+	data := make([]int, len(fields))
+	for i, w := range fields {
+		idx, ok := wordIndex[w]
+		if !ok {
+			return nil, fmt.Errorf("invalid mnemonic word %q", w)
+		}
+		data[i] = idx
+	}
+
+	idExp := int(indicesToInt(data[:idExpWords]).Int64())
+	s := &share{
+		identifier:        uint16(idExp >> 5),
+		extendable:        (idExp>>4)&1 == 1,
+		iterationExponent: uint8(idExp & 0xf),
+	}
+	if !verifyChecksum(data, s.extendable) {
+		return nil, ErrInvalidChecksum
+	}
+
+	params := intToIndices(indicesToInt(data[idExpWords:idExpWords+2]), 5, 4)
+	s.groupIndex = uint8(params[0])
+	s.groupThreshold = uint8(params[1]) + 1
+	s.groupCount = uint8(params[2]) + 1
+	s.memberIndex = uint8(params[3])
+	s.memberThreshold = uint8(params[4]) + 1
+	if s.groupCount < s.groupThreshold {
+		return nil, errors.New("invalid mnemonic: group threshold exceeds group count")
+	}
+
+	valueData := data[idExpWords+2 : len(data)-checksumWords]
+	valueBytes := (radixBits*len(valueData) - paddingBits) / 8
+	value := indicesToInt(valueData)
+	if value.BitLen() > valueBytes*8 {
+		return nil, errors.New("invalid mnemonic padding")
+	}
+	s.value = value.FillBytes(make([]byte, valueBytes))
+	return s, nil
+}
@@ -0,0 +1,275 @@
+// Package slip39 implements SLIP-0039 Shamir secret sharing: a secret such
+// as a private key or BIP-39 entropy is split into mnemonic shares organised
+// in groups, and any group threshold of groups, each with its member
+// threshold of shares, recovers it.
+package slip39
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/fbsobreira/go-bip39"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	baseIterationCount = 10000
+	roundCount         = 4
+
+	// MinSecretLength is the shortest master secret in bytes.
+	MinSecretLength = minStrengthBit / 8
+)
+
+var (
+	// ErrInvalidChecksum is returned for a share whose checksum does not
+	// match, usually because of a mistyped word.
+	ErrInvalidChecksum = errors.New("invalid share checksum")
+	// ErrInvalidDigest is returned when the shares do not belong together or
+	// one of them is corrupted.
+	ErrInvalidDigest = errors.New("invalid share digest")
+	// ErrInsufficientShares is returned when fewer shares than required by
+	// the thresholds are given.
+	ErrInsufficientShares = errors.New("insufficient shares")
+)
+
+// Group describes one group of shares: MemberThreshold of its MemberCount
+// shares reconstruct the group secret.
+type Group struct {
+	MemberThreshold int
+	MemberCount     int
+}
+
+type options struct {
+	iterationExponent uint8
+	extendable        bool
+}
+
+// Option configures Split.
+type Option func(*options)
+
+// WithIterationExponent sets the exponent e of the PBKDF2 iteration count
+// 10000·2^e used to encrypt the master secret. The default is 1.
+func WithIterationExponent(e uint8) Option {
+	return func(o *options) {
+		o.iterationExponent = e
+	}
+}
+
+// WithExtendable sets whether the shares are extendable, i.e. whether more
+// shares for the same secret can be generated later. The default is true.
+func WithExtendable(extendable bool) Option {
+	return func(o *options) {
+		o.extendable = extendable
+	}
+}
+
+// Split encrypts masterSecret with passphrase and splits it into groups of
+// mnemonic shares; groupThreshold of the groups are needed to recover it.
+// The result holds the mnemonics of every group in order.
+func Split(masterSecret []byte, passphrase string, groupThreshold int, groups []Group, opts ...Option) ([][]string, error) {
+	o := options{iterationExponent: 1, extendable: true}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if len(masterSecret) < MinSecretLength {
+		return nil, fmt.Errorf("master secret must be at least %d bytes", MinSecretLength)
+	}
+	if len(masterSecret)%2 != 0 {
+		return nil, errors.New("master secret length must be even")
+	}
+	if err := validatePassphrase(passphrase); err != nil {
+		return nil, err
+	}
+	if o.iterationExponent > 0xf {
+		return nil, fmt.Errorf("iteration exponent %d exceeds 15", o.iterationExponent)
+	}
+	if len(groups) == 0 {
+		return nil, errors.New("at least one group is required")
+	}
+	if groupThreshold > len(groups) {
+		return nil, fmt.Errorf("group threshold %d exceeds group count %d", groupThreshold, len(groups))
+	}
+	for i, g := range groups {
+		if g.MemberThreshold == 1 && g.MemberCount > 1 {
+			return nil, fmt.Errorf("group %d: a member threshold of 1 requires a single share", i+1)
+		}
+	}
+
+	var id [2]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, err
+	}
+	identifier := binary.BigEndian.Uint16(id[:]) & (1<<idLengthBits - 1)
+	ems := crypt(masterSecret, passphrase, o.iterationExponent, identifier, o.extendable, false)
+
+	groupShares, err := splitSecret(groupThreshold, len(groups), ems)
+	if err != nil {
+		return nil, err
+	}
+	result := make([][]string, len(groups))
+	for i, g := range groups {
+		memberShares, err := splitSecret(g.MemberThreshold, g.MemberCount, groupShares[i].value)
+		if err != nil {
+			return nil, fmt.Errorf("group %d: %w", i+1, err)
+		}
+		for _, m := range memberShares {
+			s := share{
+				identifier:        identifier,
+				extendable:        o.extendable,
+				iterationExponent: o.iterationExponent,
+				groupIndex:        groupShares[i].x,
+				groupThreshold:    uint8(groupThreshold),
+				groupCount:        uint8(len(groups)),
+				memberIndex:       m.x,
+				memberThreshold:   uint8(g.MemberThreshold),
+				value:             m.value,
+			}
+			result[i] = append(result[i], s.words())
+		}
+	}
+	return result, nil
+}
+
+// Combine recovers the master secret from mnemonic shares: exactly the
+// group threshold of groups, each with exactly its member threshold of
+// shares. A wrong passphrase yields a different secret rather than an
+// error, as SLIP-0039 intends.
+func Combine(mnemonics []string, passphrase string) ([]byte, error) {
+	if len(mnemonics) == 0 {
+		return nil, errors.New("no shares given")
+	}
+	if err := validatePassphrase(passphrase); err != nil {
+		return nil, err
+	}
+	shares := make([]*share, len(mnemonics))
+	for i, m := range mnemonics {
+		s, err := parseShare(m)
+		if err != nil {
+			return nil, fmt.Errorf("share %d: %w", i+1, err)
+		}
+		shares[i] = s
+	}
+
+	first := shares[0]
+	groups := make(map[uint8][]*share)
+	var order []uint8
+	for _, s := range shares {
+		if s.identifier != first.identifier || s.extendable != first.extendable ||
+			s.iterationExponent != first.iterationExponent {
+			return nil, errors.New("shares belong to different secrets")
+		}
+		if s.groupThreshold != first.groupThreshold || s.groupCount != first.groupCount {
+			return nil, errors.New("shares have inconsistent group parameters")
+		}
+		if len(s.value) != len(first.value) {
+			return nil, errors.New("shares have different lengths")
+		}
+		if _, ok := groups[s.groupIndex]; !ok {
+			order = append(order, s.groupIndex)
+		}
+		groups[s.groupIndex] = append(groups[s.groupIndex], s)
+	}
+	if len(first.value) < MinSecretLength || len(first.value)%2 != 0 {
+		return nil, fmt.Errorf("invalid share value length %d", len(first.value))
+	}
+	if len(groups) < int(first.groupThreshold) {
+		return nil, fmt.Errorf("%w: %d of %d groups", ErrInsufficientShares, len(groups), first.groupThreshold)
+	}
+	if len(groups) != int(first.groupThreshold) {
+		return nil, fmt.Errorf("wrong number of groups: want %d, got %d", first.groupThreshold, len(groups))
+	}
+
+	groupPoints := make([]point, 0, len(groups))
+	for _, gi := range order {
+		members := groups[gi]
+		threshold := members[0].memberThreshold
+		points := make([]point, 0, len(members))
+		seen := make(map[uint8]bool, len(members))
+		for _, m := range members {
+			if m.memberThreshold != threshold {
+				return nil, fmt.Errorf("group %d: shares have different member thresholds", gi+1)
+			}
+			if seen[m.memberIndex] {
+				return nil, fmt.Errorf("group %d: duplicate share %d", gi+1, m.memberIndex+1)
+			}
+			seen[m.memberIndex] = true
+			points = append(points, point{x: m.memberIndex, value: m.value})
+		}
+		if len(points) < int(threshold) {
+			return nil, fmt.Errorf("%w: group %d has %d of %d shares", ErrInsufficientShares, gi+1, len(points), threshold)
+		}
+		if len(points) != int(threshold) {
+			return nil, fmt.Errorf("group %d: wrong number of shares: want %d, got %d", gi+1, threshold, len(points))
+		}
+		groupSecret, err := recoverSecret(int(threshold), points)
+		if err != nil {
+			return nil, fmt.Errorf("group %d: %w", gi+1, err)
+		}
+		groupPoints = append(groupPoints, point{x: gi, value: groupSecret})
+	}
+
+	ems, err := recoverSecret(int(first.groupThreshold), groupPoints)
+	if err != nil {
+		return nil, err
+	}
+	return crypt(ems, passphrase, first.iterationExponent, first.identifier, first.extendable, true), nil
+}
+
+// SplitMnemonic splits the entropy of a BIP-39 mnemonic, so the shares
+// recover the very same mnemonic and with it every derived account.
+func SplitMnemonic(mnemonic, passphrase string, groupThreshold int, groups []Group, opts ...Option) ([][]string, error) {
+	entropy, err := bip39.EntropyFromMnemonic(mnemonic)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mnemonic: %w", err)
+	}
+	return Split(entropy, passphrase, groupThreshold, groups, opts...)
+}
+
+// CombineMnemonic recovers a BIP-39 mnemonic from shares created by
+// SplitMnemonic.
+func CombineMnemonic(mnemonics []string, passphrase string) (string, error) {
+	entropy, err := Combine(mnemonics, passphrase)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+func validatePassphrase(passphrase string) error {
+	for _, c := range passphrase {
+		if c < 32 || c > 126 {
+			return errors.New("passphrase must contain only printable ASCII characters")
+		}
+	}
+	return nil
+}
+
+// crypt runs the four-round Feistel network that encrypts (or, with
+// decrypt set, decrypts) the master secret with the passphrase.
+func crypt(secret []byte, passphrase string, iterationExponent uint8, identifier uint16, extendable, decrypt bool) []byte {
+	half := len(secret) / 2
+	l := append([]byte(nil), secret[:half]...)
+	r := append([]byte(nil), secret[half:]...)
+
+	var salt []byte
+	if !extendable {
+		salt = binary.BigEndian.AppendUint16(append([]byte(nil), customizationOriginal...), identifier)
+	}
+	iterations := (baseIterationCount << iterationExponent) / roundCount
+	for n := 0; n < roundCount; n++ {
+		i := n
+		if decrypt {
+			i = roundCount - 1 - n
+		}
+		key := append([]byte{byte(i)}, passphrase...)
+		f := pbkdf2.Key(key, append(append([]byte(nil), salt...), r...), iterations, len(r), sha256.New)
+		for j := range f {
+			f[j] ^= l[j]
+		}
+		l, r = r, f
+	}
+	return append(r, l...)
+}
@@ -0,0 +1,149 @@
+package slip39
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+// TestCombine_Vectors runs the official SLIP-0039 test vectors, which use
+// the passphrase "TREZOR". An empty secret marks an invalid share set.
+func TestCombine_Vectors(t *testing.T) {
+	raw, err := os.ReadFile("testdata/vectors.json")
+	require.NoError(t, err)
+	var vectors [][]json.RawMessage
+	require.NoError(t, json.Unmarshal(raw, &vectors))
+	require.NotEmpty(t, vectors)
+
+	for _, v := range vectors {
+		var name, secret string
+		var mnemonics []string
+		require.NoError(t, json.Unmarshal(v[0], &name))
+		require.NoError(t, json.Unmarshal(v[1], &mnemonics))
+		require.NoError(t, json.Unmarshal(v[2], &secret))
+
+		t.Run(name, func(t *testing.T) {
+			got, err := Combine(mnemonics, "TREZOR")
+			if secret == "" {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, secret, hex.EncodeToString(got))
+		})
+	}
+}
+
+func TestSplit_RoundTrip(t *testing.T) {
+	secret, err := hex.DecodeString("bb54aac4b89dc868ba37d9cc21b2cecebb54aac4b89dc868ba37d9cc21b2cece")
+	require.NoError(t, err)
+	groups := []Group{{1, 1}, {2, 3}, {3, 5}}
+
+	shares, err := Split(secret, "pass", 2, groups, WithIterationExponent(0))
+	require.NoError(t, err)
+	require.Len(t, shares, 3)
+	assert.Len(t, shares[1], 3)
+	assert.Len(t, shares[2], 5)
+	assert.Len(t, strings.Fields(shares[0][0]), 33, "256-bit secrets give 33-word shares")
+
+	got, err := Combine([]string{shares[0][0], shares[2][4], shares[2][0], shares[2][2]}, "pass")
+	require.NoError(t, err)
+	assert.Equal(t, secret, got)
+
+	got, err = Combine([]string{shares[1][2], shares[1][1], shares[0][0]}, "pass")
+	require.NoError(t, err)
+	assert.Equal(t, secret, got)
+
+	wrong, err := Combine([]string{shares[1][2], shares[1][1], shares[0][0]}, "other")
+	require.NoError(t, err, "a wrong passphrase decrypts to a different secret")
+	assert.NotEqual(t, secret, wrong)
+
+	_, err = Combine([]string{shares[1][0], shares[0][0]}, "pass")
+	assert.ErrorIs(t, err, ErrInsufficientShares)
+	_, err = Combine([]string{shares[1][0], shares[1][1]}, "pass")
+	assert.ErrorIs(t, err, ErrInsufficientShares)
+}
+
+func TestSplit_NotExtendable(t *testing.T) {
+	secret := []byte("0123456789abcdef")
+	shares, err := Split(secret, "", 1, []Group{{2, 2}}, WithExtendable(false), WithIterationExponent(0))
+	require.NoError(t, err)
+
+	s, err := parseShare(shares[0][0])
+	require.NoError(t, err)
+	assert.False(t, s.extendable)
+
+	got, err := Combine(shares[0], "")
+	require.NoError(t, err)
+	assert.Equal(t, secret, got)
+}
+
+func TestSplit_Invalid(t *testing.T) {
+	secret := make([]byte, 16)
+	tests := []struct {
+		name      string
+		secret    []byte
+		pass      string
+		threshold int
+		groups    []Group
+	}{
+		{"short secret", make([]byte, 14), "", 1, []Group{{1, 1}}},
+		{"odd secret", make([]byte, 17), "", 1, []Group{{1, 1}}},
+		{"non-ASCII passphrase", secret, "pässword", 1, []Group{{1, 1}}},
+		{"no groups", secret, "", 1, nil},
+		{"group threshold above count", secret, "", 2, []Group{{1, 1}}},
+		{"member threshold above count", secret, "", 1, []Group{{3, 2}}},
+		{"threshold one with many shares", secret, "", 1, []Group{{1, 2}}},
+		{"too many shares", secret, "", 1, []Group{{2, 17}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Split(tt.secret, tt.pass, tt.threshold, tt.groups)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCombine_InvalidShare(t *testing.T) {
+	shares, err := Split(make([]byte, 16), "", 1, []Group{{1, 1}}, WithIterationExponent(0))
+	require.NoError(t, err)
+	words := strings.Fields(shares[0][0])
+
+	words[5], words[6] = words[6], words[5]
+	_, err = Combine([]string{strings.Join(words, " ")}, "")
+	assert.ErrorIs(t, err, ErrInvalidChecksum)
+
+	words[5] = "notaword"
+	_, err = Combine([]string{strings.Join(words, " ")}, "")
+	assert.ErrorContains(t, err, "invalid mnemonic word")
+}
+
+func TestSplitMnemonic_RoundTrip(t *testing.T) {
+	shares, err := SplitMnemonic(testMnemonic, "", 1, []Group{{2, 3}}, WithIterationExponent(0))
+	require.NoError(t, err)
+	assert.Len(t, strings.Fields(shares[0][0]), 20)
+
+	got, err := CombineMnemonic([]string{shares[0][2], shares[0][0]}, "")
+	require.NoError(t, err)
+	assert.Equal(t, testMnemonic, got)
+
+	_, err = SplitMnemonic("abandon abandon", "", 1, []Group{{1, 1}})
+	assert.Error(t, err)
+}
+
+func TestGF256Tables(t *testing.T) {
+	seen := make(map[byte]bool)
+	for _, v := range expTable {
+		assert.False(t, seen[v], "generator must cycle through every non-zero element")
+		seen[v] = true
+		assert.Equal(t, v, expTable[logTable[v]])
+	}
+	assert.Len(t, seen, 255)
+}
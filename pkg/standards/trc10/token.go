@@ -0,0 +1,188 @@
+// Package trc10 provides a typed, high-level wrapper for TRC10 tokens that
+// mirrors trc20.Token, so wallets can treat both token types the same way.
+// Writes are returned as txbuilder transactions.
+package trc10
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/fbsobreira/gotron-sdk/pkg/standards/trc20"
+	"github.com/fbsobreira/gotron-sdk/pkg/txbuilder"
+)
+
+// Client is the subset of GrpcClient that Token needs.
+type Client interface {
+	txbuilder.AssetClient
+	GetAccountCtx(ctx context.Context, addr string) (*core.Account, error)
+}
+
+// TokenInfo holds the asset issue details returned by Info.
+type TokenInfo struct {
+	ID          string
+	Name        string
+	Abbr        string
+	Precision   uint8
+	TotalSupply int64 // in base units
+	Owner       string
+	// ICOStart and ICOEnd bound the window in which Participate is accepted.
+	ICOStart time.Time
+	ICOEnd   time.Time
+	// During the ICO, TrxNum SUN buy Num base units.
+	TrxNum int32
+	Num    int32
+}
+
+// Token is a typed TRC10 token handle.
+type Token struct {
+	client  Client
+	tokenID string
+	cache   *trc20.MetadataCache // nil means no caching (opt-in)
+	builder *txbuilder.Builder
+}
+
+// TokenOption configures optional Token behavior.
+type TokenOption func(*Token)
+
+// WithCache enables metadata caching for this Token instance. Name, abbr and
+// precision are stored under the token ID in the same cache used by TRC20
+// tokens, so a single MetadataCache can be shared across both standards.
+func WithCache(c *trc20.MetadataCache) TokenOption {
+	return func(t *Token) {
+		t.cache = c
+	}
+}
+
+// New creates a Token instance for the given TRC10 token ID.
+func New(client Client, tokenID string, opts ...TokenOption) *Token {
+	t := &Token{
+		client:  client,
+		tokenID: tokenID,
+		builder: txbuilder.New(client),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// ID returns the token ID.
+func (t *Token) ID() string {
+	return t.tokenID
+}
+
+// Info retrieves the asset issue details of the token. It always queries the
+// network and refreshes the metadata cache, if one is configured.
+func (t *Token) Info(ctx context.Context) (*TokenInfo, error) {
+	asset, err := t.client.GetAssetIssueByIDCtx(ctx, t.tokenID)
+	if err != nil {
+		return nil, err
+	}
+	if len(asset.GetOwnerAddress()) == 0 {
+		return nil, fmt.Errorf("token %s not found", t.tokenID)
+	}
+	if asset.GetPrecision() < 0 || asset.GetPrecision() > 255 {
+		return nil, fmt.Errorf("precision value %d out of uint8 range", asset.GetPrecision())
+	}
+	info := &TokenInfo{
+		ID:          t.tokenID,
+		Name:        string(asset.GetName()),
+		Abbr:        string(asset.GetAbbr()),
+		Precision:   uint8(asset.GetPrecision()),
+		TotalSupply: asset.GetTotalSupply(),
+		Owner:       address.Address(asset.GetOwnerAddress()).String(),
+		ICOStart:    time.UnixMilli(asset.GetStartTime()),
+		ICOEnd:      time.UnixMilli(asset.GetEndTime()),
+		TrxNum:      asset.GetTrxNum(),
+		Num:         asset.GetNum(),
+	}
+	if t.cache != nil {
+		t.cache.Store(t.tokenID, trc20.Metadata{
+			Name:     info.Name,
+			Symbol:   info.Abbr,
+			Decimals: info.Precision,
+		})
+	}
+	return info, nil
+}
+
+// metadata returns the cached name, abbr and precision, fetching them with
+// Info on a cache miss.
+func (t *Token) metadata(ctx context.Context) (trc20.Metadata, error) {
+	if t.cache != nil {
+		if meta, ok := t.cache.Lookup(t.tokenID); ok {
+			return meta, nil
+		}
+	}
+	info, err := t.Info(ctx)
+	if err != nil {
+		return trc20.Metadata{}, err
+	}
+	return trc20.Metadata{Name: info.Name, Symbol: info.Abbr, Decimals: info.Precision}, nil
+}
+
+// Name returns the token name. If a MetadataCache is configured, the result
+// is cached after the first successful fetch.
+func (t *Token) Name(ctx context.Context) (string, error) {
+	meta, err := t.metadata(ctx)
+	return meta.Name, err
+}
+
+// Symbol returns the token abbreviation, the TRC10 counterpart of a TRC20
+// symbol. If a MetadataCache is configured, the result is cached after the
+// first successful fetch.
+func (t *Token) Symbol(ctx context.Context) (string, error) {
+	meta, err := t.metadata(ctx)
+	return meta.Symbol, err
+}
+
+// Decimals returns the token precision. If a MetadataCache is configured, the
+// result is cached after the first successful fetch.
+func (t *Token) Decimals(ctx context.Context) (uint8, error) {
+	meta, err := t.metadata(ctx)
+	return meta.Decimals, err
+}
+
+// BalanceOf returns the token balance of addr from its account AssetV2 map,
+// formatted with the token precision. Accounts that never held the token,
+// including inactive accounts (client.ErrAccountNotFound), have a zero
+// balance.
+func (t *Token) BalanceOf(ctx context.Context, addr string) (*trc20.Balance, error) {
+	if _, err := address.Base58ToAddress(addr); err != nil {
+		return nil, fmt.Errorf("invalid address %s: %w", addr, err)
+	}
+	acc, err := t.client.GetAccountCtx(ctx, addr)
+	if errors.Is(err, client.ErrAccountNotFound) {
+		acc = &core.Account{}
+	} else if err != nil {
+		return nil, err
+	}
+	meta, err := t.metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	raw := big.NewInt(acc.GetAssetV2()[t.tokenID])
+	return &trc20.Balance{
+		Raw:     raw,
+		Display: trc20.FormatBalance(raw, meta.Decimals),
+		Symbol:  meta.Symbol,
+	}, nil
+}
+
+// Transfer returns a transaction sending amount base units of the token from
+// from to to.
+func (t *Token) Transfer(from, to string, amount int64, opts ...txbuilder.Option) *txbuilder.Tx {
+	return t.builder.TransferAsset(from, to, t.tokenID, amount, opts...)
+}
+
+// Participate returns a transaction buying the token in its ICO by spending
+// amount SUN. The issuer is looked up when the transaction is built.
+func (t *Token) Participate(from string, amount int64, opts ...txbuilder.Option) *txbuilder.Tx {
+	return t.builder.ParticipateAssetIssue(from, t.tokenID, amount, opts...)
+}
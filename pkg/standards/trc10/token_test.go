@@ -0,0 +1,176 @@
+package trc10
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/fbsobreira/gotron-sdk/pkg/standards/trc20"
+	"github.com/fbsobreira/gotron-sdk/pkg/txbuilder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	tokenID   = "1002000"
+	ownerAddr = "TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b"
+	otherAddr = "TLyqzVGLV1srkB7dToTAEqgDSfPtXRJZYH"
+)
+
+// mockClient serves a single asset and account. Builder methods not
+// overridden here panic through the nil embedded interface.
+type mockClient struct {
+	txbuilder.Client
+	asset       *core.AssetIssueContract
+	account     *core.Account
+	accountErr  error
+	assetCalls  int
+	participant string
+	issuer      string
+}
+
+func (m *mockClient) GetAssetIssueByIDCtx(_ context.Context, id string) (*core.AssetIssueContract, error) {
+	m.assetCalls++
+	if id != tokenID {
+		return &core.AssetIssueContract{}, nil
+	}
+	return m.asset, nil
+}
+
+func (m *mockClient) GetAccountCtx(context.Context, string) (*core.Account, error) {
+	if m.accountErr != nil {
+		return nil, m.accountErr
+	}
+	if m.account == nil {
+		return nil, client.ErrAccountNotFound
+	}
+	return m.account, nil
+}
+
+func (m *mockClient) TransferAssetCtx(context.Context, string, string, string, int64) (*api.TransactionExtention, error) {
+	return dummyTx(), nil
+}
+
+func (m *mockClient) ParticipateAssetIssueCtx(_ context.Context, from, issuer, _ string, _ int64) (*api.TransactionExtention, error) {
+	m.participant = from
+	m.issuer = issuer
+	return dummyTx(), nil
+}
+
+func dummyTx() *api.TransactionExtention {
+	return &api.TransactionExtention{
+		Transaction: &core.Transaction{RawData: &core.TransactionRaw{}},
+		Result:      &api.Return{Result: true},
+	}
+}
+
+func newMock(t *testing.T) *mockClient {
+	owner, err := address.Base58ToAddress(ownerAddr)
+	require.NoError(t, err)
+	return &mockClient{
+		asset: &core.AssetIssueContract{
+			Id:           tokenID,
+			OwnerAddress: owner,
+			Name:         []byte("BitTorrent"),
+			Abbr:         []byte("BTT"),
+			Precision:    6,
+			TotalSupply:  990_000_000_000_000_000,
+			StartTime:    1548000000000,
+			EndTime:      1548000001000,
+			TrxNum:       1,
+			Num:          1,
+		},
+		account: &core.Account{AssetV2: map[string]int64{tokenID: 1_234_500_000}},
+	}
+}
+
+func TestInfo(t *testing.T) {
+	m := newMock(t)
+	info, err := New(m, tokenID).Info(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &TokenInfo{
+		ID:          tokenID,
+		Name:        "BitTorrent",
+		Abbr:        "BTT",
+		Precision:   6,
+		TotalSupply: 990_000_000_000_000_000,
+		Owner:       ownerAddr,
+		ICOStart:    time.UnixMilli(1548000000000),
+		ICOEnd:      time.UnixMilli(1548000001000),
+		TrxNum:      1,
+		Num:         1,
+	}, info)
+
+	_, err = New(m, "999").Info(context.Background())
+	assert.ErrorContains(t, err, "token 999 not found")
+}
+
+func TestBalanceOf(t *testing.T) {
+	m := newMock(t)
+	token := New(m, tokenID)
+
+	bal, err := token.BalanceOf(context.Background(), otherAddr)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1_234_500_000), bal.Raw.Int64())
+	assert.Equal(t, "1,234.5", bal.Display)
+	assert.Equal(t, "BTT", bal.Symbol)
+
+	m.account = &core.Account{}
+	bal, err = token.BalanceOf(context.Background(), otherAddr)
+	require.NoError(t, err)
+	assert.Equal(t, "0", bal.Display)
+
+	// Inactive accounts are not found on chain.
+	m.account = nil
+	bal, err = token.BalanceOf(context.Background(), otherAddr)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), bal.Raw.Int64())
+	assert.Equal(t, "0", bal.Display)
+
+	m.accountErr = errors.New("connection refused")
+	_, err = token.BalanceOf(context.Background(), otherAddr)
+	assert.ErrorContains(t, err, "connection refused")
+
+	_, err = token.BalanceOf(context.Background(), "bad")
+	assert.ErrorContains(t, err, "invalid address")
+}
+
+func TestSharedCache(t *testing.T) {
+	m := newMock(t)
+	cache := trc20.NewMetadataCache(10)
+	token := New(m, tokenID, WithCache(cache))
+	ctx := context.Background()
+
+	_, err := token.BalanceOf(ctx, otherAddr)
+	require.NoError(t, err)
+	decimals, err := token.Decimals(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(6), decimals)
+	symbol, err := New(m, tokenID, WithCache(cache)).Symbol(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "BTT", symbol)
+	assert.Equal(t, 1, m.assetCalls)
+
+	meta, ok := cache.Lookup(tokenID)
+	require.True(t, ok)
+	assert.Equal(t, trc20.Metadata{Name: "BitTorrent", Symbol: "BTT", Decimals: 6}, meta)
+}
+
+func TestWrites(t *testing.T) {
+	m := newMock(t)
+	token := New(m, tokenID)
+	ctx := context.Background()
+
+	_, err := token.Transfer(ownerAddr, otherAddr, 10).Build(ctx)
+	require.NoError(t, err)
+
+	_, err = token.Participate(otherAddr, 1_000_000, txbuilder.WithMemo("ico")).Build(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, otherAddr, m.participant)
+	assert.Equal(t, ownerAddr, m.issuer)
+}
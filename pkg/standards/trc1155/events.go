@@ -0,0 +1,116 @@
+package trc1155
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	eABI "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+)
+
+// ErrNotEvent is returned when a log is not the requested TRC-1155 event.
+var ErrNotEvent = errors.New("log is not the requested TRC-1155 event")
+
+// parsedABI is the token ABI, parsed once for event decoding.
+var parsedABI = mustParseABI()
+
+// Event topics (Keccak-256 of the event signature).
+var (
+	TopicTransferSingle = parsedABI.Events["TransferSingle"].ID.Bytes()
+	TopicTransferBatch  = parsedABI.Events["TransferBatch"].ID.Bytes()
+)
+
+// TransferSingleEvent is a decoded TransferSingle log. From is empty for
+// mints and To is empty for burns.
+type TransferSingleEvent struct {
+	Operator string
+	From     string
+	To       string
+	ID       *big.Int
+	Value    *big.Int
+}
+
+// TransferBatchEvent is a decoded TransferBatch log: Values[i] of IDs[i]
+// moved for each i.
+type TransferBatchEvent struct {
+	Operator string
+	From     string
+	To       string
+	IDs      []*big.Int
+	Values   []*big.Int
+}
+
+// DecodeTransferSingle decodes a TransferSingle log.
+func DecodeTransferSingle(log *core.TransactionInfo_Log) (*TransferSingleEvent, error) {
+	topics, values, err := decodeEvent(log, "TransferSingle")
+	if err != nil {
+		return nil, err
+	}
+	return &TransferSingleEvent{
+		Operator: topicAddress(topics[1]),
+		From:     topicAddress(topics[2]),
+		To:       topicAddress(topics[3]),
+		ID:       values[0].(*big.Int),
+		Value:    values[1].(*big.Int),
+	}, nil
+}
+
+// DecodeTransferBatch decodes a TransferBatch log.
+func DecodeTransferBatch(log *core.TransactionInfo_Log) (*TransferBatchEvent, error) {
+	topics, values, err := decodeEvent(log, "TransferBatch")
+	if err != nil {
+		return nil, err
+	}
+	ev := &TransferBatchEvent{
+		Operator: topicAddress(topics[1]),
+		From:     topicAddress(topics[2]),
+		To:       topicAddress(topics[3]),
+		IDs:      values[0].([]*big.Int),
+		Values:   values[1].([]*big.Int),
+	}
+	if len(ev.IDs) != len(ev.Values) {
+		return nil, fmt.Errorf("TransferBatch: %d ids but %d values", len(ev.IDs), len(ev.Values))
+	}
+	return ev, nil
+}
+
+// decodeEvent checks the topics of log against the named event and unpacks
+// its data.
+func decodeEvent(log *core.TransactionInfo_Log, name string) ([][]byte, []interface{}, error) {
+	ev := parsedABI.Events[name]
+	topics := log.GetTopics()
+	if len(topics) != 4 || !bytes.Equal(topics[0], ev.ID.Bytes()) {
+		return nil, nil, ErrNotEvent
+	}
+	for _, t := range topics[1:] {
+		if len(t) != 32 {
+			return nil, nil, fmt.Errorf("%s: topic length %d, want 32", name, len(t))
+		}
+	}
+	values, err := ev.Inputs.NonIndexed().Unpack(log.GetData())
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: unpack data: %w", name, err)
+	}
+	return topics, values, nil
+}
+
+// topicAddress decodes an indexed address, returning "" for the zero
+// address.
+func topicAddress(topic []byte) string {
+	if bytes.Equal(topic[12:], make([]byte, 20)) {
+		return ""
+	}
+	return append(address.Address{address.TronBytePrefix}, topic[12:]...).String()
+}
+
+func mustParseABI() eABI.ABI {
+	parsed, err := abi.ParseABI(ABI)
+	if err != nil {
+		panic(fmt.Sprintf("trc1155: invalid ABI: %v", err))
+	}
+	return parsed
+}
@@ -0,0 +1,57 @@
+package trc1155
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func addressTopic(t *testing.T, addr string) []byte {
+	if addr == "" {
+		return make([]byte, 32)
+	}
+	return append(make([]byte, 12), mustAddress(t, addr)[1:]...)
+}
+
+func TestDecodeEvents(t *testing.T) {
+	assert.Equal(t, "c3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62", hex.EncodeToString(TopicTransferSingle))
+	assert.Equal(t, "4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb", hex.EncodeToString(TopicTransferBatch))
+
+	single := &core.TransactionInfo_Log{
+		Topics: [][]byte{TopicTransferSingle, addressTopic(t, otherAddr), addressTopic(t, ""), addressTopic(t, ownerAddr)},
+		Data:   append(encode(t, "uint256", big.NewInt(7)), encode(t, "uint256", big.NewInt(3))...),
+	}
+	ev, err := DecodeTransferSingle(single)
+	require.NoError(t, err)
+	assert.Equal(t, &TransferSingleEvent{Operator: otherAddr, To: ownerAddr, ID: big.NewInt(7), Value: big.NewInt(3)}, ev)
+
+	batchArgs, err := parsedABI.Events["TransferBatch"].Inputs.NonIndexed().Pack(
+		[]*big.Int{big.NewInt(1), big.NewInt(2)}, []*big.Int{big.NewInt(10), big.NewInt(20)})
+	require.NoError(t, err)
+	batch := &core.TransactionInfo_Log{
+		Topics: [][]byte{TopicTransferBatch, addressTopic(t, ownerAddr), addressTopic(t, ownerAddr), addressTopic(t, otherAddr)},
+		Data:   batchArgs,
+	}
+	bev, err := DecodeTransferBatch(batch)
+	require.NoError(t, err)
+	assert.Equal(t, &TransferBatchEvent{
+		Operator: ownerAddr,
+		From:     ownerAddr,
+		To:       otherAddr,
+		IDs:      []*big.Int{big.NewInt(1), big.NewInt(2)},
+		Values:   []*big.Int{big.NewInt(10), big.NewInt(20)},
+	}, bev)
+
+	_, err = DecodeTransferSingle(batch)
+	assert.ErrorIs(t, err, ErrNotEvent)
+	_, err = DecodeTransferBatch(single)
+	assert.ErrorIs(t, err, ErrNotEvent)
+
+	single.Data = single.Data[:32]
+	_, err = DecodeTransferSingle(single)
+	assert.Error(t, err)
+}
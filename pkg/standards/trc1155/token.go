@@ -0,0 +1,267 @@
+// Package trc1155 provides a typed, high-level wrapper for TRC-1155
+// multi-token interactions, built on top of the contract call builder.
+package trc1155
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/contract"
+	"github.com/fbsobreira/gotron-sdk/pkg/standards/trc20"
+)
+
+// idPlaceholder is replaced by the token ID in URI templates.
+const idPlaceholder = "{id}"
+
+// Token is a typed TRC-1155 token handle.
+type Token struct {
+	client          contract.Client
+	contractAddress string
+	cache           *trc20.MetadataCache // nil means no caching (opt-in)
+}
+
+// TokenOption configures optional Token behavior.
+type TokenOption func(*Token)
+
+// WithCache enables metadata caching for this Token instance. The name,
+// symbol and token URIs are fetched from the network once and served from
+// memory on subsequent calls. The cache is the trc20 MetadataCache, so one
+// cache can be shared across TRC-20, TRC-10 and TRC-1155 tokens; each cached
+// token URI takes one of its entries.
+func WithCache(c *trc20.MetadataCache) TokenOption {
+	return func(t *Token) {
+		t.cache = c
+	}
+}
+
+// New creates a Token instance for the given TRC-1155 contract.
+func New(client contract.Client, contractAddress string, opts ...TokenOption) *Token {
+	t := &Token{
+		client:          client,
+		contractAddress: contractAddress,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// call starts a ContractCall against the token ABI.
+func (t *Token) call(method string, args ...interface{}) *contract.ContractCall {
+	return contract.New(t.client, t.contractAddress).
+		WithABI(ABI).
+		Method(method).
+		Args(args...)
+}
+
+// Name returns the collection name. The getter is not part of TRC-1155 but
+// is implemented by most collections. If a MetadataCache is configured, the
+// result is cached after the first successful fetch.
+func (t *Token) Name(ctx context.Context) (string, error) {
+	if t.cache != nil {
+		if name, ok := t.cache.LookupName(t.contractAddress); ok {
+			return name, nil
+		}
+	}
+	var name string
+	if err := t.call("name").CallInto(ctx, &name); err != nil {
+		return "", err
+	}
+	if t.cache != nil {
+		t.cache.StoreName(t.contractAddress, name)
+	}
+	return name, nil
+}
+
+// Symbol returns the collection symbol; see Name.
+func (t *Token) Symbol(ctx context.Context) (string, error) {
+	if t.cache != nil {
+		if symbol, ok := t.cache.LookupSymbol(t.contractAddress); ok {
+			return symbol, nil
+		}
+	}
+	var symbol string
+	if err := t.call("symbol").CallInto(ctx, &symbol); err != nil {
+		return "", err
+	}
+	if t.cache != nil {
+		t.cache.StoreSymbol(t.contractAddress, symbol)
+	}
+	return symbol, nil
+}
+
+// URI returns the raw uri(id) result, which may be a template containing
+// {id}. If a MetadataCache is configured, the result is cached per token ID:
+// contracts may return a shared template for some IDs and a per-token URI
+// for others.
+func (t *Token) URI(ctx context.Context, id *big.Int) (string, error) {
+	key := t.contractAddress + "/" + id.String()
+	if t.cache != nil {
+		if uri, ok := t.cache.LookupURI(key); ok {
+			return uri, nil
+		}
+	}
+	var uri string
+	if err := t.call("uri", id).CallInto(ctx, &uri); err != nil {
+		return "", err
+	}
+	if t.cache != nil {
+		t.cache.StoreURI(key, uri)
+	}
+	return uri, nil
+}
+
+// TokenURI returns the metadata URI of id with the {id} placeholder
+// substituted (see ExpandURI).
+func (t *Token) TokenURI(ctx context.Context, id *big.Int) (string, error) {
+	uri, err := t.URI(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return ExpandURI(uri, id), nil
+}
+
+// ExpandURI substitutes every {id} in template with id as 64 lowercase hex
+// characters, zero-padded, as the metadata URI extension specifies.
+func ExpandURI(template string, id *big.Int) string {
+	return strings.ReplaceAll(template, idPlaceholder, fmt.Sprintf("%064x", id))
+}
+
+// BalanceOf returns the amount of token id held by account.
+func (t *Token) BalanceOf(ctx context.Context, account string, id *big.Int) (*big.Int, error) {
+	accountAddr, err := address.Base58ToAddress(account)
+	if err != nil {
+		return nil, fmt.Errorf("invalid account address %s: %w", account, err)
+	}
+	var balance *big.Int
+	if err := t.call("balanceOf", accountAddr, id).CallInto(ctx, &balance); err != nil {
+		return nil, err
+	}
+	return balance, nil
+}
+
+// BalanceOfBatch returns the balance of accounts[i] in ids[i] for each i in a
+// single call.
+func (t *Token) BalanceOfBatch(ctx context.Context, accounts []string, ids []*big.Int) ([]*big.Int, error) {
+	if len(accounts) != len(ids) {
+		return nil, fmt.Errorf("accounts and ids length mismatch: %d != %d", len(accounts), len(ids))
+	}
+	addrs := make([]address.Address, len(accounts))
+	for i, account := range accounts {
+		addr, err := address.Base58ToAddress(account)
+		if err != nil {
+			return nil, fmt.Errorf("invalid account address %s: %w", account, err)
+		}
+		addrs[i] = addr
+	}
+	var balances []*big.Int
+	if err := t.call("balanceOfBatch", addrs, ids).CallInto(ctx, &balances); err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
+
+// IsApprovedForAll reports whether operator may transfer all of account's
+// tokens.
+func (t *Token) IsApprovedForAll(ctx context.Context, account, operator string) (bool, error) {
+	accountAddr, err := address.Base58ToAddress(account)
+	if err != nil {
+		return false, fmt.Errorf("invalid account address %s: %w", account, err)
+	}
+	operatorAddr, err := address.Base58ToAddress(operator)
+	if err != nil {
+		return false, fmt.Errorf("invalid operator address %s: %w", operator, err)
+	}
+	var approved bool
+	if err := t.call("isApprovedForAll", accountAddr, operatorAddr).CallInto(ctx, &approved); err != nil {
+		return false, err
+	}
+	return approved, nil
+}
+
+// SupportsInterface calls the contract's ERC-165 supportsInterface. A
+// contract without ERC-165 reverts or returns nothing, which is reported as
+// false.
+func (t *Token) SupportsInterface(ctx context.Context, interfaceID [4]byte) (bool, error) {
+	var supported bool
+	err := t.call("supportsInterface", interfaceID).CallInto(ctx, &supported)
+	var rerr *contract.RevertError
+	if errors.As(err, &rerr) || errors.Is(err, contract.ErrEmptyResult) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return supported, nil
+}
+
+// SafeTransferFrom returns a ContractCall transferring amount of token id
+// from from to to, passing data to the recipient's onTRC1155Received hook.
+// The caller parameter is the address that signs the transaction: from
+// itself or an approved operator. Address validation errors are deferred
+// and surface when a terminal (Build/Send) is called.
+func (t *Token) SafeTransferFrom(caller, from, to string, id, amount *big.Int, data []byte, opts ...contract.Option) *contract.ContractCall {
+	fromAddr, toAddr, err := transferAddresses(from, to)
+	if err != nil {
+		return contract.New(t.client, t.contractAddress).SetError(err)
+	}
+	return t.call("safeTransferFrom", fromAddr, toAddr, id, amount, nonNil(data)).
+		From(caller).
+		Apply(opts...)
+}
+
+// SafeBatchTransferFrom returns a ContractCall transferring amounts[i] of
+// ids[i] for each i in one transaction. See SafeTransferFrom for caller and
+// data.
+func (t *Token) SafeBatchTransferFrom(caller, from, to string, ids, amounts []*big.Int, data []byte, opts ...contract.Option) *contract.ContractCall {
+	if len(ids) != len(amounts) {
+		return contract.New(t.client, t.contractAddress).
+			SetError(fmt.Errorf("ids and amounts length mismatch: %d != %d", len(ids), len(amounts)))
+	}
+	fromAddr, toAddr, err := transferAddresses(from, to)
+	if err != nil {
+		return contract.New(t.client, t.contractAddress).SetError(err)
+	}
+	return t.call("safeBatchTransferFrom", fromAddr, toAddr, ids, amounts, nonNil(data)).
+		From(caller).
+		Apply(opts...)
+}
+
+// SetApprovalForAll returns a ContractCall granting or revoking operator's
+// permission to transfer all of from's tokens.
+func (t *Token) SetApprovalForAll(from, operator string, approved bool, opts ...contract.Option) *contract.ContractCall {
+	operatorAddr, err := address.Base58ToAddress(operator)
+	if err != nil {
+		return contract.New(t.client, t.contractAddress).
+			SetError(fmt.Errorf("invalid operator address %s: %w", operator, err))
+	}
+	return t.call("setApprovalForAll", operatorAddr, approved).
+		From(from).
+		Apply(opts...)
+}
+
+// transferAddresses validates the from and to addresses of a transfer.
+func transferAddresses(from, to string) (address.Address, address.Address, error) {
+	fromAddr, err := address.Base58ToAddress(from)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid from address %s: %w", from, err)
+	}
+	toAddr, err := address.Base58ToAddress(to)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid to address %s: %w", to, err)
+	}
+	return fromAddr, toAddr, nil
+}
+
+// nonNil returns data, or an empty slice when it is nil, so the bytes
+// argument always packs.
+func nonNil(data []byte) []byte {
+	if data == nil {
+		return []byte{}
+	}
+	return data
+}
@@ -0,0 +1,251 @@
+package trc1155
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/fbsobreira/gotron-sdk/pkg/standards/trc20"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	tokenAddr = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	ownerAddr = "TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b"
+	otherAddr = "TLyqzVGLV1srkB7dToTAEqgDSfPtXRJZYH"
+)
+
+// mockClient answers constant calls by exact call data; unknown calls
+// revert. State-changing calls capture their data.
+type mockClient struct {
+	returns  map[string][]byte
+	calls    int
+	lastData []byte
+	err      error
+}
+
+func newMockClient() *mockClient {
+	return &mockClient{returns: make(map[string][]byte)}
+}
+
+// on registers the return data of a call, packed against the token ABI.
+func (m *mockClient) on(t *testing.T, method string, ret []byte, args ...interface{}) {
+	parsed, err := abi.ParseABI(ABI)
+	require.NoError(t, err)
+	meth, err := abi.FindMethod(parsed, method)
+	require.NoError(t, err)
+	packed, err := abi.PackArgs(meth.Inputs, args...)
+	require.NoError(t, err)
+	m.returns[string(append(meth.ID, packed...))] = ret
+}
+
+func (m *mockClient) TriggerConstantContractWithDataCtx(_ context.Context, _, _ string, data []byte, _ ...client.ConstantCallOption) (*api.TransactionExtention, error) {
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	ret, ok := m.returns[string(data)]
+	if !ok {
+		return &api.TransactionExtention{
+			Result:      &api.Return{Result: false, Message: []byte("REVERT opcode executed")},
+			Transaction: &core.Transaction{Ret: []*core.Transaction_Result{{ContractRet: core.Transaction_Result_REVERT}}},
+		}, nil
+	}
+	return &api.TransactionExtention{Result: &api.Return{Result: true}, ConstantResult: [][]byte{ret}}, nil
+}
+
+func (m *mockClient) TriggerConstantContractCtx(context.Context, string, string, string, string, ...client.ConstantCallOption) (*api.TransactionExtention, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClient) TriggerContractCtx(context.Context, string, string, string, string, int64, int64, string, int64) (*api.TransactionExtention, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClient) TriggerContractWithDataCtx(_ context.Context, _, _ string, data []byte, _, _ int64, _ string, _ int64) (*api.TransactionExtention, error) {
+	m.lastData = data
+	return &api.TransactionExtention{
+		Transaction: &core.Transaction{RawData: &core.TransactionRaw{Contract: []*core.Transaction_Contract{{}}}},
+		Result:      &api.Return{Result: true},
+	}, nil
+}
+
+func (m *mockClient) EstimateEnergyCtx(context.Context, string, string, string, string, int64, string, int64) (*api.EstimateEnergyMessage, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClient) EstimateEnergyWithDataCtx(context.Context, string, string, []byte, int64, string, int64) (*api.EstimateEnergyMessage, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClient) BroadcastCtx(context.Context, *core.Transaction) (*api.Return, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClient) GetTransactionInfoByIDCtx(context.Context, string) (*core.TransactionInfo, error) {
+	return nil, errors.New("not implemented")
+}
+
+// encode ABI-encodes a single value of type ty.
+func encode(t *testing.T, ty string, v interface{}) []byte {
+	parsed, err := abi.ParseABI(`[{"type":"function","name":"f","inputs":[{"name":"v","type":"` + ty + `"}],"outputs":[]}]`)
+	require.NoError(t, err)
+	out, err := abi.PackArgs(parsed.Methods["f"].Inputs, v)
+	require.NoError(t, err)
+	return out
+}
+
+func mustAddress(t *testing.T, addr string) address.Address {
+	a, err := address.Base58ToAddress(addr)
+	require.NoError(t, err)
+	return a
+}
+
+func TestReads(t *testing.T) {
+	m := newMockClient()
+	owner := mustAddress(t, ownerAddr)
+	other := mustAddress(t, otherAddr)
+
+	m.on(t, "name", encode(t, "string", "Items"))
+	m.on(t, "balanceOf", encode(t, "uint256", big.NewInt(5)), owner, big.NewInt(1))
+	m.on(t, "balanceOfBatch", encode(t, "uint256[]", []*big.Int{big.NewInt(5), big.NewInt(0)}),
+		[]address.Address{owner, other}, []*big.Int{big.NewInt(1), big.NewInt(2)})
+	m.on(t, "isApprovedForAll", encode(t, "bool", true), owner, other)
+	m.on(t, "supportsInterface", encode(t, "bool", true), InterfaceTRC1155)
+	token := New(m, tokenAddr)
+	ctx := context.Background()
+
+	name, err := token.Name(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Items", name)
+
+	balance, err := token.BalanceOf(ctx, ownerAddr, big.NewInt(1))
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(5), balance)
+
+	balances, err := token.BalanceOfBatch(ctx, []string{ownerAddr, otherAddr}, []*big.Int{big.NewInt(1), big.NewInt(2)})
+	require.NoError(t, err)
+	require.Len(t, balances, 2)
+	assert.Equal(t, "5", balances[0].String())
+	assert.Equal(t, "0", balances[1].String())
+
+	_, err = token.BalanceOfBatch(ctx, []string{ownerAddr}, nil)
+	assert.ErrorContains(t, err, "length mismatch")
+
+	approved, err := token.IsApprovedForAll(ctx, ownerAddr, otherAddr)
+	require.NoError(t, err)
+	assert.True(t, approved)
+
+	supported, err := token.SupportsInterface(ctx, InterfaceTRC1155)
+	require.NoError(t, err)
+	assert.True(t, supported)
+
+	// Unregistered calls revert, which means unsupported.
+	supported, err = token.SupportsInterface(ctx, InterfaceMetadataURI)
+	require.NoError(t, err)
+	assert.False(t, supported)
+
+	_, err = token.BalanceOf(ctx, "bad", big.NewInt(1))
+	assert.ErrorContains(t, err, "invalid account address")
+}
+
+func TestExpandURI(t *testing.T) {
+	assert.Equal(t,
+		"https://token-cdn-domain/000000000000000000000000000000000000000000000000000000000004cce0.json",
+		ExpandURI("https://token-cdn-domain/{id}.json", big.NewInt(314592)))
+	assert.Equal(t, "ipfs://fixed", ExpandURI("ipfs://fixed", big.NewInt(1)))
+}
+
+func TestURICache(t *testing.T) {
+	m := newMockClient()
+	m.on(t, "uri", encode(t, "string", "ipfs://items/{id}.json"), big.NewInt(1))
+	m.on(t, "uri", encode(t, "string", "ipfs://custom/2.json"), big.NewInt(2))
+	cache := trc20.NewMetadataCache(10)
+	token := New(m, tokenAddr, WithCache(cache))
+	ctx := context.Background()
+
+	uri, err := token.TokenURI(ctx, big.NewInt(1))
+	require.NoError(t, err)
+	assert.Equal(t, "ipfs://items/0000000000000000000000000000000000000000000000000000000000000001.json", uri)
+	assert.Equal(t, 1, m.calls)
+
+	// A template returned for one id is not reused for others, which may
+	// have their own URI (ERC1155URIStorage).
+	uri, err = New(m, tokenAddr, WithCache(cache)).TokenURI(ctx, big.NewInt(2))
+	require.NoError(t, err)
+	assert.Equal(t, "ipfs://custom/2.json", uri)
+	assert.Equal(t, 2, m.calls)
+
+	// Both are served from the cache afterwards.
+	for _, id := range []int64{1, 2} {
+		_, err = token.TokenURI(ctx, big.NewInt(id))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, m.calls)
+
+	cache.Clear()
+	_, err = token.TokenURI(ctx, big.NewInt(2))
+	require.NoError(t, err)
+	assert.Equal(t, 3, m.calls)
+}
+
+func TestMetadataCache(t *testing.T) {
+	m := newMockClient()
+	m.on(t, "name", encode(t, "string", "Items"))
+	m.on(t, "symbol", encode(t, "string", "ITM"))
+	cache := trc20.NewMetadataCache(10)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		token := New(m, tokenAddr, WithCache(cache))
+		name, err := token.Name(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Items", name)
+		symbol, err := token.Symbol(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "ITM", symbol)
+	}
+	assert.Equal(t, 2, m.calls)
+}
+
+func TestWrites(t *testing.T) {
+	m := newMockClient()
+	token := New(m, tokenAddr)
+	ctx := context.Background()
+	parsed, err := abi.ParseABI(ABI)
+	require.NoError(t, err)
+
+	_, err = token.SafeTransferFrom(ownerAddr, ownerAddr, otherAddr, big.NewInt(1), big.NewInt(5), nil).Build(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, parsed.Methods["safeTransferFrom"].ID, m.lastData[:4])
+	// Five head words plus the empty bytes length word.
+	assert.Len(t, m.lastData, 4+6*32)
+
+	ids := []*big.Int{big.NewInt(1), big.NewInt(2)}
+	amounts := []*big.Int{big.NewInt(5), big.NewInt(6)}
+	_, err = token.SafeBatchTransferFrom(ownerAddr, ownerAddr, otherAddr, ids, amounts, []byte("hi")).Build(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, parsed.Methods["safeBatchTransferFrom"].ID, m.lastData[:4])
+	args, err := parsed.Methods["safeBatchTransferFrom"].Inputs.Unpack(m.lastData[4:])
+	require.NoError(t, err)
+	assert.Equal(t, ids, args[2])
+	assert.Equal(t, amounts, args[3])
+	assert.Equal(t, []byte("hi"), args[4])
+
+	_, err = token.SetApprovalForAll(ownerAddr, otherAddr, true).Build(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, parsed.Methods["setApprovalForAll"].ID, m.lastData[:4])
+	assert.Equal(t, byte(1), m.lastData[len(m.lastData)-1])
+
+	call := token.SafeBatchTransferFrom(ownerAddr, ownerAddr, otherAddr, ids, amounts[:1], nil)
+	assert.ErrorContains(t, call.Err(), "length mismatch")
+	call = token.SafeTransferFrom(ownerAddr, ownerAddr, "bad", big.NewInt(1), big.NewInt(1), nil)
+	assert.ErrorContains(t, call.Err(), "invalid to address")
+}
@@ -10,14 +10,19 @@ const (
 	metaName     uint8 = 1 << iota // 1
 	metaSymbol                     // 2
 	metaDecimals                   // 4
+	metaURI                        // 8
 )
 
+// metaDisplay is the set of fields Lookup requires.
+const metaDisplay = metaName | metaSymbol | metaDecimals
+
 // tokenMeta holds the immutable metadata for a single TRC20 contract.
 type tokenMeta struct {
 	name      string
 	symbol    string
 	decimals  uint8
-	populated uint8 // bitmask of metaName | metaSymbol | metaDecimals
+	uri       string
+	populated uint8 // bitmask of metaName | metaSymbol | metaDecimals | metaURI
 }
 
 // cacheEntry is stored in the LRU list elements.
@@ -28,7 +33,9 @@ type cacheEntry struct {
 
 // MetadataCache is a thread-safe LRU cache for immutable TRC20 token metadata
 // (name, symbol, decimals). It is safe for concurrent use by multiple
-// goroutines and multiple Token instances.
+// goroutines and multiple Token instances. The trc10 and trc1155 packages
+// store their metadata in the same cache, keyed by token ID and contract
+// address, so one cache can serve every token type.
 //
 // Create with NewMetadataCache and pass to Token via WithCache.
 type MetadataCache struct {
@@ -183,3 +190,90 @@ func (c *MetadataCache) putDecimals(addr string, decimals uint8) {
 	entry.meta.decimals = decimals
 	entry.meta.populated |= metaDecimals
 }
+
+// --- Exported access for other token standards ---
+
+// Metadata is the display metadata of a token as held by MetadataCache.
+type Metadata struct {
+	Name     string
+	Symbol   string
+	Decimals uint8
+}
+
+// Lookup returns the metadata cached under key, which is a TRC20 contract
+// address or a TRC10 token ID. ok is false unless name, symbol and decimals
+// are all populated.
+func (c *MetadataCache) Lookup(key string) (Metadata, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		return Metadata{}, false
+	}
+	c.eviction.MoveToFront(el)
+	meta := el.Value.(*cacheEntry).meta
+	if meta.populated&metaDisplay != metaDisplay {
+		return Metadata{}, false
+	}
+	return Metadata{Name: meta.name, Symbol: meta.symbol, Decimals: meta.decimals}, true
+}
+
+// Store caches m under key. It lets other token standards, such as TRC10,
+// share one cache with TRC20 tokens.
+func (c *MetadataCache) Store(key string, m Metadata) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := c.getOrCreate(key)
+	entry.meta.name = m.Name
+	entry.meta.symbol = m.Symbol
+	entry.meta.decimals = m.Decimals
+	entry.meta.populated |= metaDisplay
+}
+
+// LookupName returns the name cached under key, whether stored by a TRC20
+// Token or by StoreName.
+func (c *MetadataCache) LookupName(key string) (string, bool) {
+	return c.getName(key)
+}
+
+// StoreName caches name under key, for standards such as TRC-1155 whose
+// metadata has no decimals.
+func (c *MetadataCache) StoreName(key, name string) {
+	c.putName(key, name)
+}
+
+// LookupSymbol returns the symbol cached under key; see LookupName.
+func (c *MetadataCache) LookupSymbol(key string) (string, bool) {
+	return c.getSymbol(key)
+}
+
+// StoreSymbol caches symbol under key; see StoreName.
+func (c *MetadataCache) StoreSymbol(key, symbol string) {
+	c.putSymbol(key, symbol)
+}
+
+// LookupURI returns the metadata URI cached under key by StoreURI.
+func (c *MetadataCache) LookupURI(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		return "", false
+	}
+	c.eviction.MoveToFront(el)
+	entry := el.Value.(*cacheEntry)
+	if entry.meta.populated&metaURI == 0 {
+		return "", false
+	}
+	return entry.meta.uri, true
+}
+
+// StoreURI caches a metadata URI under key. Token URIs differ per token, so
+// callers key them by contract address and token ID.
+func (c *MetadataCache) StoreURI(key, uri string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry := c.getOrCreate(key)
+	entry.meta.uri = uri
+	entry.meta.populated |= metaURI
+}
@@ -46,6 +46,66 @@ func TestCache_PutAndGet(t *testing.T) {
 	assert.Equal(t, uint8(6), decimals)
 }
 
+func TestCache_LookupAndStore(t *testing.T) {
+	c := NewMetadataCache(10)
+
+	c.putName("TUSDT", "Tether USD")
+	_, ok := c.Lookup("TUSDT")
+	assert.False(t, ok, "partial entries are not returned")
+
+	c.Store("1002000", Metadata{Name: "BitTorrent", Symbol: "BTT", Decimals: 6})
+	meta, ok := c.Lookup("1002000")
+	assert.True(t, ok)
+	assert.Equal(t, Metadata{Name: "BitTorrent", Symbol: "BTT", Decimals: 6}, meta)
+
+	// Stored entries are visible to Token getters.
+	symbol, ok := c.getSymbol("1002000")
+	assert.True(t, ok)
+	assert.Equal(t, "BTT", symbol)
+}
+
+func TestCache_StringFields(t *testing.T) {
+	c := NewMetadataCache(10)
+
+	c.StoreName("TItems", "Items")
+	c.StoreSymbol("TItems", "ITM")
+	name, ok := c.LookupName("TItems")
+	assert.True(t, ok)
+	assert.Equal(t, "Items", name)
+	symbol, ok := c.LookupSymbol("TItems")
+	assert.True(t, ok)
+	assert.Equal(t, "ITM", symbol)
+	_, ok = c.Lookup("TItems")
+	assert.False(t, ok, "no decimals were stored")
+
+	_, ok = c.LookupURI("TItems/1")
+	assert.False(t, ok)
+	c.StoreURI("TItems/1", "ipfs://items/{id}.json")
+	uri, ok := c.LookupURI("TItems/1")
+	assert.True(t, ok)
+	assert.Equal(t, "ipfs://items/{id}.json", uri)
+
+	// A URI next to full display metadata does not hide it from Lookup.
+	c.Store("TUSDT", Metadata{Name: "Tether USD", Symbol: "USDT", Decimals: 6})
+	c.StoreURI("TUSDT", "https://tether.to")
+	_, ok = c.Lookup("TUSDT")
+	assert.True(t, ok)
+}
+
+func TestCache_StoreKeepsURI(t *testing.T) {
+	c := NewMetadataCache(10)
+
+	c.StoreURI("TUSDT", "https://tether.to")
+	c.Store("TUSDT", Metadata{Name: "Tether USD", Symbol: "USDT", Decimals: 6})
+
+	uri, ok := c.LookupURI("TUSDT")
+	assert.True(t, ok)
+	assert.Equal(t, "https://tether.to", uri)
+	meta, ok := c.Lookup("TUSDT")
+	assert.True(t, ok)
+	assert.Equal(t, Metadata{Name: "Tether USD", Symbol: "USDT", Decimals: 6}, meta)
+}
+
 func TestCache_MissReturnsNotOk(t *testing.T) {
 	c := NewMetadataCache(10)
 
@@ -0,0 +1,123 @@
+package trc20
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/contract"
+	"github.com/fbsobreira/gotron-sdk/pkg/contract/multicall"
+	"github.com/fbsobreira/gotron-sdk/pkg/standards/trc20enc"
+)
+
+// WithMulticall makes BalanceOf fetch the balance, decimals and symbol in a
+// single aggregate3 call through m instead of one constant call each.
+func WithMulticall(m *multicall.Multicall) TokenOption {
+	return func(t *Token) {
+		t.multicall = m
+	}
+}
+
+// balanceReads records where each value for one token sits in the batch;
+// -1 means the value came from the metadata cache.
+type balanceReads struct {
+	balance, decimals, symbol int
+}
+
+// BalancesOf returns the balance of holder in each of tokens, read through
+// m in as few aggregate3 calls as the batch limits allow. Decimals and
+// symbols already in a token's MetadataCache are not fetched again, and
+// fetched values are stored in it.
+func BalancesOf(ctx context.Context, m *multicall.Multicall, holder string, tokens ...*Token) ([]*Balance, error) {
+	holderAddr, err := address.Base58ToAddress(holder)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %s: %w", holder, err)
+	}
+
+	var calls []*contract.ContractCall
+	add := func(t *Token, data []byte) int {
+		calls = append(calls, contract.New(t.client, t.contractAddress).WithData(data))
+		return len(calls) - 1
+	}
+	plans := make([]balanceReads, len(tokens))
+	for i, t := range tokens {
+		plans[i] = balanceReads{balance: add(t, encodeWithAddress(trc20enc.SelectorBalanceOf, holderAddr)), decimals: -1, symbol: -1}
+		if _, ok := t.cachedDecimals(); !ok {
+			plans[i].decimals = add(t, trc20enc.SelectorBytes(trc20enc.SelectorDecimals))
+		}
+		if _, ok := t.cachedSymbol(); !ok {
+			plans[i].symbol = add(t, trc20enc.SelectorBytes(trc20enc.SelectorSymbol))
+		}
+	}
+
+	results, err := m.Aggregate(ctx, calls...)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make([]*Balance, len(tokens))
+	for i, t := range tokens {
+		plan := plans[i]
+		raw, err := uint256Result(results[plan.balance])
+		if err != nil {
+			return nil, fmt.Errorf("%s: balanceOf: %w", t.contractAddress, err)
+		}
+
+		decimals, _ := t.cachedDecimals()
+		if plan.decimals >= 0 {
+			n, err := uint256Result(results[plan.decimals])
+			if err != nil {
+				return nil, fmt.Errorf("%s: decimals: %w", t.contractAddress, err)
+			}
+			if decimals, err = toDecimals(n); err != nil {
+				return nil, fmt.Errorf("%s: %w", t.contractAddress, err)
+			}
+			if t.cache != nil {
+				t.cache.putDecimals(t.contractAddress, decimals)
+			}
+		}
+
+		symbol, _ := t.cachedSymbol()
+		if plan.symbol >= 0 {
+			r := results[plan.symbol]
+			if err := r.Err(); err != nil {
+				return nil, fmt.Errorf("%s: symbol: %w", t.contractAddress, err)
+			}
+			if symbol, err = decodeString([][]byte{r.ReturnData}); err != nil {
+				return nil, fmt.Errorf("%s: symbol: %w", t.contractAddress, err)
+			}
+			if t.cache != nil {
+				t.cache.putSymbol(t.contractAddress, symbol)
+			}
+		}
+
+		balances[i] = &Balance{
+			Raw:     raw,
+			Display: FormatBalance(raw, decimals),
+			Symbol:  symbol,
+		}
+	}
+	return balances, nil
+}
+
+func uint256Result(r multicall.Result) (*big.Int, error) {
+	if err := r.Err(); err != nil {
+		return nil, err
+	}
+	return decodeUint256([][]byte{r.ReturnData})
+}
+
+func (t *Token) cachedDecimals() (uint8, bool) {
+	if t.cache == nil {
+		return 0, false
+	}
+	return t.cache.getDecimals(t.contractAddress)
+}
+
+func (t *Token) cachedSymbol() (string, bool) {
+	if t.cache == nil {
+		return "", false
+	}
+	return t.cache.getSymbol(t.contractAddress)
+}
@@ -0,0 +1,89 @@
+package trc20
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/contract/multicall"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// aggregate3Result encodes an aggregate3 return value of successful calls.
+func aggregate3Result(t *testing.T, returns ...[]byte) []byte {
+	parsed, err := abi.ParseABI(`[{"type":"function","name":"aggregate3","inputs":[],
+	 "outputs":[{"name":"returnData","type":"tuple[]","components":[
+	  {"name":"success","type":"bool"},{"name":"returnData","type":"bytes"}]}]}]`)
+	require.NoError(t, err)
+	type result struct {
+		Success    bool
+		ReturnData []byte
+	}
+	results := make([]result, len(returns))
+	for i, r := range returns {
+		results[i] = result{Success: r != nil, ReturnData: r}
+	}
+	data, err := abi.PackArgs(parsed.Methods["aggregate3"].Outputs, results)
+	require.NoError(t, err)
+	return data
+}
+
+func TestBalancesOf(t *testing.T) {
+	cache := NewMetadataCache(10)
+	cache.putDecimals("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", 6)
+	cache.putSymbol("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", "USDT")
+
+	mc := &mockClient{results: [][][]byte{{aggregate3Result(t,
+		abiEncodeUint256(big.NewInt(2_500_000)), // USDT balanceOf
+		abiEncodeUint256(big.NewInt(1e18)),      // second token balanceOf
+		abiEncodeUint256(big.NewInt(18)),        // decimals
+		abiEncodeString("USDD"),                 // symbol
+	)}}}
+	m := multicall.New(mc, multicall.MainnetAddress)
+	usdt := New(mc, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", WithCache(cache))
+	usdd := New(mc, "TPYmHEhy5n8TCEfYGqW2rPxsghSfzghPDn", WithCache(cache))
+
+	balances, err := BalancesOf(context.Background(), m, "TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1", usdt, usdd)
+	require.NoError(t, err)
+	require.Len(t, balances, 2)
+	assert.Equal(t, "2.5", balances[0].Display)
+	assert.Equal(t, "USDT", balances[0].Symbol)
+	assert.Equal(t, "1", balances[1].Display)
+	assert.Equal(t, "USDD", balances[1].Symbol)
+
+	assert.Equal(t, []byte{0x82, 0xad, 0x56, 0xcb}, mc.lastData[:4], "one aggregate3 call")
+	d, ok := cache.getDecimals("TPYmHEhy5n8TCEfYGqW2rPxsghSfzghPDn")
+	assert.True(t, ok)
+	assert.Equal(t, uint8(18), d)
+}
+
+func TestBalanceOfWithMulticall(t *testing.T) {
+	mc := &mockClient{results: [][][]byte{{aggregate3Result(t,
+		abiEncodeUint256(big.NewInt(1_500_000)),
+		abiEncodeUint256(big.NewInt(6)),
+		abiEncodeString("USDT"),
+	)}}}
+	token := New(mc, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", WithMulticall(multicall.New(mc, multicall.MainnetAddress)))
+
+	result, err := token.BalanceOf(context.Background(), "TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1")
+	require.NoError(t, err)
+	assert.Equal(t, "1.5", result.Display)
+	assert.Equal(t, "USDT", result.Symbol)
+	assert.Empty(t, mc.results, "balance, decimals and symbol come from one call")
+}
+
+func TestBalancesOfFailedCall(t *testing.T) {
+	mc := &mockClient{results: [][][]byte{{aggregate3Result(t,
+		nil,
+		abiEncodeUint256(big.NewInt(6)),
+		abiEncodeString("USDT"),
+	)}}}
+	_, err := BalancesOf(context.Background(), multicall.New(mc, multicall.MainnetAddress),
+		"TSvT6Bg3siokv3dbdtt9o4oM1CTXmymGn1", New(mc, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"))
+	assert.ErrorContains(t, err, "balanceOf: execution reverted")
+
+	_, err = BalancesOf(context.Background(), multicall.New(mc, multicall.MainnetAddress), "bad")
+	assert.ErrorContains(t, err, "invalid address")
+}
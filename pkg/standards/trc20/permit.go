@@ -0,0 +1,201 @@
+package trc20
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/contract"
+	"github.com/fbsobreira/gotron-sdk/pkg/signer"
+	"github.com/fbsobreira/gotron-sdk/pkg/typeddata"
+)
+
+// permitABI covers the EIP-2612 permit extension and the EIP-5267 domain
+// getter.
+const permitABI = `[
+{"type":"function","name":"nonces","stateMutability":"view","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+{"type":"function","name":"DOMAIN_SEPARATOR","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"bytes32"}]},
+{"type":"function","name":"eip712Domain","stateMutability":"view","inputs":[],"outputs":[{"name":"fields","type":"bytes1"},{"name":"name","type":"string"},{"name":"version","type":"string"},{"name":"chainId","type":"uint256"},{"name":"verifyingContract","type":"address"},{"name":"salt","type":"bytes32"},{"name":"extensions","type":"uint256[]"}]},
+{"type":"function","name":"permit","stateMutability":"nonpayable","inputs":[{"name":"owner","type":"address"},{"name":"spender","type":"address"},{"name":"value","type":"uint256"},{"name":"deadline","type":"uint256"},{"name":"v","type":"uint8"},{"name":"r","type":"bytes32"},{"name":"s","type":"bytes32"}],"outputs":[]}
+]`
+
+// permitTypes is the TIP-712 type of an EIP-2612 permit.
+var permitTypes = typeddata.Types{
+	"Permit": {
+		{Name: "owner", Type: "address"},
+		{Name: "spender", Type: "address"},
+		{Name: "value", Type: "uint256"},
+		{Name: "nonce", Type: "uint256"},
+		{Name: "deadline", Type: "uint256"},
+	},
+}
+
+// eip712Domain is the EIP-5267 eip712Domain() result.
+type eip712Domain struct {
+	Fields            [1]byte
+	Name              string
+	Version           string
+	ChainID           *big.Int `abi:"chainId"`
+	VerifyingContract address.Address
+	Salt              [32]byte
+	Extensions        []*big.Int
+}
+
+// Permit is a signed EIP-2612 permit. Anyone holding it can submit the
+// approval with Call, so the owner needs no TRX for energy.
+type Permit struct {
+	Owner    string
+	Spender  string
+	Value    *big.Int
+	Nonce    *big.Int
+	Deadline *big.Int // unix seconds
+	// Signature is the 65-byte [R || S || V] signature, V being 27 or 28.
+	Signature []byte
+
+	token *Token
+}
+
+// Permit signs an EIP-2612 permit allowing spender to spend value of owner's
+// tokens until deadline (unix seconds). It reads nonces(owner) and the
+// domain separator from the token, preferring DOMAIN_SEPARATOR() and falling
+// back to the EIP-5267 eip712Domain() getter, then signs the TIP-712 digest
+// with s, which must be owner's signer.HashSigner.
+func (t *Token) Permit(ctx context.Context, owner, spender string, value, deadline *big.Int, s signer.Signer) (*Permit, error) {
+	ownerAddr, err := address.Base58ToAddress(owner)
+	if err != nil {
+		return nil, fmt.Errorf("invalid owner address %s: %w", owner, err)
+	}
+	if _, err := address.Base58ToAddress(spender); err != nil {
+		return nil, fmt.Errorf("invalid spender address %s: %w", spender, err)
+	}
+	if !bytes.Equal(s.Address(), ownerAddr) {
+		return nil, fmt.Errorf("signer %s is not the owner %s", s.Address(), owner)
+	}
+	hs, ok := s.(signer.HashSigner)
+	if !ok {
+		return nil, typeddata.ErrHashSigningUnsupported
+	}
+
+	var nonce *big.Int
+	if err := t.permitCall("nonces", ownerAddr).CallInto(ctx, &nonce); err != nil {
+		return nil, fmt.Errorf("nonces: %w", err)
+	}
+	separator, err := t.domainSeparator(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	td := &typeddata.TypedData{Types: permitTypes}
+	structHash, err := td.HashStruct("Permit", map[string]interface{}{
+		"owner":    owner,
+		"spender":  spender,
+		"value":    value,
+		"nonce":    nonce,
+		"deadline": deadline,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sig, err := hs.SignHash(crypto.Keccak256([]byte{0x19, 0x01}, separator, structHash))
+	if err != nil {
+		return nil, err
+	}
+	if len(sig) != 65 {
+		return nil, fmt.Errorf("invalid signature length: %d/65", len(sig))
+	}
+	if sig[64] < 27 {
+		sig[64] += 27
+	}
+
+	return &Permit{
+		Owner:     owner,
+		Spender:   spender,
+		Value:     value,
+		Nonce:     nonce,
+		Deadline:  deadline,
+		Signature: sig,
+		token:     t,
+	}, nil
+}
+
+// Call returns a ContractCall submitting the permit, signed and paid for by
+// relayer.
+func (p *Permit) Call(relayer string, opts ...contract.Option) *contract.ContractCall {
+	ownerAddr, err := address.Base58ToAddress(p.Owner)
+	if err != nil {
+		return contract.New(p.token.client, p.token.contractAddress).
+			SetError(fmt.Errorf("invalid owner address %s: %w", p.Owner, err))
+	}
+	spenderAddr, err := address.Base58ToAddress(p.Spender)
+	if err != nil {
+		return contract.New(p.token.client, p.token.contractAddress).
+			SetError(fmt.Errorf("invalid spender address %s: %w", p.Spender, err))
+	}
+	if len(p.Signature) != 65 {
+		return contract.New(p.token.client, p.token.contractAddress).
+			SetError(fmt.Errorf("invalid signature length: %d/65", len(p.Signature)))
+	}
+	var r, s [32]byte
+	copy(r[:], p.Signature[:32])
+	copy(s[:], p.Signature[32:64])
+	return p.token.permitCall("permit", ownerAddr, spenderAddr, p.Value, p.Deadline, p.Signature[64], r, s).
+		From(relayer).
+		Apply(opts...)
+}
+
+// permitCall starts a ContractCall against the permit ABI.
+func (t *Token) permitCall(method string, args ...interface{}) *contract.ContractCall {
+	return contract.New(t.client, t.contractAddress).
+		WithABI(permitABI).
+		Method(method).
+		Args(args...)
+}
+
+// domainSeparator reads DOMAIN_SEPARATOR(), or computes it from
+// eip712Domain() when the token does not expose it.
+func (t *Token) domainSeparator(ctx context.Context) ([]byte, error) {
+	var separator [32]byte
+	err := t.permitCall("DOMAIN_SEPARATOR").CallInto(ctx, &separator)
+	if err == nil {
+		return separator[:], nil
+	}
+	var rerr *contract.RevertError
+	if !errors.As(err, &rerr) && !errors.Is(err, contract.ErrEmptyResult) {
+		return nil, fmt.Errorf("DOMAIN_SEPARATOR: %w", err)
+	}
+
+	var domain eip712Domain
+	if err := t.permitCall("eip712Domain").CallInto(ctx, &domain); err != nil {
+		return nil, fmt.Errorf("token exposes neither DOMAIN_SEPARATOR nor eip712Domain: %w", err)
+	}
+	return domain.separator()
+}
+
+// separator hashes the domain fields flagged in Fields, in EIP-712 order.
+func (d *eip712Domain) separator() ([]byte, error) {
+	candidates := []struct {
+		field typeddata.Type
+		value interface{}
+	}{
+		{typeddata.Type{Name: "name", Type: "string"}, d.Name},
+		{typeddata.Type{Name: "version", Type: "string"}, d.Version},
+		{typeddata.Type{Name: "chainId", Type: "uint256"}, d.ChainID},
+		{typeddata.Type{Name: "verifyingContract", Type: "address"}, d.VerifyingContract.String()},
+		{typeddata.Type{Name: "salt", Type: "bytes32"}, d.Salt[:]},
+	}
+	var fields []typeddata.Type
+	values := make(map[string]interface{})
+	for i, c := range candidates {
+		if d.Fields[0]&(1<<i) == 0 {
+			continue
+		}
+		fields = append(fields, c.field)
+		values[c.field.Name] = c.value
+	}
+	td := &typeddata.TypedData{Types: typeddata.Types{typeddata.DomainType: fields}, Domain: values}
+	return td.DomainSeparator()
+}
@@ -0,0 +1,133 @@
+package trc20
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/signer"
+	"github.com/fbsobreira/gotron-sdk/pkg/typeddata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	permitToken   = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	permitSpender = "TLyqzVGLV1srkB7dToTAEqgDSfPtXRJZYH"
+	permitRelayer = "TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b"
+)
+
+// permitTypedData is the full TIP-712 document a wallet would sign.
+func permitTypedData(owner string, nonce int64) *typeddata.TypedData {
+	return &typeddata.TypedData{
+		Types: typeddata.Types{
+			typeddata.DomainType: {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"Permit": permitTypes["Permit"],
+		},
+		PrimaryType: "Permit",
+		Domain: map[string]interface{}{
+			"name":              "Permit Token",
+			"version":           "1",
+			"chainId":           big.NewInt(728126428),
+			"verifyingContract": permitToken,
+		},
+		Message: map[string]interface{}{
+			"owner":    owner,
+			"spender":  permitSpender,
+			"value":    big.NewInt(1_000_000),
+			"nonce":    big.NewInt(nonce),
+			"deadline": big.NewInt(1_900_000_000),
+		},
+	}
+}
+
+func permitOutputs(t *testing.T, method string, values ...interface{}) []byte {
+	parsed, err := abi.ParseABI(permitABI)
+	require.NoError(t, err)
+	packed, err := abi.PackArgs(parsed.Methods[method].Outputs, values...)
+	require.NoError(t, err)
+	return packed
+}
+
+func newPermitSigner(t *testing.T) signer.Signer {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	s, err := signer.NewPrivateKeySigner(key)
+	require.NoError(t, err)
+	return s
+}
+
+func TestPermit_DomainSeparator(t *testing.T) {
+	s := newPermitSigner(t)
+	owner := s.Address().String()
+	td := permitTypedData(owner, 3)
+	separator, err := td.DomainSeparator()
+	require.NoError(t, err)
+
+	mc := &mockClient{results: [][][]byte{
+		{abiEncodeUint256(big.NewInt(3))},
+		{separator},
+	}}
+	token := New(mc, permitToken)
+	permit, err := token.Permit(context.Background(), owner, permitSpender, big.NewInt(1_000_000), big.NewInt(1_900_000_000), s)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(3), permit.Nonce)
+
+	ok, err := typeddata.Verify(td, permit.Signature, owner)
+	require.NoError(t, err)
+	assert.True(t, ok, "permit must match the wallet-signed typed data")
+
+	_, err = permit.Call(permitRelayer).Build(context.Background())
+	require.NoError(t, err)
+	parsed, err := abi.ParseABI(permitABI)
+	require.NoError(t, err)
+	method := parsed.Methods["permit"]
+	assert.Equal(t, method.ID, mc.lastData[:4])
+	args, err := method.Inputs.Unpack(mc.lastData[4:])
+	require.NoError(t, err)
+	assert.Equal(t, permit.Signature[64], args[4])
+	r := args[5].([32]byte)
+	assert.Equal(t, permit.Signature[:32], r[:])
+}
+
+func TestPermit_EIP712DomainFallback(t *testing.T) {
+	s := newPermitSigner(t)
+	owner := s.Address().String()
+	contractAddr, err := address.Base58ToAddress(permitToken)
+	require.NoError(t, err)
+
+	mc := &mockClient{results: [][][]byte{
+		{abiEncodeUint256(big.NewInt(0))},
+		{}, // DOMAIN_SEPARATOR is not implemented
+		{permitOutputs(t, "eip712Domain", [1]byte{0x0f}, "Permit Token", "1", big.NewInt(728126428), contractAddr, [32]byte{}, []*big.Int{})},
+	}}
+	permit, err := New(mc, permitToken).Permit(context.Background(), owner, permitSpender, big.NewInt(1_000_000), big.NewInt(1_900_000_000), s)
+	require.NoError(t, err)
+
+	ok, err := typeddata.Verify(permitTypedData(owner, 0), permit.Signature, owner)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestPermit_Errors(t *testing.T) {
+	s := newPermitSigner(t)
+	token := New(&mockClient{}, permitToken)
+	ctx := context.Background()
+
+	_, err := token.Permit(ctx, permitRelayer, permitSpender, big.NewInt(1), big.NewInt(1), s)
+	assert.ErrorContains(t, err, "is not the owner")
+
+	_, err = token.Permit(ctx, s.Address().String(), "bad", big.NewInt(1), big.NewInt(1), s)
+	assert.ErrorContains(t, err, "invalid spender address")
+
+	call := (&Permit{Owner: permitRelayer, Spender: permitSpender, token: token}).Call(permitRelayer)
+	assert.ErrorContains(t, call.Err(), "invalid signature length")
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/fbsobreira/gotron-sdk/pkg/address"
 	"github.com/fbsobreira/gotron-sdk/pkg/contract"
+	"github.com/fbsobreira/gotron-sdk/pkg/contract/multicall"
 	"github.com/fbsobreira/gotron-sdk/pkg/standards/trc20enc"
 )
 
@@ -34,6 +35,7 @@ type Token struct {
 	client          contract.Client
 	contractAddress string
 	cache           *MetadataCache // nil means no caching (opt-in)
+	multicall       *multicall.Multicall
 }
 
 // TokenOption configures optional Token behavior.
@@ -158,10 +160,10 @@ func (t *Token) Decimals(ctx context.Context) (uint8, error) {
 	if err != nil {
 		return 0, err
 	}
-	if !n.IsUint64() || n.Uint64() > 255 {
-		return 0, fmt.Errorf("decimals value %s out of uint8 range", n.String())
+	d, err := toDecimals(n)
+	if err != nil {
+		return 0, err
 	}
-	d := uint8(n.Uint64())
 
 	if t.cache != nil {
 		t.cache.putDecimals(t.contractAddress, d)
@@ -181,8 +183,17 @@ func (t *Token) TotalSupply(ctx context.Context) (*big.Int, error) {
 }
 
 // BalanceOf returns the balance of the given address with both raw and
-// human-readable display format.
+// human-readable display format. With WithMulticall, the balance, decimals
+// and symbol are read in a single call.
 func (t *Token) BalanceOf(ctx context.Context, addr string) (*Balance, error) {
+	if t.multicall != nil {
+		balances, err := BalancesOf(ctx, t.multicall, addr, t)
+		if err != nil {
+			return nil, err
+		}
+		return balances[0], nil
+	}
+
 	addrBytes, err := address.Base58ToAddress(addr)
 	if err != nil {
 		return nil, fmt.Errorf("invalid address %s: %w", addr, err)
@@ -213,7 +224,7 @@ func (t *Token) BalanceOf(ctx context.Context, addr string) (*Balance, error) {
 
 	return &Balance{
 		Raw:     raw,
-		Display: formatBalance(raw, decimals),
+		Display: FormatBalance(raw, decimals),
 		Symbol:  symbol,
 	}, nil
 }
@@ -303,9 +314,17 @@ func (t *Token) TransferFrom(caller, from, to string, amount *big.Int, opts ...c
 		Apply(opts...)
 }
 
-// formatBalance converts a raw big.Int token amount to a human-readable string
+// toDecimals validates a decimals() result.
+func toDecimals(n *big.Int) (uint8, error) {
+	if !n.IsUint64() || n.Uint64() > 255 {
+		return 0, fmt.Errorf("decimals value %s out of uint8 range", n.String())
+	}
+	return uint8(n.Uint64()), nil
+}
+
+// FormatBalance converts a raw token amount to a human-readable string
 // with the given number of decimals.
-func formatBalance(raw *big.Int, decimals uint8) string {
+func FormatBalance(raw *big.Int, decimals uint8) string {
 	if raw == nil || raw.Sign() == 0 {
 		return "0"
 	}
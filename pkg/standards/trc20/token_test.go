@@ -214,7 +214,7 @@ func TestFormatBalance(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			assert.Equal(t, tt.want, formatBalance(tt.raw, tt.decimals))
+			assert.Equal(t, tt.want, FormatBalance(tt.raw, tt.decimals))
 		})
 	}
 }
@@ -950,21 +950,21 @@ func TestTokenAllowanceClientError(t *testing.T) {
 }
 
 func TestFormatBalanceNil(t *testing.T) {
-	assert.Equal(t, "0", formatBalance(nil, 6))
+	assert.Equal(t, "0", FormatBalance(nil, 6))
 }
 
 func TestFormatBalanceZeroDecimals(t *testing.T) {
-	assert.Equal(t, "1,000", formatBalance(big.NewInt(1000), 0))
+	assert.Equal(t, "1,000", FormatBalance(big.NewInt(1000), 0))
 }
 
 func TestFormatBalanceExactWhole(t *testing.T) {
 	// 2,000,000 with 6 decimals = 2.0 (no fraction) -> "2"
-	assert.Equal(t, "2", formatBalance(big.NewInt(2_000_000), 6))
+	assert.Equal(t, "2", FormatBalance(big.NewInt(2_000_000), 6))
 }
 
 func TestFormatBalanceLeadingZeroFraction(t *testing.T) {
 	// 1,000,001 with 6 decimals = 1.000001
-	assert.Equal(t, "1.000001", formatBalance(big.NewInt(1_000_001), 6))
+	assert.Equal(t, "1.000001", FormatBalance(big.NewInt(1_000_001), 6))
 }
 
 func TestTokenBalanceOfDecimalsError(t *testing.T) {
@@ -0,0 +1,131 @@
+package trc721
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"golang.org/x/crypto/sha3"
+)
+
+// ErrNotEvent is returned when a log is not the requested TRC-721 event.
+var ErrNotEvent = errors.New("log is not the requested TRC-721 event")
+
+// Event topics (Keccak-256 of the event signature).
+var (
+	TopicTransfer       = eventTopic("Transfer(address,address,uint256)")
+	TopicApproval       = eventTopic("Approval(address,address,uint256)")
+	TopicApprovalForAll = eventTopic("ApprovalForAll(address,address,bool)")
+)
+
+// TransferEvent is a decoded Transfer log. From is empty for mints and To
+// is empty for burns.
+type TransferEvent struct {
+	From    string
+	To      string
+	TokenID *big.Int
+}
+
+// ApprovalEvent is a decoded Approval log. Approved is empty when the
+// approval is cleared.
+type ApprovalEvent struct {
+	Owner    string
+	Approved string
+	TokenID  *big.Int
+}
+
+// ApprovalForAllEvent is a decoded ApprovalForAll log.
+type ApprovalForAllEvent struct {
+	Owner    string
+	Operator string
+	Approved bool
+}
+
+// DecodeTransfer decodes a Transfer log. TRC-20 Transfer logs share the
+// topic but carry the amount in data rather than a fourth topic, so they
+// are rejected with ErrNotEvent.
+func DecodeTransfer(log *core.TransactionInfo_Log) (*TransferEvent, error) {
+	topics, err := eventTopics(log, TopicTransfer, 4)
+	if err != nil {
+		return nil, err
+	}
+	return &TransferEvent{
+		From:    topicAddress(topics[1]),
+		To:      topicAddress(topics[2]),
+		TokenID: new(big.Int).SetBytes(topics[3]),
+	}, nil
+}
+
+// DecodeApproval decodes an Approval log.
+func DecodeApproval(log *core.TransactionInfo_Log) (*ApprovalEvent, error) {
+	topics, err := eventTopics(log, TopicApproval, 4)
+	if err != nil {
+		return nil, err
+	}
+	return &ApprovalEvent{
+		Owner:    topicAddress(topics[1]),
+		Approved: topicAddress(topics[2]),
+		TokenID:  new(big.Int).SetBytes(topics[3]),
+	}, nil
+}
+
+// DecodeApprovalForAll decodes an ApprovalForAll log.
+func DecodeApprovalForAll(log *core.TransactionInfo_Log) (*ApprovalForAllEvent, error) {
+	topics, err := eventTopics(log, TopicApprovalForAll, 3)
+	if err != nil {
+		return nil, err
+	}
+	data := log.GetData()
+	if len(data) != 32 {
+		return nil, fmt.Errorf("ApprovalForAll: data length %d, want 32", len(data))
+	}
+	return &ApprovalForAllEvent{
+		Owner:    topicAddress(topics[1]),
+		Operator: topicAddress(topics[2]),
+		Approved: data[31] != 0,
+	}, nil
+}
+
+// Transfers decodes every TRC-721 Transfer in logs, skipping other logs.
+func Transfers(logs []*core.TransactionInfo_Log) []*TransferEvent {
+	var out []*TransferEvent
+	for _, log := range logs {
+		if ev, err := DecodeTransfer(log); err == nil {
+			out = append(out, ev)
+		}
+	}
+	return out
+}
+
+// eventTopics checks the event topic and topic count of log.
+func eventTopics(log *core.TransactionInfo_Log, topic []byte, count int) ([][]byte, error) {
+	topics := log.GetTopics()
+	if len(topics) != count || !bytes.Equal(topics[0], topic) {
+		return nil, ErrNotEvent
+	}
+	for _, t := range topics[1:] {
+		if len(t) != 32 {
+			return nil, fmt.Errorf("topic length %d, want 32", len(t))
+		}
+	}
+	return topics, nil
+}
+
+// topicAddress decodes an indexed address, returning "" for the zero
+// address.
+func topicAddress(topic []byte) string {
+	addr := append(address.Address{address.TronBytePrefix}, topic[12:]...)
+	if isZeroAddress(addr) {
+		return ""
+	}
+	return addr.String()
+}
+
+func eventTopic(signature string) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte(signature))
+	return h.Sum(nil)
+}
@@ -0,0 +1,55 @@
+package trc721
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func addressTopic(t *testing.T, addr string) []byte {
+	if addr == "" {
+		return make([]byte, 32)
+	}
+	a, err := address.Base58ToAddress(addr)
+	require.NoError(t, err)
+	return append(make([]byte, 12), a[1:]...)
+}
+
+func TestDecodeEvents(t *testing.T) {
+	assert.Equal(t, "ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef", hex.EncodeToString(TopicTransfer))
+
+	tokenTopic := make([]byte, 32)
+	tokenTopic[31] = 7
+
+	mint := &core.TransactionInfo_Log{Topics: [][]byte{TopicTransfer, addressTopic(t, ""), addressTopic(t, ownerAddr), tokenTopic}}
+	ev, err := DecodeTransfer(mint)
+	require.NoError(t, err)
+	assert.Equal(t, &TransferEvent{To: ownerAddr, TokenID: big.NewInt(7)}, ev)
+
+	approval := &core.TransactionInfo_Log{Topics: [][]byte{TopicApproval, addressTopic(t, ownerAddr), addressTopic(t, otherAddr), tokenTopic}}
+	ap, err := DecodeApproval(approval)
+	require.NoError(t, err)
+	assert.Equal(t, &ApprovalEvent{Owner: ownerAddr, Approved: otherAddr, TokenID: big.NewInt(7)}, ap)
+
+	forAll := &core.TransactionInfo_Log{
+		Topics: [][]byte{TopicApprovalForAll, addressTopic(t, ownerAddr), addressTopic(t, otherAddr)},
+		Data:   tokenTopic,
+	}
+	all, err := DecodeApprovalForAll(forAll)
+	require.NoError(t, err)
+	assert.Equal(t, &ApprovalForAllEvent{Owner: ownerAddr, Operator: otherAddr, Approved: true}, all)
+
+	// A TRC-20 Transfer has the same topic but only three topics.
+	trc20 := &core.TransactionInfo_Log{Topics: [][]byte{TopicTransfer, addressTopic(t, ownerAddr), addressTopic(t, otherAddr)}, Data: tokenTopic}
+	_, err = DecodeTransfer(trc20)
+	assert.ErrorIs(t, err, ErrNotEvent)
+	_, err = DecodeApproval(mint)
+	assert.ErrorIs(t, err, ErrNotEvent)
+
+	assert.Len(t, Transfers([]*core.TransactionInfo_Log{mint, approval, trc20}), 1)
+}
@@ -0,0 +1,315 @@
+// Package trc721 provides a typed, high-level wrapper for TRC-721
+// non-fungible token interactions, built on top of the contract call builder.
+package trc721
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/contract"
+)
+
+// Method signatures of the overloaded safeTransferFrom.
+const (
+	methodSafeTransferFrom         = "safeTransferFrom(address,address,uint256)"
+	methodSafeTransferFromWithData = "safeTransferFrom(address,address,uint256,bytes)"
+)
+
+// Interfaces reports which TRC-721 interfaces a contract declares through
+// ERC-165.
+type Interfaces struct {
+	TRC721     bool
+	Metadata   bool
+	Enumerable bool
+}
+
+// Token is a typed TRC-721 token handle.
+type Token struct {
+	client          contract.Client
+	contractAddress string
+}
+
+// New creates a Token instance for the given TRC-721 contract.
+func New(client contract.Client, contractAddress string) *Token {
+	return &Token{
+		client:          client,
+		contractAddress: contractAddress,
+	}
+}
+
+// call starts a ContractCall against the token ABI.
+func (t *Token) call(method string, args ...interface{}) *contract.ContractCall {
+	return contract.New(t.client, t.contractAddress).
+		WithABI(ABI).
+		Method(method).
+		Args(args...)
+}
+
+// Name returns the collection name (metadata extension).
+func (t *Token) Name(ctx context.Context) (string, error) {
+	var name string
+	if err := t.call("name").CallInto(ctx, &name); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// Symbol returns the collection symbol (metadata extension).
+func (t *Token) Symbol(ctx context.Context) (string, error) {
+	var symbol string
+	if err := t.call("symbol").CallInto(ctx, &symbol); err != nil {
+		return "", err
+	}
+	return symbol, nil
+}
+
+// TokenURI returns the metadata URI of tokenID (metadata extension).
+func (t *Token) TokenURI(ctx context.Context, tokenID *big.Int) (string, error) {
+	var uri string
+	if err := t.call("tokenURI", tokenID).CallInto(ctx, &uri); err != nil {
+		return "", err
+	}
+	return uri, nil
+}
+
+// BalanceOf returns the number of tokens held by owner.
+func (t *Token) BalanceOf(ctx context.Context, owner string) (*big.Int, error) {
+	ownerAddr, err := address.Base58ToAddress(owner)
+	if err != nil {
+		return nil, fmt.Errorf("invalid owner address %s: %w", owner, err)
+	}
+	var balance *big.Int
+	if err := t.call("balanceOf", ownerAddr).CallInto(ctx, &balance); err != nil {
+		return nil, err
+	}
+	return balance, nil
+}
+
+// OwnerOf returns the owner of tokenID.
+func (t *Token) OwnerOf(ctx context.Context, tokenID *big.Int) (string, error) {
+	var owner address.Address
+	if err := t.call("ownerOf", tokenID).CallInto(ctx, &owner); err != nil {
+		return "", err
+	}
+	return owner.String(), nil
+}
+
+// GetApproved returns the address approved to transfer tokenID, or an empty
+// string when there is none.
+func (t *Token) GetApproved(ctx context.Context, tokenID *big.Int) (string, error) {
+	var approved address.Address
+	if err := t.call("getApproved", tokenID).CallInto(ctx, &approved); err != nil {
+		return "", err
+	}
+	if isZeroAddress(approved) {
+		return "", nil
+	}
+	return approved.String(), nil
+}
+
+// IsApprovedForAll reports whether operator may transfer all of owner's
+// tokens.
+func (t *Token) IsApprovedForAll(ctx context.Context, owner, operator string) (bool, error) {
+	ownerAddr, err := address.Base58ToAddress(owner)
+	if err != nil {
+		return false, fmt.Errorf("invalid owner address %s: %w", owner, err)
+	}
+	operatorAddr, err := address.Base58ToAddress(operator)
+	if err != nil {
+		return false, fmt.Errorf("invalid operator address %s: %w", operator, err)
+	}
+	var approved bool
+	if err := t.call("isApprovedForAll", ownerAddr, operatorAddr).CallInto(ctx, &approved); err != nil {
+		return false, err
+	}
+	return approved, nil
+}
+
+// TotalSupply returns the number of tokens in existence (enumerable
+// extension).
+func (t *Token) TotalSupply(ctx context.Context) (*big.Int, error) {
+	var supply *big.Int
+	if err := t.call("totalSupply").CallInto(ctx, &supply); err != nil {
+		return nil, err
+	}
+	return supply, nil
+}
+
+// TokenByIndex returns the token at index of all tokens (enumerable
+// extension).
+func (t *Token) TokenByIndex(ctx context.Context, index *big.Int) (*big.Int, error) {
+	var tokenID *big.Int
+	if err := t.call("tokenByIndex", index).CallInto(ctx, &tokenID); err != nil {
+		return nil, err
+	}
+	return tokenID, nil
+}
+
+// TokenOfOwnerByIndex returns the token at index of owner's tokens
+// (enumerable extension).
+func (t *Token) TokenOfOwnerByIndex(ctx context.Context, owner string, index *big.Int) (*big.Int, error) {
+	ownerAddr, err := address.Base58ToAddress(owner)
+	if err != nil {
+		return nil, fmt.Errorf("invalid owner address %s: %w", owner, err)
+	}
+	var tokenID *big.Int
+	if err := t.call("tokenOfOwnerByIndex", ownerAddr, index).CallInto(ctx, &tokenID); err != nil {
+		return nil, err
+	}
+	return tokenID, nil
+}
+
+// TokensOfOwner lists all tokens held by owner using balanceOf and
+// tokenOfOwnerByIndex (enumerable extension). It issues one call per token.
+func (t *Token) TokensOfOwner(ctx context.Context, owner string) ([]*big.Int, error) {
+	balance, err := t.BalanceOf(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	if !balance.IsInt64() {
+		return nil, fmt.Errorf("balance %s too large to enumerate", balance)
+	}
+	tokens := make([]*big.Int, 0, balance.Int64())
+	for i := int64(0); i < balance.Int64(); i++ {
+		tokenID, err := t.TokenOfOwnerByIndex(ctx, owner, big.NewInt(i))
+		if err != nil {
+			return nil, fmt.Errorf("token %d: %w", i, err)
+		}
+		tokens = append(tokens, tokenID)
+	}
+	return tokens, nil
+}
+
+// SupportsInterface calls the contract's ERC-165 supportsInterface. A
+// contract without ERC-165 reverts or returns nothing, which is reported as
+// false.
+func (t *Token) SupportsInterface(ctx context.Context, interfaceID [4]byte) (bool, error) {
+	var supported bool
+	err := t.call("supportsInterface", interfaceID).CallInto(ctx, &supported)
+	var rerr *contract.RevertError
+	if errors.As(err, &rerr) || errors.Is(err, contract.ErrEmptyResult) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return supported, nil
+}
+
+// Interfaces detects the TRC-721 interfaces of the contract following the
+// ERC-165 detection procedure: the contract must report ERC-165 support and
+// must reject the 0xffffffff identifier before its other answers are trusted.
+func (t *Token) Interfaces(ctx context.Context) (*Interfaces, error) {
+	out := &Interfaces{}
+	erc165, err := t.SupportsInterface(ctx, InterfaceERC165)
+	if err != nil {
+		return nil, err
+	}
+	if !erc165 {
+		return out, nil
+	}
+	invalid, err := t.SupportsInterface(ctx, interfaceInvalid)
+	if err != nil {
+		return nil, err
+	}
+	if invalid {
+		return out, nil
+	}
+	for _, check := range []struct {
+		id  [4]byte
+		out *bool
+	}{
+		{InterfaceTRC721, &out.TRC721},
+		{InterfaceMetadata, &out.Metadata},
+		{InterfaceEnumerable, &out.Enumerable},
+	} {
+		if *check.out, err = t.SupportsInterface(ctx, check.id); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// TransferFrom returns a ContractCall transferring tokenID from from to to
+// without checking that the recipient can receive it. The caller parameter is
+// the address that signs the transaction: the owner, an approved address or
+// an operator. Address validation errors are deferred to the terminal call.
+func (t *Token) TransferFrom(caller, from, to string, tokenID *big.Int, opts ...contract.Option) *contract.ContractCall {
+	return t.transfer("transferFrom", caller, from, to, tokenID, opts)
+}
+
+// SafeTransferFrom returns a ContractCall transferring tokenID from from to
+// to. Transfers to contracts revert unless the recipient implements
+// onTRC721Received. See TransferFrom for caller.
+func (t *Token) SafeTransferFrom(caller, from, to string, tokenID *big.Int, opts ...contract.Option) *contract.ContractCall {
+	return t.transfer(methodSafeTransferFrom, caller, from, to, tokenID, opts)
+}
+
+// SafeTransferFromWithData is SafeTransferFrom passing data to the
+// recipient's onTRC721Received hook.
+func (t *Token) SafeTransferFromWithData(caller, from, to string, tokenID *big.Int, data []byte, opts ...contract.Option) *contract.ContractCall {
+	return t.transfer(methodSafeTransferFromWithData, caller, from, to, tokenID, opts, data)
+}
+
+func (t *Token) transfer(method, caller, from, to string, tokenID *big.Int, opts []contract.Option, extra ...interface{}) *contract.ContractCall {
+	fromAddr, err := address.Base58ToAddress(from)
+	if err != nil {
+		return contract.New(t.client, t.contractAddress).
+			SetError(fmt.Errorf("invalid from address %s: %w", from, err))
+	}
+	toAddr, err := address.Base58ToAddress(to)
+	if err != nil {
+		return contract.New(t.client, t.contractAddress).
+			SetError(fmt.Errorf("invalid to address %s: %w", to, err))
+	}
+	args := append([]interface{}{fromAddr, toAddr, tokenID}, extra...)
+	return t.call(method, args...).
+		From(caller).
+		Apply(opts...)
+}
+
+// Approve returns a ContractCall approving to to transfer tokenID. An empty
+// to clears the approval.
+func (t *Token) Approve(from, to string, tokenID *big.Int, opts ...contract.Option) *contract.ContractCall {
+	toAddr := make(address.Address, address.AddressLength)
+	toAddr[0] = address.TronBytePrefix
+	if to != "" {
+		var err error
+		if toAddr, err = address.Base58ToAddress(to); err != nil {
+			return contract.New(t.client, t.contractAddress).
+				SetError(fmt.Errorf("invalid to address %s: %w", to, err))
+		}
+	}
+	return t.call("approve", toAddr, tokenID).
+		From(from).
+		Apply(opts...)
+}
+
+// SetApprovalForAll returns a ContractCall granting or revoking operator's
+// permission to transfer all of from's tokens.
+func (t *Token) SetApprovalForAll(from, operator string, approved bool, opts ...contract.Option) *contract.ContractCall {
+	operatorAddr, err := address.Base58ToAddress(operator)
+	if err != nil {
+		return contract.New(t.client, t.contractAddress).
+			SetError(fmt.Errorf("invalid operator address %s: %w", operator, err))
+	}
+	return t.call("setApprovalForAll", operatorAddr, approved).
+		From(from).
+		Apply(opts...)
+}
+
+// isZeroAddress reports whether addr is empty or all zero after the prefix.
+func isZeroAddress(addr address.Address) bool {
+	if len(addr) > 0 {
+		addr = addr[1:]
+	}
+	for _, b := range addr {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,233 @@
+package trc721
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/abi"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/client"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	tokenAddr = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+	ownerAddr = "TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b"
+	otherAddr = "TLyqzVGLV1srkB7dToTAEqgDSfPtXRJZYH"
+)
+
+// mockClient answers constant calls by exact call data; unknown calls
+// revert. State-changing calls capture their data.
+type mockClient struct {
+	returns  map[string][]byte
+	lastData []byte
+	err      error
+}
+
+func newMockClient() *mockClient {
+	return &mockClient{returns: make(map[string][]byte)}
+}
+
+// on registers the return data of a call, packed against the token ABI.
+func (m *mockClient) on(t *testing.T, method string, ret []byte, args ...interface{}) {
+	parsed, err := abi.ParseABI(ABI)
+	require.NoError(t, err)
+	meth, err := abi.FindMethod(parsed, method)
+	require.NoError(t, err)
+	packed, err := abi.PackArgs(meth.Inputs, args...)
+	require.NoError(t, err)
+	m.returns[string(append(meth.ID, packed...))] = ret
+}
+
+func (m *mockClient) TriggerConstantContractWithDataCtx(_ context.Context, _, _ string, data []byte, _ ...client.ConstantCallOption) (*api.TransactionExtention, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	ret, ok := m.returns[string(data)]
+	if !ok {
+		return &api.TransactionExtention{
+			Result:      &api.Return{Result: false, Message: []byte("REVERT opcode executed")},
+			Transaction: &core.Transaction{Ret: []*core.Transaction_Result{{ContractRet: core.Transaction_Result_REVERT}}},
+		}, nil
+	}
+	return &api.TransactionExtention{Result: &api.Return{Result: true}, ConstantResult: [][]byte{ret}}, nil
+}
+
+func (m *mockClient) TriggerConstantContractCtx(context.Context, string, string, string, string, ...client.ConstantCallOption) (*api.TransactionExtention, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClient) TriggerContractCtx(context.Context, string, string, string, string, int64, int64, string, int64) (*api.TransactionExtention, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClient) TriggerContractWithDataCtx(_ context.Context, _, _ string, data []byte, _, _ int64, _ string, _ int64) (*api.TransactionExtention, error) {
+	m.lastData = data
+	return &api.TransactionExtention{
+		Transaction: &core.Transaction{RawData: &core.TransactionRaw{Contract: []*core.Transaction_Contract{{}}}},
+		Result:      &api.Return{Result: true},
+	}, nil
+}
+
+func (m *mockClient) EstimateEnergyCtx(context.Context, string, string, string, string, int64, string, int64) (*api.EstimateEnergyMessage, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClient) EstimateEnergyWithDataCtx(context.Context, string, string, []byte, int64, string, int64) (*api.EstimateEnergyMessage, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClient) BroadcastCtx(context.Context, *core.Transaction) (*api.Return, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockClient) GetTransactionInfoByIDCtx(context.Context, string) (*core.TransactionInfo, error) {
+	return nil, errors.New("not implemented")
+}
+
+func word(t *testing.T, v interface{}) []byte {
+	var ty string
+	switch v.(type) {
+	case bool:
+		ty = "bool"
+	case string:
+		ty = "address"
+	default:
+		ty = "uint256"
+	}
+	parsed, err := abi.ParseABI(`[{"type":"function","name":"f","inputs":[{"name":"v","type":"` + ty + `"}],"outputs":[]}]`)
+	require.NoError(t, err)
+	out, err := abi.PackArgs(parsed.Methods["f"].Inputs, v)
+	require.NoError(t, err)
+	return out
+}
+
+func stringResult(t *testing.T, s string) []byte {
+	parsed, err := abi.ParseABI(`[{"type":"function","name":"f","inputs":[{"name":"v","type":"string"}],"outputs":[]}]`)
+	require.NoError(t, err)
+	out, err := abi.PackArgs(parsed.Methods["f"].Inputs, s)
+	require.NoError(t, err)
+	return out
+}
+
+func TestReads(t *testing.T) {
+	m := newMockClient()
+	owner, err := address.Base58ToAddress(ownerAddr)
+	require.NoError(t, err)
+	other, err := address.Base58ToAddress(otherAddr)
+	require.NoError(t, err)
+
+	m.on(t, "name", stringResult(t, "Punks"))
+	m.on(t, "tokenURI", stringResult(t, "ipfs://punk/7"), big.NewInt(7))
+	m.on(t, "ownerOf", word(t, ownerAddr), big.NewInt(7))
+	m.on(t, "getApproved", word(t, "T9yD14Nj9j7xAB4dbGeiX9h8unkKHxuWwb"), big.NewInt(7))
+	m.on(t, "isApprovedForAll", word(t, true), owner, other)
+	m.on(t, "balanceOf", word(t, big.NewInt(2)), owner)
+	m.on(t, "tokenOfOwnerByIndex", word(t, big.NewInt(7)), owner, big.NewInt(0))
+	m.on(t, "tokenOfOwnerByIndex", word(t, big.NewInt(9)), owner, big.NewInt(1))
+	token := New(m, tokenAddr)
+	ctx := context.Background()
+
+	name, err := token.Name(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "Punks", name)
+
+	uri, err := token.TokenURI(ctx, big.NewInt(7))
+	require.NoError(t, err)
+	assert.Equal(t, "ipfs://punk/7", uri)
+
+	holder, err := token.OwnerOf(ctx, big.NewInt(7))
+	require.NoError(t, err)
+	assert.Equal(t, ownerAddr, holder)
+
+	approved, err := token.GetApproved(ctx, big.NewInt(7))
+	require.NoError(t, err)
+	assert.Empty(t, approved)
+
+	all, err := token.IsApprovedForAll(ctx, ownerAddr, otherAddr)
+	require.NoError(t, err)
+	assert.True(t, all)
+
+	tokens, err := token.TokensOfOwner(ctx, ownerAddr)
+	require.NoError(t, err)
+	assert.Equal(t, []*big.Int{big.NewInt(7), big.NewInt(9)}, tokens)
+
+	_, err = token.BalanceOf(ctx, "bad")
+	assert.ErrorContains(t, err, "invalid owner address")
+
+	// A nonexistent token reverts.
+	_, err = token.OwnerOf(ctx, big.NewInt(8))
+	assert.Error(t, err)
+}
+
+func TestInterfaces(t *testing.T) {
+	m := newMockClient()
+	m.on(t, "supportsInterface", word(t, true), InterfaceERC165)
+	m.on(t, "supportsInterface", word(t, false), interfaceInvalid)
+	m.on(t, "supportsInterface", word(t, true), InterfaceTRC721)
+	m.on(t, "supportsInterface", word(t, true), InterfaceMetadata)
+	m.on(t, "supportsInterface", word(t, false), InterfaceEnumerable)
+
+	got, err := New(m, tokenAddr).Interfaces(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &Interfaces{TRC721: true, Metadata: true}, got)
+
+	// A contract that claims every interface is not trusted.
+	m.on(t, "supportsInterface", word(t, true), interfaceInvalid)
+	got, err = New(m, tokenAddr).Interfaces(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &Interfaces{}, got)
+
+	// Without ERC-165 the call reverts.
+	got, err = New(newMockClient(), tokenAddr).Interfaces(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &Interfaces{}, got)
+
+	m.err = errors.New("connection refused")
+	_, err = New(m, tokenAddr).Interfaces(context.Background())
+	assert.ErrorContains(t, err, "connection refused")
+}
+
+func TestWrites(t *testing.T) {
+	m := newMockClient()
+	token := New(m, tokenAddr)
+	ctx := context.Background()
+	parsed, err := abi.ParseABI(ABI)
+	require.NoError(t, err)
+	selector := func(sig string) []byte {
+		meth, err := abi.FindMethod(parsed, sig)
+		require.NoError(t, err)
+		return meth.ID
+	}
+
+	_, err = token.SafeTransferFrom(ownerAddr, ownerAddr, otherAddr, big.NewInt(7)).Build(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, selector(methodSafeTransferFrom), m.lastData[:4])
+	assert.Len(t, m.lastData, 4+3*32)
+
+	_, err = token.SafeTransferFromWithData(ownerAddr, ownerAddr, otherAddr, big.NewInt(7), []byte("hi")).Build(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, selector(methodSafeTransferFromWithData), m.lastData[:4])
+
+	_, err = token.TransferFrom(otherAddr, ownerAddr, otherAddr, big.NewInt(7)).Build(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, selector("transferFrom"), m.lastData[:4])
+
+	_, err = token.Approve(ownerAddr, "", big.NewInt(7)).Build(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, selector("approve"), m.lastData[:4])
+	assert.Equal(t, make([]byte, 32), m.lastData[4:36])
+
+	_, err = token.SetApprovalForAll(ownerAddr, otherAddr, true).Build(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, selector("setApprovalForAll"), m.lastData[:4])
+	assert.Equal(t, byte(1), m.lastData[len(m.lastData)-1])
+
+	call := token.SafeTransferFrom(ownerAddr, ownerAddr, "bad", big.NewInt(7))
+	assert.ErrorContains(t, call.Err(), "invalid to address")
+}
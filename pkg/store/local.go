@@ -110,6 +110,9 @@ func (s *Store) DescribeLocalAccounts() {
 		}
 		ks.Close()
 		s.Forget(ks)
+		for _, account := range hdAccounts(s.configAccountsDir(), name) {
+			fmt.Printf("%-48s\t%s\n", name, account.Address)
+		}
 	}
 }
 
@@ -129,6 +132,9 @@ func (s *Store) AddressFromAccountName(name string) (string, error) {
 	for _, account := range ks.Accounts() {
 		return account.Address.String(), nil
 	}
+	for _, account := range hdAccounts(s.configAccountsDir(), name) {
+		return account.Address.String(), nil
+	}
 	return "", fmt.Errorf("no accounts found in keystore for %s", name)
 }
 
@@ -192,6 +198,12 @@ func (s *Store) CloseAll() {
 	s.newKeyStore = keystore.ForPath
 }
 
+// HDWallet loads the HD wallet of the named account. The error wraps
+// os.ErrNotExist when the account has none.
+func (s *Store) HDWallet(name string) (*keystore.HDWallet, error) {
+	return keystore.LoadHDWallet(hdWalletFile(s.configAccountsDir(), name))
+}
+
 // DefaultLocation returns the current default keystore directory path.
 func (s *Store) DefaultLocation() string {
 	return s.configAccountsDir()
@@ -213,6 +225,7 @@ func (s *Store) SetDefaultLocation(directory string) {
 }
 
 // UnlockedKeystore finds, unlocks, and returns the keystore and account for the given Base58 address.
+// Accounts pinned in an account's HD wallet are unlocked by deriving their key.
 func (s *Store) UnlockedKeystore(from, passphrase string) (*keystore.KeyStore, *keystore.Account, error) {
 	sender, err := address.Base58ToAddress(from)
 	if err != nil {
@@ -220,7 +233,17 @@ func (s *Store) UnlockedKeystore(from, passphrase string) (*keystore.KeyStore, *
 	}
 	ks := s.FromAddress(from)
 	if ks == nil {
-		return nil, nil, fmt.Errorf("could not open local keystore for %s", from)
+		name, account, ok := findHDAccount(s.configAccountsDir(), s.LocalAccounts(), sender)
+		if !ok {
+			return nil, nil, fmt.Errorf("could not open local keystore for %s", from)
+		}
+		ks = s.FromAccountName(name)
+		if err := unlockHDAccount(ks, account, passphrase); err != nil {
+			ks.Close()
+			s.Forget(ks)
+			return nil, nil, err
+		}
+		return ks, &account, nil
 	}
 	account, lookupErr := ks.Find(keystore.Account{Address: sender})
 	if lookupErr != nil {
@@ -289,6 +312,9 @@ func DescribeLocalAccounts() {
 			fmt.Printf("%-48s\t%s\n", name, account.Address)
 		}
 		ks.Close()
+		for _, account := range hdAccounts(configAccountsDir(), name) {
+			fmt.Printf("%-48s\t%s\n", name, account.Address)
+		}
 	}
 }
 
@@ -305,6 +331,9 @@ func AddressFromAccountName(name string) (string, error) {
 	for _, account := range ks.Accounts() {
 		return account.Address.String(), nil
 	}
+	for _, account := range hdAccounts(configAccountsDir(), name) {
+		return account.Address.String(), nil
+	}
 	return "", fmt.Errorf("no accounts found in keystore for %s", name)
 }
 
@@ -365,6 +394,12 @@ func CloseAll() {
 	newKeyStore = keystore.ForPath
 }
 
+// HDWallet loads the HD wallet of the named account. The error wraps
+// os.ErrNotExist when the account has none.
+func HDWallet(name string) (*keystore.HDWallet, error) {
+	return keystore.LoadHDWallet(hdWalletFile(configAccountsDir(), name))
+}
+
 // DefaultLocation returns the current default keystore directory path.
 func DefaultLocation() string {
 	return configAccountsDir()
@@ -385,6 +420,7 @@ func SetDefaultLocation(directory string) {
 }
 
 // UnlockedKeystore finds, unlocks, and returns the keystore and account for the given Base58 address.
+// Accounts pinned in an account's HD wallet are unlocked by deriving their key.
 func UnlockedKeystore(from, passphrase string) (*keystore.KeyStore, *keystore.Account, error) {
 	sender, err := address.Base58ToAddress(from)
 	if err != nil {
@@ -392,7 +428,16 @@ func UnlockedKeystore(from, passphrase string) (*keystore.KeyStore, *keystore.Ac
 	}
 	ks := FromAddress(from)
 	if ks == nil {
-		return nil, nil, fmt.Errorf("could not open local keystore for %s", from)
+		name, account, ok := findHDAccount(configAccountsDir(), LocalAccounts(), sender)
+		if !ok {
+			return nil, nil, fmt.Errorf("could not open local keystore for %s", from)
+		}
+		ks = FromAccountName(name)
+		if err := unlockHDAccount(ks, account, passphrase); err != nil {
+			ks.Close()
+			return nil, nil, err
+		}
+		return ks, &account, nil
 	}
 	account, lookupErr := ks.Find(keystore.Account{Address: sender})
 	if lookupErr != nil {
@@ -405,3 +450,48 @@ func UnlockedKeystore(from, passphrase string) (*keystore.KeyStore, *keystore.Ac
 	}
 	return ks, &account, nil
 }
+
+// --- HD wallet helpers shared by Store and the package-level functions ---
+
+func hdWalletFile(accountsDir, name string) string {
+	return filepath.Join(accountsDir, name, keystore.HDWalletFileName)
+}
+
+// hdAccounts returns the accounts pinned in the named account's HD wallet,
+// or nil if it has none.
+func hdAccounts(accountsDir, name string) []keystore.Account {
+	w, err := keystore.LoadHDWallet(hdWalletFile(accountsDir, name))
+	if err != nil {
+		return nil
+	}
+	return w.Accounts()
+}
+
+// findHDAccount returns the account name whose HD wallet pinned addr.
+func findHDAccount(accountsDir string, names []string, addr address.Address) (string, keystore.Account, bool) {
+	for _, name := range names {
+		w, err := keystore.LoadHDWallet(hdWalletFile(accountsDir, name))
+		if err != nil {
+			continue
+		}
+		account := keystore.Account{Address: addr, URL: w.URL()}
+		if w.Contains(account) {
+			return name, account, true
+		}
+	}
+	return "", keystore.Account{}, false
+}
+
+// unlockHDAccount opens the HD wallet holding account with passphrase and
+// unlocks the derived key in ks.
+func unlockHDAccount(ks *keystore.KeyStore, account keystore.Account, passphrase string) error {
+	w, err := ks.HDWallet()
+	if err != nil {
+		return err
+	}
+	if err := w.Open(passphrase); err != nil {
+		return fmt.Errorf("%s: %w", err.Error(), ErrNoUnlockBadPassphrase)
+	}
+	defer func() { _ = w.Close() }()
+	return ks.UnlockDerived(w, account)
+}
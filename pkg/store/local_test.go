@@ -713,3 +713,37 @@ func TestStore_UnlockedKeystore(t *testing.T) {
 	assert.Equal(t, addrStr, rAcct.Address.String())
 	rKs.Close()
 }
+
+func TestUnlockedKeystore_HDWalletAccount(t *testing.T) {
+	s := newTempStore(t)
+	s.SetKeystoreFactory(keystore.ForPathLight)
+
+	ks := s.FromAccountName("hd-test")
+	seed := make([]byte, 64)
+	w, err := ks.NewHDWallet(seed, "correct-pass")
+	require.NoError(t, err)
+	require.NoError(t, w.Open("correct-pass"))
+	acct, err := w.Derive(keystore.TronDerivationPath(0, 0, 3), true)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	ks.Close()
+	s.Forget(ks)
+	addrStr := acct.Address.String()
+
+	got, err := s.AddressFromAccountName("hd-test")
+	require.NoError(t, err)
+	assert.Equal(t, addrStr, got)
+
+	_, err = s.HDWallet("missing")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	_, _, err = s.UnlockedKeystore(addrStr, "wrong-pass")
+	assert.ErrorIs(t, err, store.ErrNoUnlockBadPassphrase)
+
+	resultKs, resultAcct, err := s.UnlockedKeystore(addrStr, "correct-pass")
+	require.NoError(t, err)
+	assert.Equal(t, addrStr, resultAcct.Address.String())
+	_, err = resultKs.SignHash(*resultAcct, make([]byte, 32))
+	assert.NoError(t, err)
+	resultKs.Close()
+}
@@ -1,6 +1,6 @@
 // Package tron provides convenience constructors that bind a GrpcClient to
-// the builder packages (txbuilder, contract, trc20). This avoids boilerplate
-// and gives developers a single entry point to the SDK.
+// the builder packages (txbuilder, contract, trc20, trc10). This avoids
+// boilerplate and gives developers a single entry point to the SDK.
 //
 //	conn := client.NewGrpcClient("grpc.trongrid.io:50051")
 //	conn.Start(grpc.WithTransportCredentials(insecure.NewCredentials()))
@@ -9,19 +9,24 @@
 //	t.TxBuilder().Transfer(from, to, amount).Send(ctx, signer)
 //	t.Contract(addr).Method("transfer").From(from).Params(json).Send(ctx, signer)
 //	t.TRC20(addr).Transfer(from, to, amount).Send(ctx, signer)
+//	t.TRC10(tokenID).Transfer(from, to, amount).Send(ctx, signer)
 package tron
 
 import (
 	"github.com/fbsobreira/gotron-sdk/pkg/client"
 	"github.com/fbsobreira/gotron-sdk/pkg/contract"
+	"github.com/fbsobreira/gotron-sdk/pkg/standards/trc10"
 	"github.com/fbsobreira/gotron-sdk/pkg/standards/trc20"
 	"github.com/fbsobreira/gotron-sdk/pkg/txbuilder"
 )
 
 // Compile-time interface satisfaction checks.
 var (
-	_ txbuilder.Client = (*client.GrpcClient)(nil)
-	_ contract.Client  = (*client.GrpcClient)(nil)
+	_ txbuilder.Client      = (*client.GrpcClient)(nil)
+	_ txbuilder.AssetClient = (*client.GrpcClient)(nil)
+	_ contract.Client       = (*client.GrpcClient)(nil)
+	_ contract.Deployer     = (*client.GrpcClient)(nil)
+	_ trc10.Client          = (*client.GrpcClient)(nil)
 )
 
 // SDK wraps a GrpcClient and provides builder constructors.
@@ -50,6 +55,11 @@ func (s *SDK) TRC20(contractAddress string) *trc20.Token {
 	return trc20.New(s.conn, contractAddress)
 }
 
+// TRC10 returns a typed TRC10 token handle for the given token ID.
+func (s *SDK) TRC10(tokenID string) *trc10.Token {
+	return trc10.New(s.conn, tokenID)
+}
+
 // Client returns the underlying GrpcClient.
 func (s *SDK) Client() *client.GrpcClient {
 	return s.conn
@@ -0,0 +1,51 @@
+package txbuilder
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
+)
+
+// TransferAsset creates a TRC10 transfer of amount base units of tokenID.
+// The Builder's client must implement AssetClient.
+func (b *Builder) TransferAsset(from, to, tokenID string, amount int64, opts ...Option) *Tx {
+	return b.newTx(func(ctx context.Context) (*api.TransactionExtention, error) {
+		c, err := b.assetClient()
+		if err != nil {
+			return nil, err
+		}
+		return c.TransferAssetCtx(ctx, from, to, tokenID, amount)
+	}, opts)
+}
+
+// ParticipateAssetIssue creates a TRC10 ICO participation spending amount
+// SUN on tokenID. The issuer address the node requires is looked up from the
+// asset when the transaction is built. The Builder's client must implement
+// AssetClient.
+func (b *Builder) ParticipateAssetIssue(from, tokenID string, amount int64, opts ...Option) *Tx {
+	return b.newTx(func(ctx context.Context) (*api.TransactionExtention, error) {
+		c, err := b.assetClient()
+		if err != nil {
+			return nil, err
+		}
+		asset, err := c.GetAssetIssueByIDCtx(ctx, tokenID)
+		if err != nil {
+			return nil, fmt.Errorf("get asset %s: %w", tokenID, err)
+		}
+		if len(asset.GetOwnerAddress()) == 0 {
+			return nil, fmt.Errorf("asset %s not found", tokenID)
+		}
+		issuer := address.Address(asset.GetOwnerAddress()).String()
+		return c.ParticipateAssetIssueCtx(ctx, from, issuer, tokenID, amount)
+	}, opts)
+}
+
+func (b *Builder) assetClient() (AssetClient, error) {
+	c, ok := b.client.(AssetClient)
+	if !ok {
+		return nil, fmt.Errorf("client %T does not support TRC10 transactions", b.client)
+	}
+	return c, nil
+}
@@ -15,7 +15,7 @@ import (
 	"google.golang.org/protobuf/types/known/anypb"
 )
 
-// mockClient implements the Client interface for testing.
+// mockClient implements the AssetClient interface for testing.
 type mockClient struct {
 	transferFn               func(ctx context.Context, from, to string, amount int64) (*api.TransactionExtention, error)
 	broadcastFn              func(ctx context.Context, tx *core.Transaction) (*api.Return, error)
@@ -26,6 +26,9 @@ type mockClient struct {
 	unDelegateResourceFn     func(ctx context.Context, from, to string, resource core.ResourceCode, amount int64) (*api.TransactionExtention, error)
 	voteWitnessAccountFn     func(ctx context.Context, from string, votes map[string]int64) (*api.TransactionExtention, error)
 	withdrawExpireUnfreezeFn func(ctx context.Context, from string, timestamp int64) (*api.TransactionExtention, error)
+	transferAssetFn          func(ctx context.Context, from, to, tokenID string, amount int64) (*api.TransactionExtention, error)
+	participateAssetIssueFn  func(ctx context.Context, from, issuer, tokenID string, amount int64) (*api.TransactionExtention, error)
+	getAssetIssueByIDFn      func(ctx context.Context, tokenID string) (*core.AssetIssueContract, error)
 }
 
 func (m *mockClient) TransferCtx(ctx context.Context, from, to string, amount int64) (*api.TransactionExtention, error) {
@@ -91,6 +94,27 @@ func (m *mockClient) WithdrawExpireUnfreezeCtx(ctx context.Context, from string,
 	return nil, fmt.Errorf("WithdrawExpireUnfreezeCtx not implemented")
 }
 
+func (m *mockClient) TransferAssetCtx(ctx context.Context, from, to, tokenID string, amount int64) (*api.TransactionExtention, error) {
+	if m.transferAssetFn != nil {
+		return m.transferAssetFn(ctx, from, to, tokenID, amount)
+	}
+	return nil, fmt.Errorf("TransferAssetCtx not implemented")
+}
+
+func (m *mockClient) ParticipateAssetIssueCtx(ctx context.Context, from, issuer, tokenID string, amount int64) (*api.TransactionExtention, error) {
+	if m.participateAssetIssueFn != nil {
+		return m.participateAssetIssueFn(ctx, from, issuer, tokenID, amount)
+	}
+	return nil, fmt.Errorf("ParticipateAssetIssueCtx not implemented")
+}
+
+func (m *mockClient) GetAssetIssueByIDCtx(ctx context.Context, tokenID string) (*core.AssetIssueContract, error) {
+	if m.getAssetIssueByIDFn != nil {
+		return m.getAssetIssueByIDFn(ctx, tokenID)
+	}
+	return nil, fmt.Errorf("GetAssetIssueByIDCtx not implemented")
+}
+
 // mockSigner implements signer.Signer for testing.
 type mockSigner struct {
 	addr address.Address
@@ -266,6 +290,61 @@ func TestFreezeV2_Build(t *testing.T) {
 	require.NotNil(t, ext)
 }
 
+func TestTransferAsset_Build(t *testing.T) {
+	mc := &mockClient{
+		transferAssetFn: func(_ context.Context, from, to, tokenID string, amount int64) (*api.TransactionExtention, error) {
+			assert.Equal(t, "TOwner", from)
+			assert.Equal(t, "TReceiver", to)
+			assert.Equal(t, "1002000", tokenID)
+			assert.Equal(t, int64(42), amount)
+			return newDummyTxExt(), nil
+		},
+	}
+
+	b := New(mc)
+	ext, err := b.TransferAsset("TOwner", "TReceiver", "1002000", 42).Build(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+}
+
+func TestParticipateAssetIssue_ResolvesIssuer(t *testing.T) {
+	issuer, err := address.Base58ToAddress("TPpw7soPWEDQWXPCGUMagYPryaWrYR5b3b")
+	require.NoError(t, err)
+	mc := &mockClient{
+		getAssetIssueByIDFn: func(_ context.Context, tokenID string) (*core.AssetIssueContract, error) {
+			assert.Equal(t, "1002000", tokenID)
+			return &core.AssetIssueContract{OwnerAddress: issuer}, nil
+		},
+		participateAssetIssueFn: func(_ context.Context, from, to, tokenID string, amount int64) (*api.TransactionExtention, error) {
+			assert.Equal(t, "TOwner", from)
+			assert.Equal(t, issuer.String(), to)
+			assert.Equal(t, int64(1000000), amount)
+			return newDummyTxExt(), nil
+		},
+	}
+
+	b := New(mc)
+	ext, err := b.ParticipateAssetIssue("TOwner", "1002000", 1000000).Build(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+
+	mc.getAssetIssueByIDFn = func(context.Context, string) (*core.AssetIssueContract, error) {
+		return &core.AssetIssueContract{}, nil
+	}
+	_, err = b.ParticipateAssetIssue("TOwner", "999", 1).Build(context.Background())
+	assert.ErrorContains(t, err, "asset 999 not found")
+}
+
+func TestTransferAsset_ClientWithoutAssets(t *testing.T) {
+	// Hide the TRC10 methods of the mock behind the plain Client interface.
+	b := New(struct{ Client }{&mockClient{}})
+
+	_, err := b.TransferAsset("TOwner", "TReceiver", "1002000", 42).Build(context.Background())
+	assert.ErrorContains(t, err, "does not support TRC10 transactions")
+	_, err = b.ParticipateAssetIssue("TOwner", "1002000", 1).Build(context.Background())
+	assert.ErrorContains(t, err, "does not support TRC10 transactions")
+}
+
 func TestDelegateResource_WithLock(t *testing.T) {
 	mc := &mockClient{
 		delegateResourceFn: func(_ context.Context, from, to string, resource core.ResourceCode, amount int64, lock bool, lockPeriod int64) (*api.TransactionExtention, error) {
@@ -20,3 +20,12 @@ type Client interface {
 	VoteWitnessAccountCtx(ctx context.Context, from string, witnessMap map[string]int64) (*api.TransactionExtention, error)
 	WithdrawExpireUnfreezeCtx(ctx context.Context, from string, timestamp int64) (*api.TransactionExtention, error)
 }
+
+// AssetClient is a Client that can also build TRC10 transactions. The TRC10
+// builders require it and type-assert the Builder's client to it.
+type AssetClient interface {
+	Client
+	TransferAssetCtx(ctx context.Context, from, toAddress, assetName string, amount int64) (*api.TransactionExtention, error)
+	ParticipateAssetIssueCtx(ctx context.Context, from, issuerAddress, tokenID string, amount int64) (*api.TransactionExtention, error)
+	GetAssetIssueByIDCtx(ctx context.Context, tokenID string) (*core.AssetIssueContract, error)
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
 	"github.com/fbsobreira/gotron-sdk/pkg/common"
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/api"
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
@@ -73,7 +74,8 @@ func Send(ctx context.Context, b Broadcaster, s signer.Signer, tx *core.Transact
 }
 
 // SendAndConfirm sends a transaction and polls until confirmed or the context
-// is cancelled.
+// is cancelled. For CreateSmartContract transactions the receipt carries the
+// address of the deployed contract.
 func SendAndConfirm(ctx context.Context, b Broadcaster, s signer.Signer, tx *core.Transaction, pollInterval time.Duration) (*Receipt, error) {
 	receipt, err := Send(ctx, b, s, tx)
 	if err != nil {
@@ -85,11 +87,25 @@ func SendAndConfirm(ctx context.Context, b Broadcaster, s signer.Signer, tx *cor
 	if pollInterval <= 0 {
 		pollInterval = DefaultPollInterval
 	}
-	return WaitForConfirmation(ctx, b, receipt, pollInterval)
+	return waitForConfirmation(ctx, b, receipt, pollInterval, isContractCreation(tx))
 }
 
-// WaitForConfirmation polls for transaction confirmation.
+// isContractCreation reports whether tx deploys a contract. Nodes also fill
+// TransactionInfo.contract_address for contract calls, so only deployments
+// may report it as a created contract.
+func isContractCreation(tx *core.Transaction) bool {
+	contracts := tx.GetRawData().GetContract()
+	return len(contracts) > 0 && contracts[0].GetType() == core.Transaction_Contract_CreateSmartContract
+}
+
+// WaitForConfirmation polls for transaction confirmation. It does not know
+// the transaction, so it leaves the receipt's ContractAddress empty; use
+// SendAndConfirm to deploy contracts.
 func WaitForConfirmation(ctx context.Context, b Broadcaster, receipt *Receipt, pollInterval time.Duration) (*Receipt, error) {
+	return waitForConfirmation(ctx, b, receipt, pollInterval, false)
+}
+
+func waitForConfirmation(ctx context.Context, b Broadcaster, receipt *Receipt, pollInterval time.Duration, created bool) (*Receipt, error) {
 	ticker := time.NewTicker(pollInterval)
 	defer ticker.Stop()
 	for {
@@ -117,6 +133,9 @@ func WaitForConfirmation(ctx context.Context, b Broadcaster, receipt *Receipt, p
 			if results := info.GetContractResult(); len(results) > 0 {
 				receipt.Result = results[0]
 			}
+			if addr := info.GetContractAddress(); created && len(addr) > 0 {
+				receipt.ContractAddress = address.Address(addr).String()
+			}
 			if info.GetResult() != core.TransactionInfo_SUCESS {
 				receipt.Error = string(info.GetResMessage())
 			}
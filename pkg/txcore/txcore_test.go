@@ -188,6 +188,33 @@ func TestSendAndConfirm_Success(t *testing.T) {
 	assert.Equal(t, int64(50000), receipt.EnergyUsed)
 	assert.Equal(t, int64(300), receipt.BandwidthUsed)
 	assert.Equal(t, []byte{0x01, 0x02}, receipt.Result)
+	assert.Empty(t, receipt.ContractAddress)
+}
+
+func TestSendAndConfirm_ContractAddress(t *testing.T) {
+	created, err := address.Base58ToAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	require.NoError(t, err)
+	b := &mockBroadcaster{
+		broadcastFn: func(_ context.Context, _ *core.Transaction) (*api.Return, error) {
+			return &api.Return{Result: true, Code: 0}, nil
+		},
+		getTransactionInfoFn: func(_ context.Context, _ string) (*core.TransactionInfo, error) {
+			return &core.TransactionInfo{BlockNumber: 1, ContractAddress: created.Bytes()}, nil
+		},
+	}
+
+	deploy := newDummyTx()
+	deploy.RawData.Contract[0].Type = core.Transaction_Contract_CreateSmartContract
+	receipt, err := SendAndConfirm(context.Background(), b, &mockSigner{}, deploy, 10*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", receipt.ContractAddress)
+
+	// Nodes report the called contract for triggers; that is not a creation.
+	trigger := newDummyTx()
+	trigger.RawData.Contract[0].Type = core.Transaction_Contract_TriggerSmartContract
+	receipt, err = SendAndConfirm(context.Background(), b, &mockSigner{}, trigger, 10*time.Millisecond)
+	require.NoError(t, err)
+	assert.Empty(t, receipt.ContractAddress)
 }
 
 func TestSendAndConfirm_ContextCancelled(t *testing.T) {
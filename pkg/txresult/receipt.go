@@ -12,5 +12,8 @@ type Receipt struct {
 	BandwidthUsed int64
 	Fee           int64  // in SUN
 	Result        []byte // contract return data
-	Error         string // TRON error message if failed
+	// ContractAddress is the base58 address of the contract created by a
+	// confirmed CreateSmartContract transaction, empty otherwise.
+	ContractAddress string
+	Error           string // TRON error message if failed
 }
@@ -0,0 +1,245 @@
+package typeddata
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/common"
+)
+
+// encodeData returns typeHash || enc(field1) || ... || enc(fieldN).
+func (td *TypedData) encodeData(typeName string, data map[string]interface{}) ([]byte, error) {
+	typeHash, err := td.TypeHash(typeName)
+	if err != nil {
+		return nil, err
+	}
+	fields := td.Types[typeName]
+	encoded := make([]byte, 0, 32*(len(fields)+1))
+	encoded = append(encoded, typeHash...)
+	for _, field := range fields {
+		value, ok := data[field.Name]
+		if !ok {
+			return nil, fmt.Errorf("%s: missing value for field %s", typeName, field.Name)
+		}
+		word, err := td.encodeValue(field.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", typeName, field.Name, err)
+		}
+		encoded = append(encoded, word...)
+	}
+	return encoded, nil
+}
+
+// encodeValue returns the 32-byte encoding of value as typ.
+func (td *TypedData) encodeValue(typ string, value interface{}) ([]byte, error) {
+	if strings.HasSuffix(typ, "]") {
+		return td.encodeArray(typ, value)
+	}
+	if _, ok := td.Types[typ]; ok {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected object for %s, got %T", typ, value)
+		}
+		return td.HashStruct(typ, m)
+	}
+
+	switch {
+	case typ == "string":
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %T", value)
+		}
+		return crypto.Keccak256([]byte(s)), nil
+	case typ == "bytes":
+		b, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		return crypto.Keccak256(b), nil
+	case typ == "bool":
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected bool, got %T", value)
+		}
+		word := make([]byte, 32)
+		if b {
+			word[31] = 1
+		}
+		return word, nil
+	case typ == "address":
+		addr, err := toAddress(value)
+		if err != nil {
+			return nil, err
+		}
+		return append(make([]byte, 12), addr...), nil
+	case typ == "trcToken":
+		return encodeInteger("uint256", value)
+	case strings.HasPrefix(typ, "bytes"):
+		size, err := strconv.Atoi(typ[len("bytes"):])
+		if err != nil || size < 1 || size > 32 {
+			return nil, fmt.Errorf("unknown type %s", typ)
+		}
+		b, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) > size {
+			return nil, fmt.Errorf("%d bytes do not fit in %s", len(b), typ)
+		}
+		word := make([]byte, 32)
+		copy(word, b)
+		return word, nil
+	case strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "int"):
+		return encodeInteger(typ, value)
+	}
+	return nil, fmt.Errorf("unknown type %s", typ)
+}
+
+// encodeArray returns keccak256 of the concatenated encodings of the array
+// elements.
+func (td *TypedData) encodeArray(typ string, value interface{}) ([]byte, error) {
+	open := strings.LastIndexByte(typ, '[')
+	if open < 0 {
+		return nil, fmt.Errorf("unknown type %s", typ)
+	}
+	elemType, size := typ[:open], typ[open+1:len(typ)-1]
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected array for %s, got %T", typ, value)
+	}
+	if size != "" {
+		n, err := strconv.Atoi(size)
+		if err != nil {
+			return nil, fmt.Errorf("unknown type %s", typ)
+		}
+		if len(items) != n {
+			return nil, fmt.Errorf("expected %d elements for %s, got %d", n, typ, len(items))
+		}
+	}
+	encoded := make([]byte, 0, 32*len(items))
+	for i, item := range items {
+		word, err := td.encodeValue(elemType, item)
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %w", i, err)
+		}
+		encoded = append(encoded, word...)
+	}
+	return crypto.Keccak256(encoded), nil
+}
+
+// encodeInteger returns the two's complement word of an intN/uintN value
+// after checking that it fits in N bits.
+func encodeInteger(typ string, value interface{}) ([]byte, error) {
+	signed := strings.HasPrefix(typ, "int")
+	bitsStr := strings.TrimPrefix(strings.TrimPrefix(typ, "u"), "int")
+	bits := 256
+	if bitsStr != "" {
+		var err error
+		bits, err = strconv.Atoi(bitsStr)
+		if err != nil || bits < 8 || bits > 256 || bits%8 != 0 {
+			return nil, fmt.Errorf("unknown type %s", typ)
+		}
+	}
+	n, err := toBigInt(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var lo, hi *big.Int
+	if signed {
+		hi = new(big.Int).Lsh(big.NewInt(1), uint(bits-1))
+		lo = new(big.Int).Neg(hi)
+		hi.Sub(hi, big.NewInt(1))
+	} else {
+		lo = new(big.Int)
+		hi = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(bits)), big.NewInt(1))
+	}
+	if n.Cmp(lo) < 0 || n.Cmp(hi) > 0 {
+		return nil, fmt.Errorf("%s out of range for %s", n, typ)
+	}
+
+	if n.Sign() < 0 {
+		n = new(big.Int).Add(n, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+	return n.FillBytes(make([]byte, 32)), nil
+}
+
+// toBigInt accepts JSON numbers, decimal or 0x-prefixed hex strings, Go
+// integers and *big.Int.
+func toBigInt(value interface{}) (*big.Int, error) {
+	switch v := value.(type) {
+	case *big.Int:
+		return new(big.Int).Set(v), nil
+	case json.Number:
+		return parseBigInt(v.String())
+	case string:
+		return parseBigInt(v)
+	case float64:
+		if v != math.Trunc(v) || math.Abs(v) > 1<<53 {
+			return nil, fmt.Errorf("%v is not an exact integer", v)
+		}
+		return big.NewInt(int64(v)), nil
+	case int:
+		return big.NewInt(int64(v)), nil
+	case int64:
+		return big.NewInt(v), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	}
+	return nil, fmt.Errorf("expected integer, got %T", value)
+}
+
+func parseBigInt(s string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %q", s)
+	}
+	return n, nil
+}
+
+// toBytes accepts 0x-prefixed hex strings and byte slices.
+func toBytes(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		if !strings.HasPrefix(v, "0x") && !strings.HasPrefix(v, "0X") {
+			return nil, fmt.Errorf("expected 0x-prefixed hex, got %q", v)
+		}
+		return common.FromHex(v)
+	}
+	return nil, fmt.Errorf("expected hex bytes, got %T", value)
+}
+
+// toAddress accepts base58 TRON addresses and 20-byte hex addresses with an
+// optional 0x or 41 prefix, returning the 20-byte EVM form.
+func toAddress(value interface{}) ([]byte, error) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected address string, got %T", value)
+	}
+	if strings.HasPrefix(s, "T") {
+		addr, err := address.Base58ToAddress(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %s: %w", s, err)
+		}
+		return addr[1:], nil
+	}
+	b, err := common.FromHex(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %s: %w", s, err)
+	}
+	if len(b) == address.AddressLength && b[0] == address.TronBytePrefix {
+		b = b[1:]
+	}
+	if len(b) != 20 {
+		return nil, fmt.Errorf("invalid address %s", s)
+	}
+	return b, nil
+}
@@ -0,0 +1,237 @@
+// Package typeddata implements TIP-712, the TRON adaptation of EIP-712
+// typed structured data hashing and signing. It parses the standard JSON
+// document (types, primaryType, domain, message) and hashes it exactly as
+// EIP-712 does, except that address values may be given in TRON base58 or
+// 41-prefixed hex form and the trcToken type is supported. Both encode as
+// their 20-byte and uint256 EVM equivalents.
+package typeddata
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/signer"
+)
+
+// DomainType is the name of the domain separator struct type.
+const DomainType = "EIP712Domain"
+
+// ErrHashSigningUnsupported is returned by Sign when the signer cannot sign
+// raw digests (see signer.HashSigner).
+var ErrHashSigningUnsupported = errors.New("signer does not support signing raw hashes")
+
+// domainFields lists the EIP712Domain fields in canonical order, used when a
+// document omits the EIP712Domain type definition.
+var domainFields = []Type{
+	{Name: "name", Type: "string"},
+	{Name: "version", Type: "string"},
+	{Name: "chainId", Type: "uint256"},
+	{Name: "verifyingContract", Type: "address"},
+	{Name: "salt", Type: "bytes32"},
+}
+
+// Type is a single member of a struct type definition.
+type Type struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Types maps struct type names to their members.
+type Types map[string][]Type
+
+// TypedData is a TIP-712 document.
+type TypedData struct {
+	Types       Types                  `json:"types"`
+	PrimaryType string                 `json:"primaryType"`
+	Domain      map[string]interface{} `json:"domain"`
+	Message     map[string]interface{} `json:"message"`
+}
+
+// Parse decodes a TIP-712 JSON document. Numbers are kept as json.Number so
+// that uint256 values survive without loss. When the EIP712Domain type is
+// not declared, it is derived from the fields present in the domain.
+func Parse(data []byte) (*TypedData, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var td TypedData
+	if err := dec.Decode(&td); err != nil {
+		return nil, fmt.Errorf("invalid typed data: %w", err)
+	}
+	if td.Types == nil {
+		td.Types = Types{}
+	}
+	if _, ok := td.Types[DomainType]; !ok {
+		var fields []Type
+		for _, f := range domainFields {
+			if _, ok := td.Domain[f.Name]; ok {
+				fields = append(fields, f)
+			}
+		}
+		td.Types[DomainType] = fields
+	}
+	if td.PrimaryType == "" {
+		return nil, errors.New("invalid typed data: missing primaryType")
+	}
+	if _, ok := td.Types[td.PrimaryType]; !ok {
+		return nil, fmt.Errorf("invalid typed data: primaryType %s is not defined", td.PrimaryType)
+	}
+	return &td, nil
+}
+
+// Hash returns the digest to sign:
+// keccak256("\x19\x01" || domainSeparator || hashStruct(message)).
+func (td *TypedData) Hash() ([]byte, error) {
+	domainSeparator, err := td.DomainSeparator()
+	if err != nil {
+		return nil, err
+	}
+	messageHash, err := td.HashStruct(td.PrimaryType, td.Message)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Keccak256([]byte{0x19, 0x01}, domainSeparator, messageHash), nil
+}
+
+// DomainSeparator returns hashStruct(domain).
+func (td *TypedData) DomainSeparator() ([]byte, error) {
+	return td.HashStruct(DomainType, td.Domain)
+}
+
+// HashStruct returns keccak256(typeHash || encodeData(data)) for the named
+// struct type.
+func (td *TypedData) HashStruct(typeName string, data map[string]interface{}) ([]byte, error) {
+	encoded, err := td.encodeData(typeName, data)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Keccak256(encoded), nil
+}
+
+// TypeHash returns keccak256(EncodeType(typeName)).
+func (td *TypedData) TypeHash(typeName string) ([]byte, error) {
+	encoded, err := td.EncodeType(typeName)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Keccak256([]byte(encoded)), nil
+}
+
+// EncodeType returns the type encoding of typeName followed by its
+// referenced struct types in alphabetical order, e.g.
+// "Mail(Person from,Person to,string contents)Person(string name,address wallet)".
+func (td *TypedData) EncodeType(typeName string) (string, error) {
+	if _, ok := td.Types[typeName]; !ok {
+		return "", fmt.Errorf("type %s is not defined", typeName)
+	}
+	deps := make(map[string]bool)
+	td.dependencies(typeName, deps)
+	delete(deps, typeName)
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range append([]string{typeName}, names...) {
+		b.WriteString(name)
+		b.WriteByte('(')
+		for i, field := range td.Types[name] {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(field.Type)
+			b.WriteByte(' ')
+			b.WriteString(field.Name)
+		}
+		b.WriteByte(')')
+	}
+	return b.String(), nil
+}
+
+// dependencies collects typeName and every struct type it references.
+func (td *TypedData) dependencies(typeName string, found map[string]bool) {
+	if found[typeName] {
+		return
+	}
+	found[typeName] = true
+	for _, field := range td.Types[typeName] {
+		base := baseType(field.Type)
+		if _, ok := td.Types[base]; ok {
+			td.dependencies(base, found)
+		}
+	}
+}
+
+// baseType strips every array suffix from a type name.
+func baseType(typ string) string {
+	if i := strings.IndexByte(typ, '['); i >= 0 {
+		return typ[:i]
+	}
+	return typ
+}
+
+// Sign hashes td and signs the digest with s, which must implement
+// signer.HashSigner. The signature is 65 bytes [R || S || V] with V set to 27
+// or 28, as TronWeb and wallets produce.
+func Sign(td *TypedData, s signer.Signer) ([]byte, error) {
+	hs, ok := s.(signer.HashSigner)
+	if !ok {
+		return nil, ErrHashSigningUnsupported
+	}
+	hash, err := td.Hash()
+	if err != nil {
+		return nil, err
+	}
+	sig, err := hs.SignHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	if len(sig) != 65 {
+		return nil, fmt.Errorf("invalid signature length: %d/65", len(sig))
+	}
+	if sig[64] < 27 {
+		sig[64] += 27
+	}
+	return sig, nil
+}
+
+// Recover returns the address that produced sig over td. V may be given as
+// 0/1 or 27/28.
+func Recover(td *TypedData, sig []byte) (address.Address, error) {
+	if len(sig) != 65 {
+		return nil, fmt.Errorf("invalid signature length: %d/65", len(sig))
+	}
+	hash, err := td.Hash()
+	if err != nil {
+		return nil, err
+	}
+	normalized := append([]byte(nil), sig...)
+	if normalized[64] >= 27 {
+		normalized[64] -= 27
+	}
+	pub, err := crypto.SigToPub(hash, normalized)
+	if err != nil {
+		return nil, err
+	}
+	return address.PubkeyToAddress(*pub), nil
+}
+
+// Verify reports whether sig over td was produced by addr (base58).
+func Verify(td *TypedData, sig []byte, addr string) (bool, error) {
+	want, err := address.Base58ToAddress(addr)
+	if err != nil {
+		return false, fmt.Errorf("invalid address %s: %w", addr, err)
+	}
+	got, err := Recover(td, sig)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(got, want), nil
+}
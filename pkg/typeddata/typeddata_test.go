@@ -0,0 +1,172 @@
+package typeddata
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fbsobreira/gotron-sdk/pkg/address"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/fbsobreira/gotron-sdk/pkg/signer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mailJSON is the example from the EIP-712 specification.
+const mailJSON = `{
+  "types": {
+    "EIP712Domain": [
+      {"name": "name", "type": "string"},
+      {"name": "version", "type": "string"},
+      {"name": "chainId", "type": "uint256"},
+      {"name": "verifyingContract", "type": "address"}
+    ],
+    "Person": [
+      {"name": "name", "type": "string"},
+      {"name": "wallet", "type": "address"}
+    ],
+    "Mail": [
+      {"name": "from", "type": "Person"},
+      {"name": "to", "type": "Person"},
+      {"name": "contents", "type": "string"}
+    ]
+  },
+  "primaryType": "Mail",
+  "domain": {
+    "name": "Ether Mail",
+    "version": "1",
+    "chainId": 1,
+    "verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+  },
+  "message": {
+    "from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+    "to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+    "contents": "Hello, Bob!"
+  }
+}`
+
+func tronAddress(t *testing.T, hexAddr string) string {
+	b, err := hex.DecodeString(hexAddr)
+	require.NoError(t, err)
+	return address.Address(append([]byte{address.TronBytePrefix}, b...)).String()
+}
+
+func TestMailVector(t *testing.T) {
+	td, err := Parse([]byte(mailJSON))
+	require.NoError(t, err)
+
+	encoded, err := td.EncodeType("Mail")
+	require.NoError(t, err)
+	assert.Equal(t, "Mail(Person from,Person to,string contents)Person(string name,address wallet)", encoded)
+
+	domain, err := td.DomainSeparator()
+	require.NoError(t, err)
+	assert.Equal(t, "f2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f", hex.EncodeToString(domain))
+
+	hash, err := td.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, "be609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", hex.EncodeToString(hash))
+
+	// TRON address forms hash identically.
+	td.Domain["verifyingContract"] = tronAddress(t, "cccccccccccccccccccccccccccccccccccccccc")
+	td.Message["to"].(map[string]interface{})["wallet"] = "41bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	tronHash, err := td.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, hash, tronHash)
+}
+
+func TestSignAndRecover(t *testing.T) {
+	td, err := Parse([]byte(mailJSON))
+	require.NoError(t, err)
+
+	key := crypto.ToECDSAUnsafe(crypto.Keccak256([]byte("cow")))
+	s, err := signer.NewPrivateKeySigner(key)
+	require.NoError(t, err)
+
+	sig, err := Sign(td, s)
+	require.NoError(t, err)
+	// Signature from the EIP-712 specification.
+	assert.Equal(t,
+		"4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d"+
+			"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562"+"1c",
+		hex.EncodeToString(sig))
+
+	signerAddr, err := Recover(td, sig)
+	require.NoError(t, err)
+	assert.Equal(t, s.Address(), signerAddr)
+	assert.Equal(t, tronAddress(t, "cd2a3d9f938e13cd947ec05abc7fe734df8dd826"), signerAddr.String())
+
+	ok, err := Verify(td, sig, signerAddr.String())
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	td.Message["contents"] = "Hello, Alice!"
+	ok, err = Verify(td, sig, signerAddr.String())
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+// txOnlySigner cannot sign raw hashes.
+type txOnlySigner struct{}
+
+func (txOnlySigner) Sign(tx *core.Transaction) (*core.Transaction, error) { return tx, nil }
+func (txOnlySigner) Address() address.Address                             { return nil }
+
+func TestSignRequiresHashSigner(t *testing.T) {
+	td, err := Parse([]byte(mailJSON))
+	require.NoError(t, err)
+	_, err = Sign(td, txOnlySigner{})
+	assert.ErrorIs(t, err, ErrHashSigningUnsupported)
+}
+
+func TestEncodeValues(t *testing.T) {
+	doc := `{
+	  "types": {
+	    "Order": [
+	      {"name": "token", "type": "trcToken"},
+	      {"name": "amount", "type": "uint256"},
+	      {"name": "delta", "type": "int8"},
+	      {"name": "ok", "type": "bool"},
+	      {"name": "tag", "type": "bytes4"},
+	      {"name": "payload", "type": "bytes"},
+	      {"name": "ids", "type": "uint64[2]"}
+	    ]
+	  },
+	  "primaryType": "Order",
+	  "domain": {"name": "DEX", "chainId": 728126428},
+	  "message": {
+	    "token": 1002000,
+	    "amount": "115792089237316195423570985008687907853269984665640564039457584007913129639935",
+	    "delta": -1,
+	    "ok": true,
+	    "tag": "0xdeadbeef",
+	    "payload": "0x01",
+	    "ids": [1, "0x2"]
+	  }
+	}`
+	td, err := Parse([]byte(doc))
+	require.NoError(t, err)
+	assert.Equal(t, []Type{{Name: "name", Type: "string"}, {Name: "chainId", Type: "uint256"}}, td.Types[DomainType])
+	_, err = td.Hash()
+	require.NoError(t, err)
+
+	word, err := td.encodeValue("int8", -1)
+	require.NoError(t, err)
+	assert.Equal(t, "ff", hex.EncodeToString(word[:1]))
+
+	_, err = td.encodeValue("int8", 128)
+	assert.ErrorContains(t, err, "out of range")
+	_, err = td.encodeValue("uint64[2]", []interface{}{1})
+	assert.ErrorContains(t, err, "expected 2 elements")
+	_, err = td.encodeValue("bytes2", "0xdeadbeef")
+	assert.Error(t, err)
+	_, err = td.encodeValue("address", "TNotAnAddress")
+	assert.Error(t, err)
+
+	delete(td.Message, "ok")
+	_, err = td.Hash()
+	assert.ErrorContains(t, err, "missing value for field ok")
+
+	_, err = Parse([]byte(`{"types": {}, "primaryType": "Missing", "domain": {}, "message": {}}`))
+	assert.ErrorContains(t, err, "primaryType Missing is not defined")
+}